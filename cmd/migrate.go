@@ -0,0 +1,55 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"live-scheduler/infra"
+)
+
+// runMigrate migrate up|down|status サブコマンドを実行する
+func runMigrate(db *sql.DB, args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: migrate up|down|status")
+	}
+	migrations, err := infra.LoadMigrations()
+	if err != nil {
+		return err
+	}
+	migrator := infra.NewMigrator(db, migrations)
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up()
+		for _, migration := range applied {
+			fmt.Printf("applied %04d_%s\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Println("no pending migrations")
+		}
+	case "down":
+		migration, err := migrator.Down()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("rolled back %04d_%s\n", migration.Version, migration.Name)
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d_%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+	default:
+		return fmt.Errorf("unknown migrate command: %s (usage: migrate up|down|status)", args[0])
+	}
+	return nil
+}
@@ -2,55 +2,191 @@ package main
 
 import (
 	"database/sql"
+	"flag"
 	"fmt"
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/labstack/echo/v4"
 	"live-scheduler/domain"
-	"live-scheduler/infra"
 	"live-scheduler/presentation"
 	"log"
 	"os"
+	"strconv"
+	"time"
 )
 
 func main() {
 	user := os.Getenv("USER")
 	pass := os.Getenv("PASS")
-	dataSourceName := fmt.Sprintf("%s:%s@tcp(localhost:3306)/sample?parseTime=true", user, pass)
-	db, err := sql.Open("mysql", dataSourceName)
-	defer db.Close()
-	if err != nil {
-		log.Fatalln("db connection initialization failed.", err)
+	feeRule := domain.FeeRule(os.Getenv("FEE_RULE"))
+	if feeRule == "" {
+		feeRule = domain.ChargeOnce
 	}
+	if !feeRule.IsValid() {
+		log.Fatalln("unknown FEE_RULE.", feeRule)
+	}
+	changeover := 10
+	if value, ok := os.LookupEnv("CHANGEOVER"); ok {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			log.Fatalln("CHANGEOVER must be a non-negative integer.", value)
+		}
+		changeover = parsed
+	}
+	storeName := flag.String("store", "mysql", "data store: mysql or memory")
+	fixture := flag.String("fixture", "", "JSON file loaded at startup with --store=memory")
+	flag.Parse()
+
+	var repositories *stores
+	switch *storeName {
+	case "mysql":
+		dataSourceName := fmt.Sprintf("%s:%s@tcp(localhost:3306)/sample?parseTime=true", user, pass)
+		db, err := sql.Open("mysql", dataSourceName)
+		if err != nil {
+			log.Fatalln("db connection initialization failed.", err)
+		}
+		defer db.Close()
+
+		if flag.Arg(0) == "migrate" {
+			if err := runMigrate(db, flag.Args()[1:]); err != nil {
+				log.Fatalln("migration failed.", err)
+			}
+			return
+		}
+		repositories = newMySQLStores(db)
+	case "memory":
+		loaded, err := newMemoryStores(*fixture)
+		if err != nil {
+			log.Fatalln("fixture loading failed.", err)
+		}
+		repositories = loaded
+	default:
+		log.Fatalln("unknown store.", *storeName)
+	}
+
+	// ライブ詳細はプロセス内に保持し、出演バンドやメンバーへの書き込みでライブのバージョンを上げて破棄する。
+	// 出演バンドやメンバーへの書き込みはライブの状態が受け付けない場合に拒否する。
+	// トランザクションの外の書き込みも1件ずつ unitOfWork を通し、状態の確認とバージョンの更新を同じトランザクションで行う
+	liveCacheSize, err := strconv.Atoi(os.Getenv("LIVE_CACHE_SIZE"))
+	if err != nil || liveCacheSize <= 0 {
+		liveCacheSize = 100
+	}
+	liveDescService := domain.NewCachedLiveDescService(domain.NewLiveDescServiceImpl(repositories.liveDesc), liveCacheSize)
+	unitOfWork := domain.NewTrackingUnitOfWork(domain.NewGuardedUnitOfWork(repositories.unitOfWork), liveDescService)
+	autoCommitted := domain.AutoCommitWrites(&domain.Repositories{
+		Live:             repositories.live,
+		Band:             repositories.band,
+		BandMember:       repositories.bandMember,
+		Player:           repositories.player,
+		Payment:          repositories.payment,
+		LineupRule:       repositories.lineupRule,
+		BandProfile:      repositories.bandProfile,
+		EntryApplication: repositories.entryApplication,
+	}, unitOfWork)
 
-	liveRepository := infra.NewLiveRepositoryImpl(db)
-	bandRepository := infra.NewBandRepositoryImpl(db)
-	bandMemberRepository := infra.NewBandMemberRepositoryImpl(db)
-	playerRepository := infra.NewPlayerRepositoryImpl(db)
+	liveRepository := autoCommitted.Live
+	bandRepository := autoCommitted.Band
+	bandMemberRepository := autoCommitted.BandMember
+	playerRepository := autoCommitted.Player
+	paymentRepository := autoCommitted.Payment
+	lineupRuleRepository := autoCommitted.LineupRule
+	bandProfileRepository := autoCommitted.BandProfile
+	partRepository := repositories.part
+	entryApplicationRepository := autoCommitted.EntryApplication
 
-	liveDescService := domain.NewLiveDescServiceImpl(liveRepository, bandRepository, bandMemberRepository)
-	liveService := domain.NewLiveServiceImpl(liveRepository)
-	bandService := domain.NewBandServiceImpl(bandRepository)
-	bandMemberService := domain.NewBandMemberServiceImpl(bandMemberRepository)
-	playerService := domain.NewPlayerServiceImpl(playerRepository)
+	timetableService := domain.NewTimetableServiceImpl(liveRepository, bandRepository, changeover)
+	conflictService := domain.NewConflictServiceImpl(liveRepository, bandRepository, bandMemberRepository)
+	liveService := domain.NewLiveServiceImpl(liveRepository, unitOfWork, timetableService)
+	bandService := domain.NewBandServiceImpl(bandRepository, unitOfWork, timetableService)
+	bandMemberService := domain.NewBandMemberServiceImpl(bandMemberRepository, playerRepository, unitOfWork)
+	playerService := domain.NewPlayerServiceImpl(playerRepository, bandMemberRepository, unitOfWork)
+	lineupService := domain.NewLineupServiceImpl(unitOfWork, timetableService)
+	settlementService := domain.NewSettlementServiceImpl(liveDescService, feeRule)
+	paymentService := domain.NewPaymentServiceImpl(paymentRepository, liveRepository, playerRepository, unitOfWork, settlementService)
+	lineupRuleService := domain.NewLineupRuleServiceImpl(lineupRuleRepository, unitOfWork)
+	runningOrderService := domain.NewRunningOrderServiceImpl(liveDescService, bandService)
+	partService := domain.NewPartServiceImpl(partRepository)
+	bandProfileService := domain.NewBandProfileServiceImpl(bandProfileRepository, bandRepository, unitOfWork)
+	liveStatusService := domain.NewLiveStatusServiceImpl(liveDescService, lineupRuleService, timetableService, unitOfWork, time.Now)
+	entryApplicationService := domain.NewEntryApplicationServiceImpl(entryApplicationRepository, unitOfWork, timetableService, time.Now)
 
 	e := echo.New()
-	handler := presentation.NewLiveHandler(liveService, liveDescService, bandService, bandMemberService, playerService)
+	handler := presentation.NewLiveHandler(liveService, liveDescService, bandService, bandMemberService, playerService, lineupService, lineupRuleService, partService)
+	settlementHandler := presentation.NewSettlementHandler(settlementService)
+	paymentHandler := presentation.NewPaymentHandler(paymentService)
+	timetableHandler := presentation.NewTimetableHandler(timetableService)
+	conflictHandler := presentation.NewConflictHandler(conflictService)
+	lineupRuleHandler := presentation.NewLineupRuleHandler(lineupRuleService, liveDescService, partService)
+	runningOrderHandler := presentation.NewRunningOrderHandler(runningOrderService, bandService)
+	bandProfileHandler := presentation.NewBandProfileHandler(bandProfileService, partService)
+	memberHandler := presentation.NewMemberHandler(playerService, partService)
+	partHandler := presentation.NewPartHandler(partService)
+	liveStatusHandler := presentation.NewLiveStatusHandler(liveStatusService)
+	entryApplicationHandler := presentation.NewEntryApplicationHandler(entryApplicationService, partService)
 	e.Validator = presentation.NewCustomValidator()
+	e.HTTPErrorHandler = presentation.ErrorHandler
 
 	e.GET("/live", handler.GetLives)
 	e.GET("/live/:id", handler.GetLive)
 	e.POST("/live", handler.PostLive)
 	e.PATCH("/live", handler.PatchLive)
 	e.DELETE("/live/:id", handler.DeleteLive)
+	e.POST("/live/:id/transitions", liveStatusHandler.PostLiveTransition)
 
 	e.GET("/live/:id/band", handler.GetBand)
 	e.POST("/live/:id/band", handler.PostBand)
+	e.POST("/live/:id/lineup", handler.PostLineup)
+	e.POST("/live/:id/band/move", handler.PostBandMove)
+	e.POST("/live/:id/band/swap", handler.PostBandSwap)
+	e.PUT("/live/:id/band/order", handler.PutBandOrder)
+	e.POST("/live/:id/band/compact", handler.PostBandCompact)
+	e.POST("/live/:id/running-order", runningOrderHandler.PostRunningOrder)
+	e.POST("/live/:id/running-order/apply", runningOrderHandler.PostRunningOrderApply)
+	e.GET("/live/:live_id/band/:turn", handler.GetBandByTurn)
 	e.PATCH("/live/:live_id/band/:turn", handler.PatchBand)
 	e.DELETE("/live/:live_id/band/:turn", handler.DeleteBand)
 
+	e.GET("/live/:id/application", entryApplicationHandler.GetEntryApplications)
+	e.POST("/live/:id/application", entryApplicationHandler.PostEntryApplication)
+	e.GET("/live/:live_id/application/:application_id", entryApplicationHandler.GetEntryApplication)
+	e.POST("/live/:live_id/application/:application_id/approve", entryApplicationHandler.PostEntryApplicationApprove)
+	e.POST("/live/:live_id/application/:application_id/reject", entryApplicationHandler.PostEntryApplicationReject)
+	e.POST("/live/:live_id/application/:application_id/waitlist", entryApplicationHandler.PostEntryApplicationWaitlist)
+
+	e.GET("/live/:live_id/band/:turn/member", handler.GetBandMember)
+	e.POST("/live/:live_id/band/:turn/member", handler.PostBandMember)
+	e.PUT("/live/:live_id/band/:turn/member", handler.PutBandMember)
+	e.DELETE("/live/:live_id/band/:turn/member", handler.DeleteBandMember)
+	e.POST("/live/:live_id/band/:turn/member/copy", bandProfileHandler.PostBandRosterCopy)
+
+	e.GET("/band", bandProfileHandler.GetBandProfiles)
+	e.POST("/band", bandProfileHandler.PostBandProfile)
+	e.GET("/band/:id", bandProfileHandler.GetBandProfile)
+	e.PATCH("/band/:id", bandProfileHandler.PatchBandProfile)
+	e.PUT("/band/:id/member", bandProfileHandler.PutBandRoster)
+	e.GET("/band/:id/history", bandProfileHandler.GetBandHistory)
+
+	e.GET("/live/:id/settlement", settlementHandler.GetSettlement)
+	e.GET("/live/:id/timetable", timetableHandler.GetTimetable)
+	e.GET("/live/:id/conflict", conflictHandler.GetConflict)
+	e.GET("/live/:id/rule", lineupRuleHandler.GetLineupRule)
+	e.PUT("/live/:id/rule", lineupRuleHandler.PutLineupRule)
+	e.GET("/live/:id/validation", lineupRuleHandler.GetValidation)
+	e.GET("/live/:id/payment", paymentHandler.GetPayment)
+	e.POST("/live/:id/payment", paymentHandler.PostPayment)
+	e.GET("/payment/reconciliation", paymentHandler.GetReconciliation)
+
+	e.GET("/part", partHandler.GetParts)
+	e.PUT("/part/:code", partHandler.PutPart)
+	e.DELETE("/part/:code", partHandler.DeletePart)
+
 	e.GET("/member", handler.GetPart)
 	e.POST("/member/create", handler.PostPart)
 	e.POST("/member/delete", handler.DeletePart)
+	e.GET("/member/:id", memberHandler.GetMember)
+	e.PATCH("/member/:id", memberHandler.PatchMember)
+	e.POST("/member/:id/part", memberHandler.PostMemberPart)
+	e.DELETE("/member/:id/part", memberHandler.DeleteMemberPart)
+	e.GET("/member/:name/history", memberHandler.GetMemberHistory)
 
 	e.Logger.Fatal(e.Start(":1323"))
 }
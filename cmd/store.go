@@ -0,0 +1,68 @@
+package main
+
+import (
+	"database/sql"
+	"live-scheduler/domain"
+	"live-scheduler/infra"
+	"live-scheduler/infra/memory"
+	"os"
+)
+
+// stores 永続化先ごとのリポジトリの組
+type stores struct {
+	live             domain.LiveRepository
+	liveDesc         domain.LiveDescRepository
+	band             domain.BandRepository
+	bandMember       domain.BandMemberRepository
+	player           domain.PlayerRepository
+	payment          domain.PaymentRepository
+	lineupRule       domain.LineupRuleRepository
+	bandProfile      domain.BandProfileRepository
+	part             domain.PartRepository
+	entryApplication domain.EntryApplicationRepository
+	unitOfWork       domain.UnitOfWork
+}
+
+func newMySQLStores(db *sql.DB) *stores {
+	return &stores{
+		live:             infra.NewLiveRepositoryImpl(db),
+		liveDesc:         infra.NewLiveDescRepositoryImpl(db),
+		band:             infra.NewBandRepositoryImpl(db),
+		bandMember:       infra.NewBandMemberRepositoryImpl(db),
+		player:           infra.NewPlayerRepositoryImpl(db),
+		payment:          infra.NewPaymentRepositoryImpl(db),
+		lineupRule:       infra.NewLineupRuleRepositoryImpl(db),
+		bandProfile:      infra.NewBandProfileRepositoryImpl(db),
+		part:             infra.NewPartRepositoryImpl(db),
+		entryApplication: infra.NewEntryApplicationRepositoryImpl(db),
+		unitOfWork:       infra.NewUnitOfWorkImpl(db),
+	}
+}
+
+// newMemoryStores プロセス内に保持するリポジトリを返す。fixture を指定した場合は JSON のデータを登録する
+func newMemoryStores(fixture string) (*stores, error) {
+	store := memory.NewStore()
+	if fixture != "" {
+		file, err := os.Open(fixture)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		if err := memory.LoadFixture(store, file); err != nil {
+			return nil, err
+		}
+	}
+	return &stores{
+		live:             memory.NewLiveRepositoryImpl(store),
+		liveDesc:         memory.NewLiveDescRepositoryImpl(store),
+		band:             memory.NewBandRepositoryImpl(store),
+		bandMember:       memory.NewBandMemberRepositoryImpl(store),
+		player:           memory.NewPlayerRepositoryImpl(store),
+		payment:          memory.NewPaymentRepositoryImpl(store),
+		lineupRule:       memory.NewLineupRuleRepositoryImpl(store),
+		bandProfile:      memory.NewBandProfileRepositoryImpl(store),
+		part:             memory.NewPartRepositoryImpl(store),
+		entryApplication: memory.NewEntryApplicationRepositoryImpl(store),
+		unitOfWork:       memory.NewUnitOfWorkImpl(store),
+	}, nil
+}
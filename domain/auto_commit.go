@@ -0,0 +1,125 @@
+package domain
+
+// AutoCommitWrites トランザクションの外で使うリポジトリの組を返す。
+// TrackLiveChanges と GuardLineupChanges が扱う書き込みは、状態の確認やライブのバージョンの更新と同じトランザクションにするため、
+// 1件ずつ unitOfWork の中で実行する。読み込みとそれ以外の書き込みは repositories をそのまま使う
+func AutoCommitWrites(repositories *Repositories, unitOfWork UnitOfWork) *Repositories {
+	return &Repositories{
+		Live:             &autoCommitLiveRepository{LiveRepository: repositories.Live, unitOfWork: unitOfWork},
+		Band:             &autoCommitBandRepository{BandRepository: repositories.Band, unitOfWork: unitOfWork},
+		BandMember:       &autoCommitBandMemberRepository{BandMemberRepository: repositories.BandMember, unitOfWork: unitOfWork},
+		Player:           &autoCommitPlayerRepository{PlayerRepository: repositories.Player, unitOfWork: unitOfWork},
+		Payment:          repositories.Payment,
+		LineupRule:       &autoCommitLineupRuleRepository{LineupRuleRepository: repositories.LineupRule, unitOfWork: unitOfWork},
+		BandProfile:      &autoCommitBandProfileRepository{BandProfileRepository: repositories.BandProfile, unitOfWork: unitOfWork},
+		EntryApplication: repositories.EntryApplication,
+	}
+}
+
+type autoCommitLiveRepository struct {
+	LiveRepository
+	unitOfWork UnitOfWork
+}
+
+func (r *autoCommitLiveRepository) Patch(id int, version int, patch *LivePatch) error {
+	return r.unitOfWork.Do(func(repositories *Repositories) error {
+		return repositories.Live.Patch(id, version, patch)
+	})
+}
+
+func (r *autoCommitLiveRepository) Delete(id int, version int) error {
+	return r.unitOfWork.Do(func(repositories *Repositories) error {
+		return repositories.Live.Delete(id, version)
+	})
+}
+
+type autoCommitBandRepository struct {
+	BandRepository
+	unitOfWork UnitOfWork
+}
+
+func (r *autoCommitBandRepository) Create(band *Band) error {
+	return r.unitOfWork.Do(func(repositories *Repositories) error {
+		return repositories.Band.Create(band)
+	})
+}
+
+func (r *autoCommitBandRepository) Patch(id int, turn int, version int, patch *BandPatch) error {
+	return r.unitOfWork.Do(func(repositories *Repositories) error {
+		return repositories.Band.Patch(id, turn, version, patch)
+	})
+}
+
+func (r *autoCommitBandRepository) Delete(id int, turn int, version int) error {
+	return r.unitOfWork.Do(func(repositories *Repositories) error {
+		return repositories.Band.Delete(id, turn, version)
+	})
+}
+
+func (r *autoCommitBandRepository) DeleteByLiveId(id int) error {
+	return r.unitOfWork.Do(func(repositories *Repositories) error {
+		return repositories.Band.DeleteByLiveId(id)
+	})
+}
+
+type autoCommitBandMemberRepository struct {
+	BandMemberRepository
+	unitOfWork UnitOfWork
+}
+
+func (r *autoCommitBandMemberRepository) Create(bandMember *BandMember) error {
+	return r.unitOfWork.Do(func(repositories *Repositories) error {
+		return repositories.BandMember.Create(bandMember)
+	})
+}
+
+func (r *autoCommitBandMemberRepository) Delete(bandMember *BandMember) error {
+	return r.unitOfWork.Do(func(repositories *Repositories) error {
+		return repositories.BandMember.Delete(bandMember)
+	})
+}
+
+func (r *autoCommitBandMemberRepository) Update(current *BandMember, replacement *BandMember) error {
+	return r.unitOfWork.Do(func(repositories *Repositories) error {
+		return repositories.BandMember.Update(current, replacement)
+	})
+}
+
+func (r *autoCommitBandMemberRepository) DeleteByLiveId(id int) error {
+	return r.unitOfWork.Do(func(repositories *Repositories) error {
+		return repositories.BandMember.DeleteByLiveId(id)
+	})
+}
+
+type autoCommitPlayerRepository struct {
+	PlayerRepository
+	unitOfWork UnitOfWork
+}
+
+func (r *autoCommitPlayerRepository) Rename(id int, name string) error {
+	return r.unitOfWork.Do(func(repositories *Repositories) error {
+		return repositories.Player.Rename(id, name)
+	})
+}
+
+type autoCommitLineupRuleRepository struct {
+	LineupRuleRepository
+	unitOfWork UnitOfWork
+}
+
+func (r *autoCommitLineupRuleRepository) Save(rule *LineupRule) error {
+	return r.unitOfWork.Do(func(repositories *Repositories) error {
+		return repositories.LineupRule.Save(rule)
+	})
+}
+
+type autoCommitBandProfileRepository struct {
+	BandProfileRepository
+	unitOfWork UnitOfWork
+}
+
+func (r *autoCommitBandProfileRepository) Rename(id int, name string) error {
+	return r.unitOfWork.Do(func(repositories *Repositories) error {
+		return repositories.BandProfile.Rename(id, name)
+	})
+}
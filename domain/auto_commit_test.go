@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+// CountingUnitOfWorkMock 実行した回数を数える UnitOfWork
+type CountingUnitOfWorkMock struct {
+	repositories *Repositories
+	count        int
+}
+
+func (u *CountingUnitOfWorkMock) Do(fn func(repositories *Repositories) error) error {
+	u.count++
+	return fn(u.repositories)
+}
+
+func TestAutoCommitWrites(t *testing.T) {
+	// given
+	expectedError := fmt.Errorf("dummy message")
+
+	tests := []struct {
+		// テスト名
+		testName string
+		// トランザクションの外からの書き込み
+		write func(repositories *Repositories) error
+		// トランザクションの中の Create の戻り値
+		createError error
+		// トランザクションを実行する回数
+		expectedCount int
+		// 戻り値の期待値(error)
+		expectedError error
+	}{
+		{
+			testName:      "正常系_出演バンドの登録はトランザクションの中で行う",
+			write:         func(r *Repositories) error { return r.Band.Create(&Band{Name: "band", LiveId: 1, Turn: 1}) },
+			expectedCount: 1,
+		},
+		{
+			testName:      "異常系_トランザクションの中のエラーを返す",
+			write:         func(r *Repositories) error { return r.Band.Create(&Band{Name: "band", LiveId: 1, Turn: 1}) },
+			createError:   expectedError,
+			expectedCount: 1,
+			expectedError: expectedError,
+		},
+		{
+			testName: "正常系_読み込みはトランザクションの外で行う",
+			write: func(r *Repositories) error {
+				_, err := r.Band.FindByLiveId(1)
+				return err
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		outer := new(BandRepositoryMock)
+		outer.On("FindByLiveId", 1).Return([]*Band{}, nil)
+		inner := new(BandRepositoryMock)
+		inner.On("Create", mock.Anything).Return(tc.createError)
+		unitOfWork := &CountingUnitOfWorkMock{repositories: &Repositories{Band: inner}}
+		repositories := AutoCommitWrites(&Repositories{Band: outer}, unitOfWork)
+
+		// when
+		err := tc.write(repositories)
+
+		// then
+		assert.Equal(t, tc.expectedError, err, fmt.Sprintf("テスト名: %s", tc.testName))
+		assert.Equal(t, tc.expectedCount, unitOfWork.count, fmt.Sprintf("テスト名: %s", tc.testName))
+		outer.AssertNotCalled(t, "Create", mock.Anything)
+	}
+}
@@ -1,34 +1,140 @@
 package domain
 
+import (
+	"fmt"
+)
+
+// ErrPlayerNotFound Player テーブルに登録されていないメンバーを指定した場合のエラー
+var ErrPlayerNotFound = newError(ErrNotFound, "player not found")
+
 type BandMemberService interface {
-	Register(bandMember *BandMember) error
+	// Register メンバーを登録し、登録したメンバーの出演の重複を返す
+	Register(bandMember *BandMember) ([]*Conflict, error)
 	GetByLiveIdAndTurn(id int, turn int) ([]*Player, error)
-	Update(bandMember *BandMember, id int, turn int) error
+	// Update メンバーを置き換え、置き換えたメンバーの出演の重複を返す
+	Update(current *BandMember, replacement *BandMember) ([]*Conflict, error)
 	Delete(bandMember *BandMember) error
 }
 
 type BandMemberServiceImpl struct {
 	bandMemberRepository BandMemberRepository
+	playerRepository     PlayerRepository
+	unitOfWork           UnitOfWork
 }
 
-func NewBandMemberServiceImpl(bandMemberRepository BandMemberRepository) *BandMemberServiceImpl {
+func NewBandMemberServiceImpl(bandMemberRepository BandMemberRepository, playerRepository PlayerRepository, unitOfWork UnitOfWork) *BandMemberServiceImpl {
 	return &BandMemberServiceImpl{
 		bandMemberRepository: bandMemberRepository,
+		playerRepository:     playerRepository,
+		unitOfWork:           unitOfWork,
 	}
 }
 
-func (b *BandMemberServiceImpl) Register(bandMember *BandMember) error {
-	return b.bandMemberRepository.Create(bandMember)
+// Register 登録と出演の重複の確認を同じトランザクションで行い、確認に失敗した場合は登録しない
+func (b *BandMemberServiceImpl) Register(bandMember *BandMember) ([]*Conflict, error) {
+	var conflicts []*Conflict
+	err := b.unitOfWork.Do(func(repositories *Repositories) error {
+		if err := resolveBandMember(repositories.Player, bandMember); err != nil {
+			return err
+		}
+		if err := repositories.BandMember.Create(bandMember); err != nil {
+			return err
+		}
+		checked, err := checkPlayerConflicts(repositories, bandMember)
+		if err != nil {
+			return err
+		}
+		conflicts = checked
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return conflicts, nil
 }
 
 func (b *BandMemberServiceImpl) GetByLiveIdAndTurn(id int, turn int) ([]*Player, error) {
 	return b.bandMemberRepository.FindByLiveIdAndTurn(id, turn)
 }
 
-func (b *BandMemberServiceImpl) Update(bandMember *BandMember, id int, turn int) error {
-	return b.bandMemberRepository.Update(bandMember, id, turn)
+// Update 置き換えと出演の重複の確認を同じトランザクションで行い、確認に失敗した場合は置き換えない
+func (b *BandMemberServiceImpl) Update(current *BandMember, replacement *BandMember) ([]*Conflict, error) {
+	var conflicts []*Conflict
+	err := b.unitOfWork.Do(func(repositories *Repositories) error {
+		if err := resolveBandMember(repositories.Player, replacement); err != nil {
+			return err
+		}
+		memberId, err := resolveMemberId(repositories.Player, current.MemberName)
+		if err != nil {
+			return err
+		}
+		current.MemberId = memberId
+		if err := repositories.BandMember.Update(current, replacement); err != nil {
+			return err
+		}
+		checked, err := checkPlayerConflicts(repositories, replacement)
+		if err != nil {
+			return err
+		}
+		conflicts = checked
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return conflicts, nil
 }
 
 func (b *BandMemberServiceImpl) Delete(bandMember *BandMember) error {
+	memberId, err := resolveMemberId(b.playerRepository, bandMember.MemberName)
+	if err != nil {
+		return err
+	}
+	bandMember.MemberId = memberId
 	return b.bandMemberRepository.Delete(bandMember)
 }
+
+// resolveBandMember バンドメンバーのメンバーIDを設定し、担当するパートを担当できるか確認する
+func resolveBandMember(playerRepository PlayerRepository, bandMember *BandMember) error {
+	player := &Player{Name: bandMember.MemberName, Part: bandMember.MemberPart}
+	if err := resolvePlayer(playerRepository, player); err != nil {
+		return err
+	}
+	bandMember.MemberId = player.MemberId
+	return nil
+}
+
+// checkPlayerConflicts トランザクション内のリポジトリで、書き込んだバンドメンバーの出演の重複を返す
+func checkPlayerConflicts(repositories *Repositories, bandMember *BandMember) ([]*Conflict, error) {
+	return NewConflictServiceImpl(repositories.Live, repositories.Band, repositories.BandMember).CheckPlayer(bandMember.LiveId, bandMember.MemberName)
+}
+
+// checkLiveConflicts トランザクション内のリポジトリで、書き込んだライブの出演者全員の重複を返す
+func checkLiveConflicts(repositories *Repositories, id int) ([]*Conflict, error) {
+	return NewConflictServiceImpl(repositories.Live, repositories.Band, repositories.BandMember).CheckLive(id)
+}
+
+// resolvePlayer 名前からメンバーIDを設定し、メンバーがそのパートを担当できるか確認する
+func resolvePlayer(playerRepository PlayerRepository, player *Player) error {
+	member, err := playerRepository.FindByName(player.Name)
+	if err != nil {
+		return err
+	}
+	if member == nil || !member.HasPart(player.Part) {
+		return fmt.Errorf("%w: %s(%s)", ErrPlayerNotFound, player.Name, player.Part)
+	}
+	player.MemberId = member.Id
+	return nil
+}
+
+// resolveMemberId 名前からメンバーIDを返す。担当できるパートは確認しない
+func resolveMemberId(playerRepository PlayerRepository, name string) (int, error) {
+	member, err := playerRepository.FindByName(name)
+	if err != nil {
+		return 0, err
+	}
+	if member == nil {
+		return 0, fmt.Errorf("%w: %s", ErrPlayerNotFound, name)
+	}
+	return member.Id, nil
+}
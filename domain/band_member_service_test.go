@@ -0,0 +1,175 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func (m *BandMemberRepositoryMock) Create(bandMember *BandMember) error {
+	args := m.Called(bandMember)
+	return args.Error(0)
+}
+
+func (m *BandMemberRepositoryMock) Update(current *BandMember, replacement *BandMember) error {
+	args := m.Called(current, replacement)
+	return args.Error(0)
+}
+
+type PlayerRepositoryMock struct {
+	mock.Mock
+	PlayerRepository
+}
+
+func (m *PlayerRepositoryMock) FindByName(name string) (*Member, error) {
+	args := m.Called(name)
+	return args.Get(0).(*Member), args.Error(1)
+}
+
+// members 登録されているメンバーを返す。exists が false の場合は nil を返す
+func members(exists bool, member *Member) *Member {
+	if !exists {
+		return nil
+	}
+	return member
+}
+
+// conflictRepositories ライブ1 と同じ日のライブ2 に others が出演しているリポジトリの組を返す
+func conflictRepositories(bandMemberRepository *BandMemberRepositoryMock, playerRepository *PlayerRepositoryMock, others []*BandMember, findError error) *Repositories {
+	live1 := Live{Id: 1, Date: now}
+	live2 := Live{Id: 2, Date: now}
+	liveRepository := new(LiveRepositoryMock)
+	liveRepository.On("FindById", 1).Return(&live1, findError)
+	liveRepository.On("FindByPeriod", &live1.Date, &live1.Date).Return([]*Live{&live1, &live2}, nil)
+	bandRepository := new(BandRepositoryMock)
+	bandRepository.On("FindByLiveId", 1).Return([]*Band{{LiveId: 1, Turn: 1}}, nil)
+	bandMemberRepository.On("FindByLiveId", 2).Return(others, nil)
+	return &Repositories{Live: liveRepository, Band: bandRepository, BandMember: bandMemberRepository, Player: playerRepository}
+}
+
+func TestBandMemberRegister(t *testing.T) {
+	// given
+	bandMember := BandMember{LiveId: 1, Turn: 1, MemberName: "drummer", MemberPart: Dr}
+	member := Member{Id: 1, Name: "drummer", Part: []Part{Dr}}
+	expectedError := fmt.Errorf("dummy message")
+
+	tests := []struct {
+		// テスト名
+		testName string
+		// メンバーとパートが登録されているか
+		exists bool
+		// メンバー検索時のエラー
+		existsError error
+		// 重複の確認時のエラー
+		checkError error
+		// BandMember 登録が呼ばれる回数
+		createTimes int
+		// 戻り値の期待値(error)
+		expectedError error
+	}{
+		{
+			testName:      "正常系",
+			exists:        true,
+			createTimes:   1,
+			expectedError: nil,
+		},
+		{
+			testName:      "異常系_重複の確認時にエラー発生",
+			exists:        true,
+			checkError:    expectedError,
+			createTimes:   1,
+			expectedError: expectedError,
+		},
+		{
+			testName:      "異常系_Playerに登録されていないメンバー",
+			exists:        false,
+			createTimes:   0,
+			expectedError: ErrPlayerNotFound,
+		},
+		{
+			testName:      "異常系_Player検索時にエラー発生",
+			existsError:   expectedError,
+			createTimes:   0,
+			expectedError: expectedError,
+		},
+	}
+
+	for _, tc := range tests {
+		playerRepository := new(PlayerRepositoryMock)
+		playerRepository.On("FindByName", "drummer").Return(members(tc.exists, &member), tc.existsError).Once()
+		bandMemberRepository := new(BandMemberRepositoryMock)
+		bandMemberRepository.On("Create", &bandMember).Return(nil).Times(tc.createTimes)
+		bandMemberRepository.On("FindByLiveId", 1).Return([]*BandMember{&bandMember}, nil)
+		repositories := conflictRepositories(bandMemberRepository, playerRepository, nil, tc.checkError)
+		bandMemberService := NewBandMemberServiceImpl(bandMemberRepository, playerRepository, &UnitOfWorkMock{repositories: repositories})
+
+		// when
+		conflicts, err := bandMemberService.Register(&bandMember)
+
+		// then
+		assert.True(t, errors.Is(err, tc.expectedError), fmt.Sprintf("テスト名: %s", tc.testName))
+		assert.Empty(t, conflicts, fmt.Sprintf("テスト名: %s", tc.testName))
+		bandMemberRepository.AssertNumberOfCalls(t, "Create", tc.createTimes)
+	}
+}
+
+func TestBandMemberUpdate(t *testing.T) {
+	// given
+	current := BandMember{LiveId: 1, Turn: 1, MemberName: "drummer", MemberPart: Dr}
+	replacement := BandMember{LiveId: 1, Turn: 1, MemberName: "drummer2", MemberPart: Dr}
+	member := Member{Id: 2, Name: "drummer2", Part: []Part{Dr}}
+
+	tests := []struct {
+		testName string
+		exists   bool
+		// 同じ日のライブ2 のメンバー
+		others      []*BandMember
+		updateTimes int
+		// 戻り値の期待値(出演の重複)
+		expectedConflicts []*Conflict
+		expectedError     error
+	}{
+		{
+			testName:      "正常系",
+			exists:        true,
+			updateTimes:   1,
+			expectedError: nil,
+		},
+		{
+			testName:    "正常系_置き換えたメンバーの出演の重複を返す",
+			exists:      true,
+			others:      []*BandMember{{LiveId: 2, Turn: 3, MemberId: 2, MemberName: "drummer2", MemberPart: Dr}},
+			updateTimes: 1,
+			expectedConflicts: []*Conflict{
+				{Kind: DoubleBooking, PlayerName: "drummer2", LiveId: 1, Turn: 1, OtherLiveId: 2, OtherTurn: 3},
+			},
+		},
+		{
+			testName:      "異常系_Playerに登録されていないメンバー",
+			exists:        false,
+			updateTimes:   0,
+			expectedError: ErrPlayerNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		playerRepository := new(PlayerRepositoryMock)
+		playerRepository.On("FindByName", "drummer2").Return(members(tc.exists, &member), nil).Once()
+		playerRepository.On("FindByName", "drummer").Return(&Member{Id: 1, Name: "drummer", Part: []Part{Dr}}, nil)
+		bandMemberRepository := new(BandMemberRepositoryMock)
+		bandMemberRepository.On("Update", &current, &replacement).Return(nil).Times(tc.updateTimes)
+		bandMemberRepository.On("FindByLiveId", 1).Return([]*BandMember{&replacement}, nil)
+		repositories := conflictRepositories(bandMemberRepository, playerRepository, tc.others, nil)
+		bandMemberService := NewBandMemberServiceImpl(bandMemberRepository, playerRepository, &UnitOfWorkMock{repositories: repositories})
+
+		// when
+		conflicts, err := bandMemberService.Update(&current, &replacement)
+
+		// then
+		assert.True(t, errors.Is(err, tc.expectedError), fmt.Sprintf("テスト名: %s", tc.testName))
+		assert.Equal(t, tc.expectedConflicts, conflicts, fmt.Sprintf("テスト名: %s", tc.testName))
+		bandMemberRepository.AssertNumberOfCalls(t, "Update", tc.updateTimes)
+	}
+}
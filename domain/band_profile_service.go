@@ -0,0 +1,176 @@
+package domain
+
+import (
+	"fmt"
+)
+
+// ErrBandProfileNotFound 存在しないバンドプロフィールを指定した場合のエラー
+var ErrBandProfileNotFound = newError(ErrNotFound, "band profile not found")
+
+// BandProfileService ライブをまたいだバンドのプロフィールと既定のメンバーを管理する
+type BandProfileService interface {
+	GetAll() ([]*BandProfile, error)
+	GetById(id int) (*BandProfile, error)
+	// GetHistory プロフィールに紐づく出演バンドを返す
+	GetHistory(id int) ([]*Band, error)
+	Register(profile *BandProfile) error
+	// Rename バンド名を変更する。紐づく出演バンドの名前も合わせて変わる
+	Rename(id int, name string) error
+	SaveRoster(id int, players []*Player) error
+	// CopyRoster 出演バンドに紐づくプロフィールの既定のメンバーを BandMember に登録し、登録後のライブの出演の重複を返す。
+	// 既に登録されているメンバーはそのままにする。重複の確認に失敗した場合は登録しない
+	CopyRoster(id int, turn int) ([]*Conflict, error)
+}
+
+type BandProfileServiceImpl struct {
+	bandProfileRepository BandProfileRepository
+	bandRepository        BandRepository
+	unitOfWork            UnitOfWork
+}
+
+func NewBandProfileServiceImpl(bandProfileRepository BandProfileRepository, bandRepository BandRepository, unitOfWork UnitOfWork) *BandProfileServiceImpl {
+	return &BandProfileServiceImpl{
+		bandProfileRepository: bandProfileRepository,
+		bandRepository:        bandRepository,
+		unitOfWork:            unitOfWork,
+	}
+}
+
+func (b *BandProfileServiceImpl) GetAll() ([]*BandProfile, error) {
+	return b.bandProfileRepository.FindAll()
+}
+
+func (b *BandProfileServiceImpl) GetById(id int) (*BandProfile, error) {
+	profile, err := b.bandProfileRepository.FindById(id)
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		return nil, fmt.Errorf("%w: id %d", ErrBandProfileNotFound, id)
+	}
+	return profile, nil
+}
+
+func (b *BandProfileServiceImpl) GetHistory(id int) ([]*Band, error) {
+	if _, err := b.GetById(id); err != nil {
+		return nil, err
+	}
+	return b.bandRepository.FindByBandId(id)
+}
+
+func (b *BandProfileServiceImpl) Register(profile *BandProfile) error {
+	return b.unitOfWork.Do(func(repositories *Repositories) error {
+		if err := resolvePlayers(repositories.Player, profile.Player); err != nil {
+			return err
+		}
+		if err := repositories.BandProfile.Create(profile); err != nil {
+			return err
+		}
+		return repositories.BandProfile.SaveRoster(profile.Id, profile.Player)
+	})
+}
+
+func (b *BandProfileServiceImpl) Rename(id int, name string) error {
+	return b.unitOfWork.Do(func(repositories *Repositories) error {
+		if err := findBandProfile(repositories.BandProfile, id); err != nil {
+			return err
+		}
+		return repositories.BandProfile.Rename(id, name)
+	})
+}
+
+func (b *BandProfileServiceImpl) SaveRoster(id int, players []*Player) error {
+	return b.unitOfWork.Do(func(repositories *Repositories) error {
+		if err := findBandProfile(repositories.BandProfile, id); err != nil {
+			return err
+		}
+		if err := resolvePlayers(repositories.Player, players); err != nil {
+			return err
+		}
+		return repositories.BandProfile.SaveRoster(id, players)
+	})
+}
+
+func (b *BandProfileServiceImpl) CopyRoster(id int, turn int) ([]*Conflict, error) {
+	var conflicts []*Conflict
+	err := b.unitOfWork.Do(func(repositories *Repositories) error {
+		bands, err := repositories.Band.FindByLiveId(id)
+		if err != nil {
+			return err
+		}
+		var band *Band
+		for _, e := range bands {
+			if e.Turn == turn {
+				band = e
+			}
+		}
+		if band == nil {
+			return fmt.Errorf("%w: turn %d", ErrBandNotFound, turn)
+		}
+		if band.BandId == 0 {
+			return fmt.Errorf("%w: turn %d is not linked to a band profile", ErrBandProfileNotFound, turn)
+		}
+		profile, err := repositories.BandProfile.FindById(band.BandId)
+		if err != nil {
+			return err
+		}
+		if profile == nil {
+			return fmt.Errorf("%w: id %d", ErrBandProfileNotFound, band.BandId)
+		}
+
+		registered, err := repositories.BandMember.FindByLiveIdAndTurn(id, turn)
+		if err != nil {
+			return err
+		}
+		for _, player := range profile.Player {
+			if containsPlayer(registered, player) {
+				continue
+			}
+			err := repositories.BandMember.Create(&BandMember{LiveId: id, Turn: turn, MemberId: player.MemberId, MemberName: player.Name, MemberPart: player.Part})
+			if err != nil {
+				return err
+			}
+		}
+		checked, err := checkLiveConflicts(repositories, id)
+		if err != nil {
+			return err
+		}
+		conflicts = checked
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return conflicts, nil
+}
+
+// findBandProfile バンドプロフィールが存在するか確認する
+func findBandProfile(bandProfileRepository BandProfileRepository, id int) error {
+	profile, err := bandProfileRepository.FindById(id)
+	if err != nil {
+		return err
+	}
+	if profile == nil {
+		return fmt.Errorf("%w: id %d", ErrBandProfileNotFound, id)
+	}
+	return nil
+}
+
+// resolvePlayers 全てのメンバーのメンバーIDを設定し、それぞれのパートを担当できるか確認する
+func resolvePlayers(playerRepository PlayerRepository, players []*Player) error {
+	for _, player := range players {
+		if err := resolvePlayer(playerRepository, player); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func containsPlayer(players []*Player, player *Player) bool {
+	for _, p := range players {
+		if p.MemberId == player.MemberId && p.Part == player.Part {
+			return true
+		}
+	}
+	return false
+}
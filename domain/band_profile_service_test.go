@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+type BandProfileRepositoryMock struct {
+	mock.Mock
+	BandProfileRepository
+}
+
+func (m *BandProfileRepositoryMock) FindById(id int) (*BandProfile, error) {
+	args := m.Called(id)
+	return args.Get(0).(*BandProfile), args.Error(1)
+}
+
+func (m *BandProfileRepositoryMock) Rename(id int, name string) error {
+	args := m.Called(id, name)
+	return args.Error(0)
+}
+
+func TestBandProfileCopyRoster(t *testing.T) {
+	// given
+	drummer := Player{Name: "drummer", Part: Dr}
+	bassist := Player{Name: "bassist", Part: Ba}
+	profile := BandProfile{Id: 5, Name: "band", Player: []*Player{&drummer, &bassist}}
+	expectedError := fmt.Errorf("dummy message")
+
+	tests := []struct {
+		// テスト名
+		testName string
+		// 出演バンド
+		bands []*Band
+		// 既に登録されているメンバー
+		registered []*Player
+		// 重複の確認時のエラー
+		checkError error
+		// BandMember 登録が呼ばれる回数
+		createTimes int
+		// 戻り値の期待値(error)
+		expectedError error
+	}{
+		{
+			testName:    "正常系",
+			bands:       []*Band{{Name: "band", LiveId: 1, Turn: 1, BandId: 5}},
+			registered:  []*Player{},
+			createTimes: 2,
+		},
+		{
+			testName:      "異常系_登録後の重複の確認時にエラー発生",
+			bands:         []*Band{{Name: "band", LiveId: 1, Turn: 1, BandId: 5}},
+			registered:    []*Player{},
+			checkError:    expectedError,
+			createTimes:   2,
+			expectedError: expectedError,
+		},
+		{
+			testName:    "正常系_登録済みのメンバーは登録しない",
+			bands:       []*Band{{Name: "band", LiveId: 1, Turn: 1, BandId: 5}},
+			registered:  []*Player{{Name: "drummer", Part: Dr}},
+			createTimes: 1,
+		},
+		{
+			testName:      "異常系_出演バンドが存在しない",
+			bands:         []*Band{},
+			createTimes:   0,
+			expectedError: ErrBandNotFound,
+		},
+		{
+			testName:      "異常系_プロフィールに紐づいていない",
+			bands:         []*Band{{Name: "band", LiveId: 1, Turn: 1}},
+			createTimes:   0,
+			expectedError: ErrBandProfileNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		bandRepository := new(BandRepositoryMock)
+		bandRepository.On("FindByLiveId", 1).Return(tc.bands, nil)
+		bandMemberRepository := new(BandMemberRepositoryMock)
+		bandMemberRepository.On("FindByLiveIdAndTurn", 1, 1).Return(tc.registered, nil)
+		bandMemberRepository.On("Create", mock.Anything).Return(nil)
+		bandMemberRepository.On("FindByLiveId", 1).Return([]*BandMember{}, nil)
+		bandProfileRepository := new(BandProfileRepositoryMock)
+		bandProfileRepository.On("FindById", 5).Return(&profile, nil)
+		repositories := conflictRepositories(bandMemberRepository, nil, nil, tc.checkError)
+		repositories.Band, repositories.BandProfile = bandRepository, bandProfileRepository
+		bandProfileService := NewBandProfileServiceImpl(bandProfileRepository, bandRepository, &UnitOfWorkMock{repositories: repositories})
+
+		// when
+		conflicts, err := bandProfileService.CopyRoster(1, 1)
+
+		// then
+		assert.True(t, errors.Is(err, tc.expectedError), fmt.Sprintf("テスト名: %s", tc.testName))
+		assert.Empty(t, conflicts, fmt.Sprintf("テスト名: %s", tc.testName))
+		bandMemberRepository.AssertNumberOfCalls(t, "Create", tc.createTimes)
+	}
+}
+
+func TestBandProfileRename(t *testing.T) {
+	// given
+	tests := []struct {
+		testName      string
+		profile       *BandProfile
+		renameTimes   int
+		expectedError error
+	}{
+		{
+			testName:    "正常系",
+			profile:     &BandProfile{Id: 5, Name: "band"},
+			renameTimes: 1,
+		},
+		{
+			testName:      "異常系_プロフィールが存在しない",
+			profile:       nil,
+			renameTimes:   0,
+			expectedError: ErrBandProfileNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		bandProfileRepository := new(BandProfileRepositoryMock)
+		bandProfileRepository.On("FindById", 5).Return(tc.profile, nil)
+		bandProfileRepository.On("Rename", 5, "renamed").Return(nil)
+		unitOfWork := &UnitOfWorkMock{repositories: &Repositories{BandProfile: bandProfileRepository}}
+		bandProfileService := NewBandProfileServiceImpl(bandProfileRepository, nil, unitOfWork)
+
+		// when
+		err := bandProfileService.Rename(5, "renamed")
+
+		// then
+		assert.True(t, errors.Is(err, tc.expectedError), fmt.Sprintf("テスト名: %s", tc.testName))
+		bandProfileRepository.AssertNumberOfCalls(t, "Rename", tc.renameTimes)
+	}
+}
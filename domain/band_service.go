@@ -1,32 +1,272 @@
 package domain
 
+import (
+	"fmt"
+	"sort"
+)
+
+var (
+	// ErrBandNotFound 指定した出演順のバンドが存在しない場合のエラー
+	ErrBandNotFound = newError(ErrNotFound, "band not found")
+	// ErrInvalidTurnOrder 出演順の並びが出演バンドと一致しない場合のエラー
+	ErrInvalidTurnOrder = newError(ErrValidation, "invalid turn order")
+)
+
 type BandService interface {
 	GetByLiveId(id int) ([]*Band, error)
+	// GetByTurn 指定した出演順のバンドが存在しない場合は ErrBandNotFound を返す
+	GetByTurn(id int, turn int) (*Band, error)
 	Register(band *Band) error
-	Update(id int, turn int, band *Band) error
-	Delete(id int, turn int) error
+	// Update patch で指定されたフィールドだけを更新し、更新後のバンドを返す。
+	// to を指定した場合は Move と同じくバンドメンバーと合わせて to へ移動する。
+	// バンドのバージョンが version と異なる場合は ErrPreconditionFailed を返す
+	Update(id int, turn int, version int, to *int, patch *BandPatch) (*Band, error)
+	// Delete バンドのバージョンが version と異なる場合は ErrPreconditionFailed を返す
+	Delete(id int, turn int, version int) error
+	Move(id int, from int, to int) error
+	Swap(id int, turn1 int, turn2 int) error
+	Reorder(id int, turns []int) error
+	Compact(id int) error
 }
 
 type BandServiceImpl struct {
-	bandRepository BandRepository
+	bandRepository   BandRepository
+	unitOfWork       UnitOfWork
+	timetableService TimetableService
 }
 
-func NewBandServiceImpl(bandRepository BandRepository) *BandServiceImpl {
-	return &BandServiceImpl{bandRepository: bandRepository}
+func NewBandServiceImpl(bandRepository BandRepository, unitOfWork UnitOfWork, timetableService TimetableService) *BandServiceImpl {
+	return &BandServiceImpl{bandRepository: bandRepository, unitOfWork: unitOfWork, timetableService: timetableService}
 }
 
 func (b *BandServiceImpl) GetByLiveId(id int) ([]*Band, error) {
 	return b.bandRepository.FindByLiveId(id)
 }
 
+func (b *BandServiceImpl) GetByTurn(id int, turn int) (*Band, error) {
+	bands, err := b.bandRepository.FindByLiveId(id)
+	if err != nil {
+		return nil, err
+	}
+	for _, band := range bands {
+		if band.Turn == turn {
+			return band, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: turn %d", ErrBandNotFound, turn)
+}
+
 func (b *BandServiceImpl) Register(band *Band) error {
+	bands, err := b.bandRepository.FindByLiveId(band.LiveId)
+	if err != nil {
+		return err
+	}
+	if err := b.timetableService.Check(band.LiveId, append(bands, band)); err != nil {
+		return err
+	}
 	return b.bandRepository.Create(band)
 }
 
-func (b *BandServiceImpl) Update(id int, turn int, band *Band) error {
-	return b.bandRepository.Update(id, turn, band)
+func (b *BandServiceImpl) Update(id int, turn int, version int, to *int, patch *BandPatch) (*Band, error) {
+	var band *Band
+	err := b.unitOfWork.Do(func(repositories *Repositories) error {
+		bands, err := repositories.Band.FindByLiveId(id)
+		if err != nil {
+			return err
+		}
+		// やり直した場合に前回の結果を参照しないよう、見つけたバンドはこの中で宣言する
+		var found *Band
+		var others []*Band
+		for _, e := range bands {
+			if e.Turn == turn {
+				updated := *e
+				patch.Apply(&updated)
+				if to != nil {
+					updated.Turn = *to
+				}
+				found = &updated
+			} else {
+				others = append(others, e)
+			}
+		}
+		if found == nil {
+			return fmt.Errorf("%w: turn %d", ErrBandNotFound, turn)
+		}
+		// 古いバージョンに対する変更はタイムテーブルの検証より先に拒否する
+		if found.Version != version {
+			return fmt.Errorf("%w: band %d-%d", ErrPreconditionFailed, id, turn)
+		}
+		if err := b.timetableService.Check(id, append(others, found)); err != nil {
+			return err
+		}
+		if err := repositories.Band.Patch(id, turn, version, patch); err != nil {
+			return err
+		}
+		if found.Turn != turn {
+			if err := moveTurns(repositories, id, movePlan(turn, found.Turn)); err != nil {
+				return err
+			}
+		}
+		// プロフィールのバンド名を反映するため登録後の値を読み直す
+		bands, err = repositories.Band.FindByLiveId(id)
+		if err != nil {
+			return err
+		}
+		for _, e := range bands {
+			if e.Turn == found.Turn {
+				found = e
+			}
+		}
+		band = found
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return band, nil
+}
+
+func (b *BandServiceImpl) Delete(id int, turn int, version int) error {
+	return b.bandRepository.Delete(id, turn, version)
+}
+
+// Move from のバンドを to の位置へ移動する。to が空いている場合はそのまま to へ移り、
+// 埋まっている場合は間のバンドを1つずつずらす
+func (b *BandServiceImpl) Move(id int, from int, to int) error {
+	return b.rewriteTurns(id, movePlan(from, to))
+}
+
+// movePlan Move の 旧出演順 → 新出演順 の対応を返す
+func movePlan(from int, to int) func(turns []int) (map[int]int, error) {
+	return func(turns []int) (map[int]int, error) {
+		i := indexOf(turns, from)
+		if i < 0 {
+			return nil, fmt.Errorf("%w: turn %d", ErrBandNotFound, from)
+		}
+		j := indexOf(turns, to)
+		if j < 0 {
+			return map[int]int{from: to}, nil
+		}
+		order := append(append([]int{}, turns[:i]...), turns[i+1:]...)
+		order = append(order[:j], append([]int{from}, order[j:]...)...)
+		mapping := map[int]int{}
+		for k, turn := range order {
+			mapping[turn] = turns[k]
+		}
+		return mapping, nil
+	}
+}
+
+// Swap 2つのバンドの出演順を入れ替える
+func (b *BandServiceImpl) Swap(id int, turn1 int, turn2 int) error {
+	return b.rewriteTurns(id, func(turns []int) (map[int]int, error) {
+		for _, turn := range []int{turn1, turn2} {
+			if indexOf(turns, turn) < 0 {
+				return nil, fmt.Errorf("%w: turn %d", ErrBandNotFound, turn)
+			}
+		}
+		return map[int]int{turn1: turn2, turn2: turn1}, nil
+	})
+}
+
+// Reorder 現在の出演順を新しい並びで指定し、先頭から 1, 2, 3... と振り直す
+func (b *BandServiceImpl) Reorder(id int, order []int) error {
+	return b.rewriteTurns(id, func(turns []int) (map[int]int, error) {
+		if len(order) != len(turns) {
+			return nil, fmt.Errorf("%w: %d bands are registered but %d turns were given", ErrInvalidTurnOrder, len(turns), len(order))
+		}
+		mapping := map[int]int{}
+		for k, turn := range order {
+			if indexOf(turns, turn) < 0 {
+				return nil, fmt.Errorf("%w: turn %d", ErrBandNotFound, turn)
+			}
+			if _, ok := mapping[turn]; ok {
+				return nil, fmt.Errorf("%w: turn %d is duplicated", ErrInvalidTurnOrder, turn)
+			}
+			mapping[turn] = k + 1
+		}
+		return mapping, nil
+	})
+}
+
+// Compact 出演順の欠番を詰める(1, 3, 7 → 1, 2, 3)
+func (b *BandServiceImpl) Compact(id int) error {
+	return b.rewriteTurns(id, func(turns []int) (map[int]int, error) {
+		mapping := map[int]int{}
+		for k, turn := range turns {
+			mapping[turn] = k + 1
+		}
+		return mapping, nil
+	})
+}
+
+// rewriteTurns plan が返す 旧出演順 → 新出演順 の対応に従って、バンドとバンドメンバーを1つのトランザクションで振り直す
+func (b *BandServiceImpl) rewriteTurns(id int, plan func(turns []int) (map[int]int, error)) error {
+	return b.unitOfWork.Do(func(repositories *Repositories) error {
+		return moveTurns(repositories, id, plan)
+	})
+}
+
+// moveTurns トランザクション内のリポジトリで、plan の対応に従ってバンドとバンドメンバーの出演順を振り直す。
+// Turn は主キーのため、出演順が変わるバンドは一度削除してから新しい出演順で登録し直す
+func moveTurns(repositories *Repositories, id int, plan func(turns []int) (map[int]int, error)) error {
+	bands, err := repositories.Band.FindByLiveId(id)
+	if err != nil {
+		return err
+	}
+	sort.Slice(bands, func(i, j int) bool { return bands[i].Turn < bands[j].Turn })
+	var turns []int
+	for _, band := range bands {
+		turns = append(turns, band.Turn)
+	}
+	mapping, err := plan(turns)
+	if err != nil {
+		return err
+	}
+
+	bandMembers, err := repositories.BandMember.FindByLiveId(id)
+	if err != nil {
+		return err
+	}
+	var moved []*Band
+	var movedMembers []*BandMember
+	for _, band := range bands {
+		turn, ok := mapping[band.Turn]
+		if !ok || turn == band.Turn {
+			continue
+		}
+		for _, bandMember := range bandMembers {
+			if bandMember.Turn != band.Turn {
+				continue
+			}
+			if err := repositories.BandMember.Delete(bandMember); err != nil {
+				return err
+			}
+			movedMembers = append(movedMembers, &BandMember{LiveId: id, Turn: turn, MemberId: bandMember.MemberId, MemberName: bandMember.MemberName, MemberPart: bandMember.MemberPart})
+		}
+		if err := repositories.Band.Delete(id, band.Turn, band.Version); err != nil {
+			return err
+		}
+		moved = append(moved, &Band{Name: band.Name, LiveId: id, Turn: turn, SetLength: band.SetLength, BandId: band.BandId})
+	}
+	for _, band := range moved {
+		if err := repositories.Band.Create(band); err != nil {
+			return err
+		}
+	}
+	for _, bandMember := range movedMembers {
+		if err := repositories.BandMember.Create(bandMember); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func (b *BandServiceImpl) Delete(id int, turn int) error {
-	return b.bandRepository.Delete(id, turn)
+func indexOf(turns []int, turn int) int {
+	for i, t := range turns {
+		if t == turn {
+			return i
+		}
+	}
+	return -1
 }
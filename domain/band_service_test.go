@@ -0,0 +1,285 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func (m *BandRepositoryMock) Delete(id int, turn int, version int) error {
+	args := m.Called(id, turn, version)
+	return args.Error(0)
+}
+
+func (m *BandMemberRepositoryMock) Delete(bandMember *BandMember) error {
+	args := m.Called(bandMember)
+	return args.Error(0)
+}
+
+func TestRewriteTurns(t *testing.T) {
+	// given
+	bands := []*Band{
+		{Name: "band1", LiveId: 1, Turn: 1},
+		{Name: "band3", LiveId: 1, Turn: 3},
+		{Name: "band7", LiveId: 1, Turn: 7},
+	}
+	bandMembers := []*BandMember{
+		{LiveId: 1, Turn: 3, MemberName: "drummer", MemberPart: Dr},
+		{LiveId: 1, Turn: 7, MemberName: "bassist", MemberPart: Ba},
+	}
+
+	tests := []struct {
+		// テスト名
+		testName string
+		// 出演順の変更処理
+		rewrite func(service *BandServiceImpl) error
+		// 登録し直される期待値(Band)
+		expectedBands []*Band
+		// 登録し直される期待値(BandMember)
+		expectedMembers []*BandMember
+		// 戻り値の期待値(error)
+		expectedError error
+	}{
+		{
+			testName: "正常系_Compact",
+			rewrite:  func(service *BandServiceImpl) error { return service.Compact(1) },
+			expectedBands: []*Band{
+				{Name: "band3", LiveId: 1, Turn: 2},
+				{Name: "band7", LiveId: 1, Turn: 3},
+			},
+			expectedMembers: []*BandMember{
+				{LiveId: 1, Turn: 2, MemberName: "drummer", MemberPart: Dr},
+				{LiveId: 1, Turn: 3, MemberName: "bassist", MemberPart: Ba},
+			},
+		},
+		{
+			testName: "正常系_Move_空いている出演順へ移動",
+			rewrite:  func(service *BandServiceImpl) error { return service.Move(1, 7, 2) },
+			expectedBands: []*Band{
+				{Name: "band7", LiveId: 1, Turn: 2},
+			},
+			expectedMembers: []*BandMember{
+				{LiveId: 1, Turn: 2, MemberName: "bassist", MemberPart: Ba},
+			},
+		},
+		{
+			testName: "正常系_Move_埋まっている出演順へ移動",
+			rewrite:  func(service *BandServiceImpl) error { return service.Move(1, 7, 1) },
+			expectedBands: []*Band{
+				{Name: "band1", LiveId: 1, Turn: 3},
+				{Name: "band3", LiveId: 1, Turn: 7},
+				{Name: "band7", LiveId: 1, Turn: 1},
+			},
+			expectedMembers: []*BandMember{
+				{LiveId: 1, Turn: 7, MemberName: "drummer", MemberPart: Dr},
+				{LiveId: 1, Turn: 1, MemberName: "bassist", MemberPart: Ba},
+			},
+		},
+		{
+			testName: "正常系_Swap",
+			rewrite:  func(service *BandServiceImpl) error { return service.Swap(1, 1, 7) },
+			expectedBands: []*Band{
+				{Name: "band1", LiveId: 1, Turn: 7},
+				{Name: "band7", LiveId: 1, Turn: 1},
+			},
+			expectedMembers: []*BandMember{
+				{LiveId: 1, Turn: 1, MemberName: "bassist", MemberPart: Ba},
+			},
+		},
+		{
+			testName: "正常系_Reorder",
+			rewrite:  func(service *BandServiceImpl) error { return service.Reorder(1, []int{7, 1, 3}) },
+			expectedBands: []*Band{
+				{Name: "band1", LiveId: 1, Turn: 2},
+				{Name: "band7", LiveId: 1, Turn: 1},
+			},
+			expectedMembers: []*BandMember{
+				{LiveId: 1, Turn: 1, MemberName: "bassist", MemberPart: Ba},
+			},
+		},
+		{
+			testName:      "異常系_Reorder_出演バンドと数が一致しない",
+			rewrite:       func(service *BandServiceImpl) error { return service.Reorder(1, []int{7, 1}) },
+			expectedError: ErrInvalidTurnOrder,
+		},
+		{
+			testName:      "異常系_Reorder_出演順が重複している",
+			rewrite:       func(service *BandServiceImpl) error { return service.Reorder(1, []int{7, 7, 1}) },
+			expectedError: ErrInvalidTurnOrder,
+		},
+		{
+			testName:      "異常系_Swap_存在しない出演順",
+			rewrite:       func(service *BandServiceImpl) error { return service.Swap(1, 1, 2) },
+			expectedError: ErrBandNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		var createdBands []*Band
+		var createdMembers []*BandMember
+		bandRepository := new(BandRepositoryMock)
+		bandRepository.On("FindByLiveId", 1).Return(bands, nil)
+		bandRepository.On("Delete", 1, mock.Anything, mock.Anything).Return(nil)
+		bandRepository.On("Create", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			createdBands = append(createdBands, args.Get(0).(*Band))
+		})
+		bandMemberRepository := new(BandMemberRepositoryMock)
+		bandMemberRepository.On("FindByLiveId", 1).Return(bandMembers, nil)
+		bandMemberRepository.On("Delete", mock.Anything).Return(nil)
+		bandMemberRepository.On("Create", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			createdMembers = append(createdMembers, args.Get(0).(*BandMember))
+		})
+		bandService := NewBandServiceImpl(bandRepository, &UnitOfWorkMock{repositories: &Repositories{
+			Band:       bandRepository,
+			BandMember: bandMemberRepository,
+		}}, nil)
+
+		// when
+		err := tc.rewrite(bandService)
+
+		// then
+		assert.True(t, errors.Is(err, tc.expectedError), fmt.Sprintf("テスト名: %s", tc.testName))
+		assert.Equal(t, tc.expectedBands, createdBands, fmt.Sprintf("テスト名: %s", tc.testName))
+		assert.Equal(t, tc.expectedMembers, createdMembers, fmt.Sprintf("テスト名: %s", tc.testName))
+		bandRepository.AssertNumberOfCalls(t, "Delete", len(tc.expectedBands))
+		bandMemberRepository.AssertNumberOfCalls(t, "Delete", len(tc.expectedMembers))
+	}
+}
+
+func (m *BandRepositoryMock) Patch(id int, turn int, version int, patch *BandPatch) error {
+	args := m.Called(id, turn, version, patch)
+	return args.Error(0)
+}
+
+func TestBandUpdate(t *testing.T) {
+	// given
+	setLength := 25
+	patch := &BandPatch{SetLength: &setLength}
+	bands := []*Band{
+		{Name: "band1", LiveId: 1, Turn: 1, SetLength: 30, Version: 1},
+		{Name: "band3", LiveId: 1, Turn: 3, SetLength: 20, Version: 1},
+	}
+	merged := &Band{Name: "band3", LiveId: 1, Turn: 3, SetLength: 25, Version: 1}
+	updated := &Band{Name: "band3", LiveId: 1, Turn: 3, SetLength: 25, Version: 2}
+
+	tests := []struct {
+		// テスト名
+		testName string
+		// 更新する出演順
+		turn int
+		// If-Match で指定されたバージョン
+		version int
+		// タイムテーブルの確認結果
+		checkError error
+		// Patch の呼び出し回数
+		patchTimes int
+		// 戻り値の期待値
+		expected *Band
+		// 戻り値の期待値(error)
+		expectedError error
+	}{
+		{
+			testName:   "正常系",
+			turn:       3,
+			version:    1,
+			patchTimes: 1,
+			expected:   updated,
+		},
+		{
+			testName:      "異常系_存在しない出演順",
+			turn:          5,
+			version:       1,
+			expectedError: ErrBandNotFound,
+		},
+		{
+			testName:      "異常系_古いバージョン",
+			turn:          3,
+			version:       0,
+			checkError:    ErrTimetableOverrun,
+			expectedError: ErrPreconditionFailed,
+		},
+		{
+			testName:      "異常系_タイムテーブルが終演時刻を超える",
+			turn:          3,
+			version:       1,
+			checkError:    ErrTimetableOverrun,
+			expectedError: ErrTimetableOverrun,
+		},
+	}
+
+	for _, tc := range tests {
+		bandRepository := new(BandRepositoryMock)
+		bandRepository.On("FindByLiveId", 1).Return(bands, nil).Once()
+		bandRepository.On("FindByLiveId", 1).Return([]*Band{bands[0], updated}, nil).Once()
+		bandRepository.On("Patch", 1, tc.turn, tc.version, patch).Return(nil).Times(tc.patchTimes)
+		timetableService := new(TimetableServiceMock)
+		timetableService.On("Check", 1, []*Band{bands[0], merged}).Return(tc.checkError)
+		bandService := NewBandServiceImpl(bandRepository, &UnitOfWorkMock{repositories: &Repositories{Band: bandRepository}}, timetableService)
+
+		// when
+		actual, err := bandService.Update(1, tc.turn, tc.version, nil, patch)
+
+		// then
+		assert.Equal(t, tc.expected, actual, fmt.Sprintf("テスト名: %s", tc.testName))
+		assert.True(t, errors.Is(err, tc.expectedError), fmt.Sprintf("テスト名: %s", tc.testName))
+		bandRepository.AssertNumberOfCalls(t, "Patch", tc.patchTimes)
+	}
+}
+
+func TestBandUpdateTurn(t *testing.T) {
+	// given
+	to, setLength := 1, 25
+	patch := &BandPatch{SetLength: &setLength}
+	bands := []*Band{
+		{Name: "band1", LiveId: 1, Turn: 1, SetLength: 30, Version: 1},
+		{Name: "band3", LiveId: 1, Turn: 3, SetLength: 20, Version: 1},
+	}
+	patched := []*Band{bands[0], {Name: "band3", LiveId: 1, Turn: 3, SetLength: 25, Version: 2}}
+	moved := []*Band{
+		{Name: "band3", LiveId: 1, Turn: 1, SetLength: 25, Version: 3},
+		{Name: "band1", LiveId: 1, Turn: 3, SetLength: 30, Version: 4},
+	}
+	bandMembers := []*BandMember{
+		{LiveId: 1, Turn: 3, MemberName: "drummer", MemberPart: Dr},
+	}
+	var createdBands []*Band
+	var createdMembers []*BandMember
+	bandRepository := new(BandRepositoryMock)
+	bandRepository.On("FindByLiveId", 1).Return(bands, nil).Once()
+	bandRepository.On("FindByLiveId", 1).Return(patched, nil).Once()
+	bandRepository.On("FindByLiveId", 1).Return(moved, nil).Once()
+	bandRepository.On("Patch", 1, 3, 1, patch).Return(nil)
+	bandRepository.On("Delete", 1, mock.Anything, mock.Anything).Return(nil)
+	bandRepository.On("Create", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		createdBands = append(createdBands, args.Get(0).(*Band))
+	})
+	bandMemberRepository := new(BandMemberRepositoryMock)
+	bandMemberRepository.On("FindByLiveId", 1).Return(bandMembers, nil)
+	bandMemberRepository.On("Delete", bandMembers[0]).Return(nil)
+	bandMemberRepository.On("Create", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		createdMembers = append(createdMembers, args.Get(0).(*BandMember))
+	})
+	timetableService := new(TimetableServiceMock)
+	timetableService.On("Check", 1, mock.Anything).Return(nil)
+	bandService := NewBandServiceImpl(bandRepository, &UnitOfWorkMock{repositories: &Repositories{
+		Band:       bandRepository,
+		BandMember: bandMemberRepository,
+	}}, timetableService)
+
+	// when
+	actual, err := bandService.Update(1, 3, 1, &to, patch)
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, moved[0], actual)
+	// 更新後のバージョンで削除し、埋まっている出演順のバンドは Move と同じくずらす
+	bandRepository.AssertCalled(t, "Delete", 1, 3, 2)
+	bandRepository.AssertCalled(t, "Delete", 1, 1, 1)
+	assert.Equal(t, []*Band{
+		{Name: "band1", LiveId: 1, Turn: 3, SetLength: 30},
+		{Name: "band3", LiveId: 1, Turn: 1, SetLength: 25},
+	}, createdBands)
+	assert.Equal(t, []*BandMember{{LiveId: 1, Turn: 1, MemberName: "drummer", MemberPart: Dr}}, createdMembers, "バンドメンバーも合わせて移動する")
+}
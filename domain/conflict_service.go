@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"sort"
+)
+
+// ConflictService 出演者のダブルブッキングや連続出演を検出する
+type ConflictService interface {
+	// CheckLive ライブの出演者全員の重複を返す
+	CheckLive(id int) ([]*Conflict, error)
+	// CheckPlayer ライブに出演する1人のメンバーの重複を返す
+	CheckPlayer(id int, name string) ([]*Conflict, error)
+}
+
+type ConflictServiceImpl struct {
+	liveRepository       LiveRepository
+	bandRepository       BandRepository
+	bandMemberRepository BandMemberRepository
+}
+
+func NewConflictServiceImpl(liveRepository LiveRepository, bandRepository BandRepository, bandMemberRepository BandMemberRepository) *ConflictServiceImpl {
+	return &ConflictServiceImpl{
+		liveRepository:       liveRepository,
+		bandRepository:       bandRepository,
+		bandMemberRepository: bandMemberRepository,
+	}
+}
+
+func (c *ConflictServiceImpl) CheckLive(id int) ([]*Conflict, error) {
+	return c.check(id, func(string) bool { return true })
+}
+
+func (c *ConflictServiceImpl) CheckPlayer(id int, name string) ([]*Conflict, error) {
+	return c.check(id, func(playerName string) bool { return playerName == name })
+}
+
+func (c *ConflictServiceImpl) check(id int, target func(name string) bool) ([]*Conflict, error) {
+	live, err := c.liveRepository.FindById(id)
+	if err != nil {
+		return nil, err
+	}
+	bandMembers, err := c.bandMemberRepository.FindByLiveId(id)
+	if err != nil {
+		return nil, err
+	}
+	turns := firstTurns(bandMembers)
+
+	var conflicts []*Conflict
+	lives, err := c.liveRepository.FindByPeriod(&live.Date, &live.Date)
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range lives {
+		if other.Id == id {
+			continue
+		}
+		otherMembers, err := c.bandMemberRepository.FindByLiveId(other.Id)
+		if err != nil {
+			return nil, err
+		}
+		otherTurns := firstTurns(otherMembers)
+		for _, bandMember := range uniqueMembers(bandMembers) {
+			otherTurn, ok := otherTurns[bandMember.MemberName]
+			if !ok || !target(bandMember.MemberName) {
+				continue
+			}
+			conflicts = append(conflicts, &Conflict{
+				Kind:        DoubleBooking,
+				PlayerName:  bandMember.MemberName,
+				LiveId:      id,
+				Turn:        turns[bandMember.MemberName],
+				OtherLiveId: other.Id,
+				OtherTurn:   otherTurn,
+			})
+		}
+	}
+
+	bands, err := c.bandRepository.FindByLiveId(id)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(bands, func(i, j int) bool { return bands[i].Turn < bands[j].Turn })
+	for i := 1; i < len(bands); i++ {
+		previous := playerNames(bandMembers, bands[i-1].Turn)
+		for _, name := range sortedNames(playerNames(bandMembers, bands[i].Turn)) {
+			if !previous[name] || !target(name) {
+				continue
+			}
+			conflicts = append(conflicts, &Conflict{
+				Kind:        BackToBack,
+				PlayerName:  name,
+				LiveId:      id,
+				Turn:        bands[i-1].Turn,
+				OtherLiveId: id,
+				OtherTurn:   bands[i].Turn,
+			})
+		}
+	}
+	return conflicts, nil
+}
+
+// firstTurns メンバーごとに最初に出演する出演順を返す
+func firstTurns(bandMembers []*BandMember) map[string]int {
+	turns := map[string]int{}
+	for _, bandMember := range bandMembers {
+		if turn, ok := turns[bandMember.MemberName]; !ok || bandMember.Turn < turn {
+			turns[bandMember.MemberName] = bandMember.Turn
+		}
+	}
+	return turns
+}
+
+// uniqueMembers 同じメンバーが複数パート・複数バンドで登録されていても1件にまとめる
+func uniqueMembers(bandMembers []*BandMember) []*BandMember {
+	var unique []*BandMember
+	seen := map[string]bool{}
+	for _, bandMember := range bandMembers {
+		if seen[bandMember.MemberName] {
+			continue
+		}
+		seen[bandMember.MemberName] = true
+		unique = append(unique, bandMember)
+	}
+	return unique
+}
+
+// playerNames 指定した出演順のバンドのメンバー名を返す
+func playerNames(bandMembers []*BandMember, turn int) map[string]bool {
+	names := map[string]bool{}
+	for _, bandMember := range bandMembers {
+		if bandMember.Turn == turn {
+			names[bandMember.MemberName] = true
+		}
+	}
+	return names
+}
+
+func sortedNames(names map[string]bool) []string {
+	var sorted []string
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
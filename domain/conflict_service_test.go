@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func newConflictService() *ConflictServiceImpl {
+	live1 := Live{Id: 1, Date: now}
+	live2 := Live{Id: 2, Date: now}
+	liveRepository := new(LiveRepositoryMock)
+	liveRepository.On("FindById", 1).Return(&live1, nil)
+	liveRepository.On("FindByPeriod", &live1.Date, &live1.Date).Return([]*Live{&live1, &live2}, nil)
+	bandRepository := new(BandRepositoryMock)
+	bandRepository.On("FindByLiveId", 1).Return([]*Band{
+		{Name: "band1", LiveId: 1, Turn: 1},
+		{Name: "band3", LiveId: 1, Turn: 3},
+		{Name: "band5", LiveId: 1, Turn: 5},
+	}, nil)
+	bandMemberRepository := new(BandMemberRepositoryMock)
+	bandMemberRepository.
+		On("FindByLiveId", 1).Return([]*BandMember{
+		{LiveId: 1, Turn: 1, MemberName: "drummer", MemberPart: Dr},
+		{LiveId: 1, Turn: 1, MemberName: "singer", MemberPart: Vo},
+		{LiveId: 1, Turn: 3, MemberName: "drummer", MemberPart: Dr},
+		{LiveId: 1, Turn: 5, MemberName: "singer", MemberPart: Vo},
+	}, nil).
+		On("FindByLiveId", 2).Return([]*BandMember{
+		{LiveId: 2, Turn: 4, MemberName: "singer", MemberPart: Gt},
+	}, nil)
+	return NewConflictServiceImpl(liveRepository, bandRepository, bandMemberRepository)
+}
+
+func TestCheckLive(t *testing.T) {
+	// given
+	conflictService := newConflictService()
+
+	// when
+	actual, err := conflictService.CheckLive(1)
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, []*Conflict{
+		{Kind: DoubleBooking, PlayerName: "singer", LiveId: 1, Turn: 1, OtherLiveId: 2, OtherTurn: 4},
+		{Kind: BackToBack, PlayerName: "drummer", LiveId: 1, Turn: 1, OtherLiveId: 1, OtherTurn: 3},
+	}, actual)
+}
+
+func TestCheckPlayer(t *testing.T) {
+	// given
+	conflictService := newConflictService()
+
+	// when
+	actual, err := conflictService.CheckPlayer(1, "drummer")
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, []*Conflict{
+		{Kind: BackToBack, PlayerName: "drummer", LiveId: 1, Turn: 1, OtherLiveId: 1, OtherTurn: 3},
+	}, actual)
+}
@@ -0,0 +1,188 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrApplicationNotFound 指定したライブに存在しない出演申込を指定した場合のエラー
+	ErrApplicationNotFound = newError(ErrNotFound, "entry application not found")
+	// ErrEntryNotOpen 出演バンドを募集していないライブに申し込んだ場合のエラー
+	ErrEntryNotOpen = newError(ErrConflict, "entry is not open")
+	// ErrInvalidApplicationTransition 出演申込の現在の状態から指定した状態に変更できない場合のエラー
+	ErrInvalidApplicationTransition = newError(ErrConflict, "invalid entry application status transition")
+)
+
+// EntryApplicationService バンドからの出演申込を受け付け、承認・却下・補欠を決める
+type EntryApplicationService interface {
+	// GetByLiveId ライブの申込を申込順に返す。status を指定した場合はその状態の申込だけを返す
+	GetByLiveId(id int, status *ApplicationStatus) ([]*EntryApplication, error)
+	GetById(liveId int, id int) (*EntryApplication, error)
+	// Apply 募集中のライブへの申込を審査待ちとして登録する。メンバーは登録済みで、それぞれのパートを担当できる必要がある
+	Apply(application *EntryApplication) error
+	// Approve 申込を承認し、出演バンドとメンバーを登録する。承認した申込と、登録後のライブの出演の重複を返す。
+	// 重複の確認は承認と同じトランザクションで行い、確認に失敗した場合は承認しない。
+	// turn が 0 の場合は希望する出演順、希望がないか既に埋まっている場合は最後の出演順に登録する
+	Approve(liveId int, id int, turn int) (*EntryApplication, []*Conflict, error)
+	Reject(liveId int, id int) (*EntryApplication, error)
+	// Waitlist 審査待ちの申込を補欠にする
+	Waitlist(liveId int, id int) (*EntryApplication, error)
+}
+
+type EntryApplicationServiceImpl struct {
+	entryApplicationRepository EntryApplicationRepository
+	unitOfWork                 UnitOfWork
+	timetableService           TimetableService
+	// now 現在時刻。申込日時に使う
+	now func() time.Time
+}
+
+func NewEntryApplicationServiceImpl(entryApplicationRepository EntryApplicationRepository, unitOfWork UnitOfWork, timetableService TimetableService, now func() time.Time) *EntryApplicationServiceImpl {
+	return &EntryApplicationServiceImpl{
+		entryApplicationRepository: entryApplicationRepository,
+		unitOfWork:                 unitOfWork,
+		timetableService:           timetableService,
+		now:                        now,
+	}
+}
+
+func (e *EntryApplicationServiceImpl) GetByLiveId(id int, status *ApplicationStatus) ([]*EntryApplication, error) {
+	return e.entryApplicationRepository.FindByLiveId(id, status)
+}
+
+func (e *EntryApplicationServiceImpl) GetById(liveId int, id int) (*EntryApplication, error) {
+	return findApplication(e.entryApplicationRepository, liveId, id)
+}
+
+func (e *EntryApplicationServiceImpl) Apply(application *EntryApplication) error {
+	return e.unitOfWork.Do(func(repositories *Repositories) error {
+		live, err := repositories.Live.FindById(application.LiveId)
+		if err != nil {
+			return err
+		}
+		if live.Status != LiveEntryOpen {
+			return fmt.Errorf("%w: live %d is %s", ErrEntryNotOpen, live.Id, live.Status)
+		}
+		if err := resolvePlayers(repositories.Player, application.Player); err != nil {
+			return err
+		}
+		application.Status = ApplicationPending
+		application.Turn = 0
+		// 申込日時はデータベースと同じく秒単位にする
+		application.AppliedAt = e.now().Truncate(time.Second)
+		return repositories.EntryApplication.Create(application)
+	})
+}
+
+func (e *EntryApplicationServiceImpl) Approve(liveId int, id int, turn int) (*EntryApplication, []*Conflict, error) {
+	var approved *EntryApplication
+	var conflicts []*Conflict
+	err := e.unitOfWork.Do(func(repositories *Repositories) error {
+		application, err := findApplication(repositories.EntryApplication, liveId, id)
+		if err != nil {
+			return err
+		}
+		if !application.Status.CanChangeTo(ApplicationApproved) {
+			return fmt.Errorf("%w: application %d is %s", ErrInvalidApplicationTransition, id, application.Status)
+		}
+		bands, err := repositories.Band.FindByLiveId(liveId)
+		if err != nil {
+			return err
+		}
+		// やり直した場合に前回選んだ出演順を使わないよう、出演順はこの中で決める
+		approvedTurn := turn
+		if approvedTurn == 0 {
+			approvedTurn = chooseTurn(bands, application.PreferredTurn)
+		}
+		band := &Band{Name: application.Name, LiveId: liveId, Turn: approvedTurn, SetLength: application.SetLength, BandId: application.BandId}
+		if err := e.timetableService.Check(liveId, append(bands, band)); err != nil {
+			return err
+		}
+		if err := repositories.Band.Create(band); err != nil {
+			return err
+		}
+		for _, player := range application.Player {
+			err := repositories.BandMember.Create(&BandMember{LiveId: liveId, Turn: approvedTurn, MemberId: player.MemberId, MemberName: player.Name, MemberPart: player.Part})
+			if err != nil {
+				return err
+			}
+		}
+		if err := repositories.EntryApplication.UpdateStatus(id, application.Status, ApplicationApproved, approvedTurn); err != nil {
+			return err
+		}
+		checked, err := checkLiveConflicts(repositories, liveId)
+		if err != nil {
+			return err
+		}
+		application.Status, application.Turn = ApplicationApproved, approvedTurn
+		approved, conflicts = application, checked
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return approved, conflicts, nil
+}
+
+func (e *EntryApplicationServiceImpl) Reject(liveId int, id int) (*EntryApplication, error) {
+	return e.changeStatus(liveId, id, ApplicationRejected)
+}
+
+func (e *EntryApplicationServiceImpl) Waitlist(liveId int, id int) (*EntryApplication, error) {
+	return e.changeStatus(liveId, id, ApplicationWaitlisted)
+}
+
+// changeStatus 出演バンドを登録せずに申込の状態だけを変更する
+func (e *EntryApplicationServiceImpl) changeStatus(liveId int, id int, status ApplicationStatus) (*EntryApplication, error) {
+	var changed *EntryApplication
+	err := e.unitOfWork.Do(func(repositories *Repositories) error {
+		application, err := findApplication(repositories.EntryApplication, liveId, id)
+		if err != nil {
+			return err
+		}
+		if !application.Status.CanChangeTo(status) {
+			return fmt.Errorf("%w: application %d is %s", ErrInvalidApplicationTransition, id, application.Status)
+		}
+		if err := repositories.EntryApplication.UpdateStatus(id, application.Status, status, 0); err != nil {
+			return err
+		}
+		application.Status = status
+		changed = application
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changed, nil
+}
+
+// findApplication ライブの申込を返す。他のライブの申込は存在しないものとして扱う
+func findApplication(entryApplicationRepository EntryApplicationRepository, liveId int, id int) (*EntryApplication, error) {
+	application, err := entryApplicationRepository.FindById(id)
+	if err != nil {
+		return nil, err
+	}
+	if application == nil || application.LiveId != liveId {
+		return nil, fmt.Errorf("%w: live %d, id %d", ErrApplicationNotFound, liveId, id)
+	}
+	return application, nil
+}
+
+// chooseTurn 希望する出演順が空いていればその出演順、そうでなければ最後の出演順の次を返す
+func chooseTurn(bands []*Band, preferred int) int {
+	last := 0
+	taken := false
+	for _, band := range bands {
+		if band.Turn == preferred {
+			taken = true
+		}
+		if band.Turn > last {
+			last = band.Turn
+		}
+	}
+	if preferred > 0 && !taken {
+		return preferred
+	}
+	return last + 1
+}
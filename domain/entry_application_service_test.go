@@ -0,0 +1,249 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+type EntryApplicationRepositoryMock struct {
+	mock.Mock
+	EntryApplicationRepository
+}
+
+func (m *EntryApplicationRepositoryMock) FindByLiveId(id int, status *ApplicationStatus) ([]*EntryApplication, error) {
+	args := m.Called(id, status)
+	return args.Get(0).([]*EntryApplication), args.Error(1)
+}
+
+func (m *EntryApplicationRepositoryMock) FindById(id int) (*EntryApplication, error) {
+	args := m.Called(id)
+	return args.Get(0).(*EntryApplication), args.Error(1)
+}
+
+func (m *EntryApplicationRepositoryMock) Create(application *EntryApplication) error {
+	args := m.Called(application)
+	return args.Error(0)
+}
+
+func (m *EntryApplicationRepositoryMock) UpdateStatus(id int, current ApplicationStatus, status ApplicationStatus, turn int) error {
+	args := m.Called(id, current, status, turn)
+	return args.Error(0)
+}
+
+func TestEntryApplicationApply(t *testing.T) {
+	// given
+	today := time.Date(2022, 1, 10, 21, 0, 0, 123, time.UTC)
+
+	tests := []struct {
+		// テスト名
+		testName string
+		// ライブの状態
+		status LiveStatus
+		// drummer がメンバーとして登録されているか
+		exists bool
+		// EntryApplication 登録が呼ばれる回数
+		createTimes int
+		// 戻り値の期待値(error)
+		expectedError error
+	}{
+		{
+			testName:    "正常系",
+			status:      LiveEntryOpen,
+			exists:      true,
+			createTimes: 1,
+		},
+		{
+			testName:      "異常系_募集中でないライブには申し込めない",
+			status:        LiveDraft,
+			exists:        true,
+			expectedError: ErrEntryNotOpen,
+		},
+		{
+			testName:      "異常系_メンバーが登録されていない",
+			status:        LiveEntryOpen,
+			expectedError: ErrPlayerNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		liveRepository := new(LiveRepositoryMock)
+		liveRepository.On("FindById", 1).Return(&Live{Id: 1, Status: tc.status}, nil)
+		playerRepository := new(PlayerRepositoryMock)
+		playerRepository.On("FindByName", "drummer").Return(members(tc.exists, &Member{Id: 3, Name: "drummer", Part: []Part{Dr}}), nil)
+		entryApplicationRepository := new(EntryApplicationRepositoryMock)
+		entryApplicationRepository.On("Create", mock.Anything).Return(nil)
+		unitOfWork := &UnitOfWorkMock{repositories: &Repositories{Live: liveRepository, Player: playerRepository, EntryApplication: entryApplicationRepository}}
+		service := NewEntryApplicationServiceImpl(entryApplicationRepository, unitOfWork, nil, func() time.Time { return today })
+		application := &EntryApplication{LiveId: 1, Name: "band", Player: []*Player{{Name: "drummer", Part: Dr}}, Status: ApplicationApproved, Turn: 2}
+
+		// when
+		err := service.Apply(application)
+
+		// then
+		assert.True(t, errors.Is(err, tc.expectedError), fmt.Sprintf("テスト名: %s", tc.testName))
+		entryApplicationRepository.AssertNumberOfCalls(t, "Create", tc.createTimes)
+		if tc.createTimes > 0 {
+			expected := &EntryApplication{LiveId: 1, Name: "band", Player: []*Player{{MemberId: 3, Name: "drummer", Part: Dr}},
+				Status: ApplicationPending, AppliedAt: today.Truncate(time.Second)}
+			assert.Equal(t, expected, application, fmt.Sprintf("テスト名: %s", tc.testName))
+		}
+	}
+}
+
+func TestEntryApplicationApprove(t *testing.T) {
+	// given
+	drummer := &Player{MemberId: 3, Name: "drummer", Part: Dr}
+	bands := []*Band{{Name: "band1", LiveId: 1, Turn: 1, SetLength: 30}, {Name: "band2", LiveId: 1, Turn: 3, SetLength: 30}}
+	// drummer は登録済みの2つのバンドに続けて出演している
+	bandMembers := []*BandMember{{LiveId: 1, Turn: 1, MemberName: "drummer", MemberPart: Dr}, {LiveId: 1, Turn: 3, MemberName: "drummer", MemberPart: Dr}}
+	conflicts := []*Conflict{{Kind: BackToBack, PlayerName: "drummer", LiveId: 1, Turn: 1, OtherLiveId: 1, OtherTurn: 3}}
+	overrun := fmt.Errorf("%w: over", ErrTimetableOverrun)
+	expectedError := fmt.Errorf("dummy message")
+
+	tests := []struct {
+		// テスト名
+		testName string
+		// 申込
+		application *EntryApplication
+		// 指定する出演順
+		turn int
+		// タイムテーブルの確認結果
+		checkError error
+		// 出演の重複の確認時のエラー
+		conflictError error
+		// 戻り値の期待値(出演順)
+		expectedTurn int
+		// 戻り値の期待値(error)
+		expectedError error
+	}{
+		{
+			testName:     "正常系_希望する出演順が空いている",
+			application:  &EntryApplication{Id: 5, LiveId: 1, Name: "new", Player: []*Player{drummer}, PreferredTurn: 2, SetLength: 20, Status: ApplicationPending},
+			expectedTurn: 2,
+		},
+		{
+			testName:     "正常系_希望する出演順が埋まっている場合は最後になる",
+			application:  &EntryApplication{Id: 5, LiveId: 1, Name: "new", Player: []*Player{drummer}, PreferredTurn: 3, SetLength: 20, Status: ApplicationPending},
+			expectedTurn: 4,
+		},
+		{
+			testName:     "正常系_補欠から出演順を指定して承認する",
+			application:  &EntryApplication{Id: 5, LiveId: 1, Name: "new", Player: []*Player{drummer}, PreferredTurn: 2, SetLength: 20, Status: ApplicationWaitlisted},
+			turn:         6,
+			expectedTurn: 6,
+		},
+		{
+			testName:      "異常系_却下した申込は承認できない",
+			application:   &EntryApplication{Id: 5, LiveId: 1, Name: "new", Player: []*Player{drummer}, SetLength: 20, Status: ApplicationRejected},
+			expectedError: ErrInvalidApplicationTransition,
+		},
+		{
+			testName:      "異常系_他のライブの申込",
+			application:   &EntryApplication{Id: 5, LiveId: 2, Name: "new", Player: []*Player{drummer}, SetLength: 20, Status: ApplicationPending},
+			expectedError: ErrApplicationNotFound,
+		},
+		{
+			testName:      "異常系_終演時刻を超える",
+			application:   &EntryApplication{Id: 5, LiveId: 1, Name: "new", Player: []*Player{drummer}, SetLength: 20, Status: ApplicationPending},
+			checkError:    overrun,
+			expectedError: ErrTimetableOverrun,
+		},
+		{
+			testName:      "異常系_承認後の重複の確認時にエラー発生",
+			application:   &EntryApplication{Id: 5, LiveId: 1, Name: "new", Player: []*Player{drummer}, SetLength: 20, Status: ApplicationPending},
+			conflictError: expectedError,
+			expectedError: expectedError,
+		},
+	}
+
+	for _, tc := range tests {
+		bandRepository := new(BandRepositoryMock)
+		bandRepository.On("FindByLiveId", 1).Return(bands, nil)
+		bandRepository.On("Create", mock.Anything).Return(nil)
+		bandMemberRepository := new(BandMemberRepositoryMock)
+		bandMemberRepository.On("Create", mock.Anything).Return(nil)
+		bandMemberRepository.On("FindByLiveId", 1).Return(bandMembers, nil)
+		timetableService := new(TimetableServiceMock)
+		timetableService.On("Check", 1, mock.Anything).Return(tc.checkError)
+		entryApplicationRepository := new(EntryApplicationRepositoryMock)
+		entryApplicationRepository.On("FindById", 5).Return(tc.application, nil)
+		entryApplicationRepository.On("UpdateStatus", 5, tc.application.Status, ApplicationApproved, mock.Anything).Return(nil)
+		repositories := conflictRepositories(bandMemberRepository, nil, nil, tc.conflictError)
+		repositories.Band, repositories.EntryApplication = bandRepository, entryApplicationRepository
+		service := NewEntryApplicationServiceImpl(entryApplicationRepository, &UnitOfWorkMock{repositories: repositories}, timetableService, time.Now)
+		current := tc.application.Status
+
+		// when
+		application, approvedConflicts, err := service.Approve(1, 5, tc.turn)
+
+		// then
+		assert.True(t, errors.Is(err, tc.expectedError), fmt.Sprintf("テスト名: %s", tc.testName))
+		if tc.expectedError == nil {
+			assert.Equal(t, ApplicationApproved, application.Status, fmt.Sprintf("テスト名: %s", tc.testName))
+			assert.Equal(t, tc.expectedTurn, application.Turn, fmt.Sprintf("テスト名: %s", tc.testName))
+			bandRepository.AssertCalled(t, "Create", &Band{Name: "new", LiveId: 1, Turn: tc.expectedTurn, SetLength: 20})
+			bandMemberRepository.AssertCalled(t, "Create", &BandMember{LiveId: 1, Turn: tc.expectedTurn, MemberId: 3, MemberName: "drummer", MemberPart: Dr})
+			entryApplicationRepository.AssertCalled(t, "UpdateStatus", 5, current, ApplicationApproved, tc.expectedTurn)
+			assert.Equal(t, conflicts, approvedConflicts, fmt.Sprintf("テスト名: %s", tc.testName))
+		} else if tc.conflictError != nil {
+			// 確認に失敗した場合は承認と同じトランザクションを取り消すため、申込も重複も返さない
+			assert.Nil(t, application, fmt.Sprintf("テスト名: %s", tc.testName))
+			assert.Nil(t, approvedConflicts, fmt.Sprintf("テスト名: %s", tc.testName))
+		} else {
+			assert.Nil(t, application, fmt.Sprintf("テスト名: %s", tc.testName))
+			repositories.Live.(*LiveRepositoryMock).AssertNotCalled(t, "FindById", mock.Anything)
+			bandRepository.AssertNotCalled(t, "Create", mock.Anything)
+			entryApplicationRepository.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		}
+	}
+}
+
+func TestEntryApplicationChangeStatus(t *testing.T) {
+	// given
+	tests := []struct {
+		// テスト名
+		testName string
+		// 申込の現在の状態
+		current ApplicationStatus
+		// 変更後の状態
+		status ApplicationStatus
+		// 戻り値の期待値(error)
+		expectedError error
+	}{
+		{testName: "正常系_審査待ちを補欠にする", current: ApplicationPending, status: ApplicationWaitlisted},
+		{testName: "正常系_補欠を却下する", current: ApplicationWaitlisted, status: ApplicationRejected},
+		{testName: "異常系_補欠を補欠にする", current: ApplicationWaitlisted, status: ApplicationWaitlisted, expectedError: ErrInvalidApplicationTransition},
+		{testName: "異常系_承認した申込は却下できない", current: ApplicationApproved, status: ApplicationRejected, expectedError: ErrInvalidApplicationTransition},
+	}
+
+	for _, tc := range tests {
+		entryApplicationRepository := new(EntryApplicationRepositoryMock)
+		entryApplicationRepository.On("FindById", 5).Return(&EntryApplication{Id: 5, LiveId: 1, Status: tc.current}, nil)
+		entryApplicationRepository.On("UpdateStatus", 5, tc.current, tc.status, 0).Return(nil)
+		unitOfWork := &UnitOfWorkMock{repositories: &Repositories{EntryApplication: entryApplicationRepository}}
+		service := NewEntryApplicationServiceImpl(entryApplicationRepository, unitOfWork, nil, time.Now)
+
+		// when
+		var application *EntryApplication
+		var err error
+		if tc.status == ApplicationRejected {
+			application, err = service.Reject(1, 5)
+		} else {
+			application, err = service.Waitlist(1, 5)
+		}
+
+		// then
+		assert.True(t, errors.Is(err, tc.expectedError), fmt.Sprintf("テスト名: %s", tc.testName))
+		if tc.expectedError == nil {
+			assert.Equal(t, &EntryApplication{Id: 5, LiveId: 1, Status: tc.status}, application, fmt.Sprintf("テスト名: %s", tc.testName))
+			entryApplicationRepository.AssertCalled(t, "UpdateStatus", 5, tc.current, tc.status, 0)
+		} else {
+			assert.Nil(t, application, fmt.Sprintf("テスト名: %s", tc.testName))
+			entryApplicationRepository.AssertNotCalled(t, "UpdateStatus", 5, tc.current, tc.status, 0)
+		}
+	}
+}
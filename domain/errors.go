@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"errors"
+)
+
+// エラーの分類。サービスやリポジトリが返すエラーは errors.Is でいずれかの分類と比較できる
+var (
+	// ErrNotFound 指定したデータが存在しない場合のエラー
+	ErrNotFound = errors.New("not found")
+	// ErrConflict 主キーや一意キーの重複など、登録済みのデータと矛盾する場合のエラー
+	ErrConflict = errors.New("conflict")
+	// ErrValidation 入力が業務上のルールを満たさない場合のエラー
+	ErrValidation = errors.New("validation failed")
+	// ErrForeignKeyViolation 参照先のデータが存在しない、または参照されているデータを削除・変更しようとした場合のエラー
+	ErrForeignKeyViolation = errors.New("foreign key violation")
+	// ErrBadRequest 存在しないパートの指定など、リクエストの値として受け付けられない場合のエラー
+	ErrBadRequest = errors.New("bad request")
+	// ErrPreconditionFailed 更新・削除しようとしたデータのバージョンが指定したものと異なる場合のエラー
+	ErrPreconditionFailed = errors.New("precondition failed")
+)
+
+// classifiedError メッセージを変えずに分類を持たせたエラー
+type classifiedError struct {
+	kind    error
+	message string
+}
+
+// newError kind に分類されるエラーを返す
+func newError(kind error, message string) error {
+	return &classifiedError{kind: kind, message: message}
+}
+
+func (e *classifiedError) Error() string {
+	return e.message
+}
+
+func (e *classifiedError) Unwrap() error {
+	return e.kind
+}
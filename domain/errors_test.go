@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestErrorKind(t *testing.T) {
+	// given
+	tests := []struct {
+		// テスト名
+		testName string
+		// サービスが返すエラー
+		err error
+		// 分類の期待値
+		expectedKind error
+		// メッセージの期待値
+		expectedMessage string
+	}{
+		{
+			testName:        "正常系_存在しない",
+			err:             ErrBandNotFound,
+			expectedKind:    ErrNotFound,
+			expectedMessage: "band not found",
+		},
+		{
+			testName:        "正常系_重複",
+			err:             ErrMemberNameTaken,
+			expectedKind:    ErrConflict,
+			expectedMessage: "member name is already taken",
+		},
+		{
+			testName:        "正常系_不正なリクエスト",
+			err:             fmt.Errorf("%w: \"kazoo\"", ErrUnknownPart),
+			expectedKind:    ErrBadRequest,
+			expectedMessage: "unknown part: \"kazoo\"",
+		},
+		{
+			testName:        "正常系_ラップしたエラー",
+			err:             fmt.Errorf("%w: from 1 to 3", ErrInvalidTurnOrder),
+			expectedKind:    ErrValidation,
+			expectedMessage: "invalid turn order: from 1 to 3",
+		},
+	}
+
+	for _, tc := range tests {
+		// then
+		assert.True(t, errors.Is(tc.err, tc.expectedKind), tc.testName)
+		assert.Equal(t, tc.expectedMessage, tc.err.Error(), tc.testName)
+	}
+}
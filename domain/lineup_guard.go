@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ErrLineupLocked ライブの状態が出演バンドやバンドメンバーの変更を受け付けない場合のエラー
+var ErrLineupLocked = newError(ErrConflict, "lineup is locked")
+
+// GuardLineupChanges 出演バンドとバンドメンバーへの書き込みの前にライブの状態を確認するリポジトリの組を返す。
+// 状態が LiveStatus.AcceptsLineupChanges を満たさない場合は書き込まずに ErrLineupLocked を返す。
+// 確認したライブはコミットまで状態を変えられないよう LiveRepository.FindByIdForUpdate で読み込むため、
+// GuardedUnitOfWork または AutoCommitWrites を通してトランザクションの中で使う。
+// ライブの削除に使う DeleteByLiveId は確認しない
+func GuardLineupChanges(repositories *Repositories) *Repositories {
+	guard := &lineupGuard{live: repositories.Live}
+	return &Repositories{
+		Live:             repositories.Live,
+		Band:             &guardedBandRepository{BandRepository: repositories.Band, guard: guard},
+		BandMember:       &guardedBandMemberRepository{BandMemberRepository: repositories.BandMember, guard: guard},
+		Player:           repositories.Player,
+		Payment:          repositories.Payment,
+		LineupRule:       repositories.LineupRule,
+		BandProfile:      repositories.BandProfile,
+		EntryApplication: repositories.EntryApplication,
+	}
+}
+
+type lineupGuard struct {
+	live LiveRepository
+}
+
+// check ライブが出演バンドやバンドメンバーの変更を受け付ける状態か確認する。
+// 複数のライブをロックする場合は、トランザクションの間でデッドロックしないよう ID の順に読み込む
+func (g *lineupGuard) check(ids ...int) error {
+	ids = append([]int{}, ids...)
+	sort.Ints(ids)
+	for _, id := range ids {
+		live, err := g.live.FindByIdForUpdate(id)
+		if err != nil {
+			return err
+		}
+		if !live.Status.AcceptsLineupChanges() {
+			return fmt.Errorf("%w: live %d is %s", ErrLineupLocked, id, live.Status)
+		}
+	}
+	return nil
+}
+
+type guardedBandRepository struct {
+	BandRepository
+	guard *lineupGuard
+}
+
+func (r *guardedBandRepository) Create(band *Band) error {
+	if err := r.guard.check(band.LiveId); err != nil {
+		return err
+	}
+	return r.BandRepository.Create(band)
+}
+
+func (r *guardedBandRepository) Patch(id int, turn int, version int, patch *BandPatch) error {
+	if err := r.guard.check(id); err != nil {
+		return err
+	}
+	return r.BandRepository.Patch(id, turn, version, patch)
+}
+
+func (r *guardedBandRepository) Delete(id int, turn int, version int) error {
+	if err := r.guard.check(id); err != nil {
+		return err
+	}
+	return r.BandRepository.Delete(id, turn, version)
+}
+
+type guardedBandMemberRepository struct {
+	BandMemberRepository
+	guard *lineupGuard
+}
+
+func (r *guardedBandMemberRepository) Create(bandMember *BandMember) error {
+	if err := r.guard.check(bandMember.LiveId); err != nil {
+		return err
+	}
+	return r.BandMemberRepository.Create(bandMember)
+}
+
+func (r *guardedBandMemberRepository) Delete(bandMember *BandMember) error {
+	if err := r.guard.check(bandMember.LiveId); err != nil {
+		return err
+	}
+	return r.BandMemberRepository.Delete(bandMember)
+}
+
+func (r *guardedBandMemberRepository) Update(current *BandMember, replacement *BandMember) error {
+	ids := []int{current.LiveId}
+	if replacement.LiveId != current.LiveId {
+		ids = append(ids, replacement.LiveId)
+	}
+	if err := r.guard.check(ids...); err != nil {
+		return err
+	}
+	return r.BandMemberRepository.Update(current, replacement)
+}
+
+// GuardedUnitOfWork トランザクション内の書き込みを GuardLineupChanges で確認する
+type GuardedUnitOfWork struct {
+	unitOfWork UnitOfWork
+}
+
+func NewGuardedUnitOfWork(unitOfWork UnitOfWork) *GuardedUnitOfWork {
+	return &GuardedUnitOfWork{unitOfWork: unitOfWork}
+}
+
+func (u *GuardedUnitOfWork) Do(fn func(repositories *Repositories) error) error {
+	return u.unitOfWork.Do(func(repositories *Repositories) error {
+		return fn(GuardLineupChanges(repositories))
+	})
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestGuardLineupChanges(t *testing.T) {
+	// given
+	tests := []struct {
+		// テスト名
+		testName string
+		// ライブの状態
+		status LiveStatus
+		// 確認するリポジトリへの書き込み
+		write func(repositories *Repositories) error
+		// 書き込むか
+		expectedWrite bool
+		// 戻り値の期待値(error)
+		expectedError error
+	}{
+		{
+			testName:      "正常系_募集中は出演バンドを登録できる",
+			status:        LiveEntryOpen,
+			write:         func(r *Repositories) error { return r.Band.Create(&Band{Name: "band", LiveId: 1, Turn: 1}) },
+			expectedWrite: true,
+		},
+		{
+			testName:      "異常系_確定したライブには出演バンドを登録できない",
+			status:        LiveConfirmed,
+			write:         func(r *Repositories) error { return r.Band.Create(&Band{Name: "band", LiveId: 1, Turn: 1}) },
+			expectedError: ErrLineupLocked,
+		},
+		{
+			testName: "異常系_中止したライブのメンバーは変更できない",
+			status:   LiveCancelled,
+			write: func(r *Repositories) error {
+				return r.BandMember.Create(&BandMember{LiveId: 1, Turn: 1, MemberId: 1, MemberPart: Dr})
+			},
+			expectedError: ErrLineupLocked,
+		},
+		{
+			testName:      "正常系_ライブの削除前の出演バンドの削除は確認しない",
+			status:        LiveDone,
+			write:         func(r *Repositories) error { return r.Band.DeleteByLiveId(1) },
+			expectedWrite: true,
+		},
+	}
+
+	for _, tc := range tests {
+		liveRepository := new(LiveRepositoryMock)
+		liveRepository.On("FindByIdForUpdate", 1).Return(&Live{Id: 1, Status: tc.status}, nil)
+		var written bool
+		bandRepository := new(BandRepositoryMock)
+		bandRepository.On("Create", mock.Anything).Return(nil).Run(func(mock.Arguments) { written = true })
+		bandRepository.On("DeleteByLiveId", 1).Return(nil).Run(func(mock.Arguments) { written = true })
+		bandMemberRepository := new(BandMemberRepositoryMock)
+		bandMemberRepository.On("Create", mock.Anything).Return(nil).Run(func(mock.Arguments) { written = true })
+		repositories := GuardLineupChanges(&Repositories{Live: liveRepository, Band: bandRepository, BandMember: bandMemberRepository})
+
+		// when
+		err := tc.write(repositories)
+
+		// then
+		assert.True(t, errors.Is(err, tc.expectedError), fmt.Sprintf("テスト名: %s", tc.testName))
+		assert.Equal(t, tc.expectedWrite, written, fmt.Sprintf("テスト名: %s", tc.testName))
+	}
+}
@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"fmt"
+)
+
+// DefaultLineupRule ルールが設定されていないライブに適用するルール(Dr. と Ba. が1人ずつ)
+func DefaultLineupRule(id int) *LineupRule {
+	return &LineupRule{
+		LiveId: id,
+		Part: []*PartRule{
+			{Part: Dr, Min: 1, Max: 1},
+			{Part: Ba, Min: 1, Max: 1},
+		},
+		GtVoCountsAsVo: true,
+		GtVoCountsAsGt: true,
+	}
+}
+
+// LineupRuleService ライブごとのバンド編成のルールを管理し、出演バンドが満たしているか確認する
+type LineupRuleService interface {
+	GetByLiveId(id int) (*LineupRule, error)
+	Save(rule *LineupRule) error
+	Validate(liveModel *LiveModel) ([]*BandValidation, error)
+}
+
+type LineupRuleServiceImpl struct {
+	lineupRuleRepository LineupRuleRepository
+	unitOfWork           UnitOfWork
+}
+
+func NewLineupRuleServiceImpl(lineupRuleRepository LineupRuleRepository, unitOfWork UnitOfWork) *LineupRuleServiceImpl {
+	return &LineupRuleServiceImpl{lineupRuleRepository: lineupRuleRepository, unitOfWork: unitOfWork}
+}
+
+func (l *LineupRuleServiceImpl) GetByLiveId(id int) (*LineupRule, error) {
+	rule, err := l.lineupRuleRepository.FindByLiveId(id)
+	if err != nil {
+		return nil, err
+	}
+	if rule == nil {
+		return DefaultLineupRule(id), nil
+	}
+	return rule, nil
+}
+
+func (l *LineupRuleServiceImpl) Save(rule *LineupRule) error {
+	return l.unitOfWork.Do(func(repositories *Repositories) error {
+		return repositories.LineupRule.Save(rule)
+	})
+}
+
+func (l *LineupRuleServiceImpl) Validate(liveModel *LiveModel) ([]*BandValidation, error) {
+	rule, err := l.GetByLiveId(liveModel.Id)
+	if err != nil {
+		return nil, err
+	}
+	var validations []*BandValidation
+	for _, band := range liveModel.Band {
+		validations = append(validations, ValidateBand(rule, band))
+	}
+	return validations, nil
+}
+
+// ValidateBand バンドのメンバーが編成のルールを満たしているか確認する。
+// 人数は同じメンバーが複数パートを担当していても1人と数える
+func ValidateBand(rule *LineupRule, band *BandModel) *BandValidation {
+	validation := &BandValidation{Name: band.Name, Turn: band.Turn}
+
+	members := len(memberNames(band))
+	if rule.MinMembers > 0 && members < rule.MinMembers {
+		validation.Violation = append(validation.Violation, fmt.Sprintf("at least %d members are required but has %d", rule.MinMembers, members))
+	}
+	if rule.MaxMembers > 0 && members > rule.MaxMembers {
+		validation.Violation = append(validation.Violation, fmt.Sprintf("at most %d members are allowed but has %d", rule.MaxMembers, members))
+	}
+
+	counts := map[Part]int{}
+	for _, player := range band.Player {
+		counts[player.Part]++
+		if player.Part == GtVo {
+			if rule.GtVoCountsAsVo {
+				counts[Vo]++
+			}
+			if rule.GtVoCountsAsGt {
+				counts[Gt]++
+			}
+		}
+	}
+	for _, partRule := range rule.Part {
+		count := counts[partRule.Part]
+		if count < partRule.Min {
+			validation.Violation = append(validation.Violation, fmt.Sprintf("at least %d %s required but has %d", partRule.Min, partRule.Part, count))
+		}
+		if partRule.Max > 0 && count > partRule.Max {
+			validation.Violation = append(validation.Violation, fmt.Sprintf("at most %d %s allowed but has %d", partRule.Max, partRule.Part, count))
+		}
+	}
+	validation.Valid = len(validation.Violation) == 0
+	return validation
+}
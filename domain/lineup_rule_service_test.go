@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+type LineupRuleRepositoryMock struct {
+	mock.Mock
+	LineupRuleRepository
+}
+
+func (m *LineupRuleRepositoryMock) FindByLiveId(id int) (*LineupRule, error) {
+	args := m.Called(id)
+	return args.Get(0).(*LineupRule), args.Error(1)
+}
+
+func TestValidateBand(t *testing.T) {
+	// given
+	rule := LineupRule{
+		Part: []*PartRule{
+			{Part: Dr, Min: 1, Max: 1},
+			{Part: Ba, Min: 1, Max: 1},
+			{Part: Vo, Min: 1},
+		},
+		MinMembers:     3,
+		MaxMembers:     5,
+		GtVoCountsAsVo: true,
+	}
+
+	tests := []struct {
+		// テスト名
+		testName string
+		// バンドのメンバー
+		players []*Player
+		// Gt.Vo. を Vo. の人数に含めるか
+		gtVoCountsAsVo bool
+		// 戻り値の期待値(満たしていないルール)
+		expectedViolation []string
+	}{
+		{
+			testName:       "正常系_Gt.Vo.をVo.として数える",
+			players:        []*Player{{Name: "a", Part: Dr}, {Name: "b", Part: Ba}, {Name: "c", Part: GtVo}},
+			gtVoCountsAsVo: true,
+		},
+		{
+			testName:          "異常系_Gt.Vo.をVo.として数えない",
+			players:           []*Player{{Name: "a", Part: Dr}, {Name: "b", Part: Ba}, {Name: "c", Part: GtVo}},
+			gtVoCountsAsVo:    false,
+			expectedViolation: []string{"at least 1 Vo. required but has 0"},
+		},
+		{
+			testName:       "異常系_ドラムがいない_ベースが多すぎる",
+			players:        []*Player{{Name: "a", Part: Ba}, {Name: "b", Part: Ba}, {Name: "c", Part: Ba}, {Name: "d", Part: Vo}},
+			gtVoCountsAsVo: true,
+			expectedViolation: []string{
+				"at least 1 Dr. required but has 0",
+				"at most 1 Ba. allowed but has 3",
+			},
+		},
+		{
+			testName:       "異常系_人数が足りない",
+			players:        []*Player{{Name: "a", Part: Dr}, {Name: "b", Part: Ba}, {Name: "b", Part: Vo}},
+			gtVoCountsAsVo: true,
+			expectedViolation: []string{
+				"at least 3 members are required but has 2",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		rule.GtVoCountsAsVo = tc.gtVoCountsAsVo
+		band := BandModel{Name: "band", Turn: 1, Player: tc.players}
+
+		// when
+		actual := ValidateBand(&rule, &band)
+
+		// then
+		assert.Equal(t, tc.expectedViolation == nil, actual.Valid, fmt.Sprintf("テスト名: %s", tc.testName))
+		assert.Equal(t, tc.expectedViolation, actual.Violation, fmt.Sprintf("テスト名: %s", tc.testName))
+	}
+}
+
+func TestLineupRuleGetByLiveIdDefault(t *testing.T) {
+	// given
+	lineupRuleRepository := new(LineupRuleRepositoryMock)
+	lineupRuleRepository.On("FindByLiveId", 1).Return((*LineupRule)(nil), nil)
+	lineupRuleService := NewLineupRuleServiceImpl(lineupRuleRepository, nil)
+
+	// when
+	actual, err := lineupRuleService.GetByLiveId(1)
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, DefaultLineupRule(1), actual)
+}
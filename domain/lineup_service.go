@@ -0,0 +1,59 @@
+package domain
+
+// LineupService ライブの出演バンドとメンバーをまとめて登録する
+type LineupService interface {
+	// Register 出演バンドとメンバーを登録し、登録後のライブの出演の重複を返す。
+	// 重複の確認は登録と同じトランザクションで行い、確認に失敗した場合は登録しない
+	Register(id int, bands []*BandModel) ([]*Conflict, error)
+}
+
+type LineupServiceImpl struct {
+	unitOfWork       UnitOfWork
+	timetableService TimetableService
+}
+
+func NewLineupServiceImpl(unitOfWork UnitOfWork, timetableService TimetableService) *LineupServiceImpl {
+	return &LineupServiceImpl{unitOfWork: unitOfWork, timetableService: timetableService}
+}
+
+func (l *LineupServiceImpl) Register(id int, bands []*BandModel) ([]*Conflict, error) {
+	var conflicts []*Conflict
+	err := l.unitOfWork.Do(func(repositories *Repositories) error {
+		lineup, err := repositories.Band.FindByLiveId(id)
+		if err != nil {
+			return err
+		}
+		for _, band := range bands {
+			lineup = append(lineup, &Band{Name: band.Name, LiveId: id, Turn: band.Turn, SetLength: band.SetLength, BandId: band.BandId})
+		}
+		if err := l.timetableService.Check(id, lineup); err != nil {
+			return err
+		}
+
+		for _, band := range bands {
+			err := repositories.Band.Create(&Band{Name: band.Name, LiveId: id, Turn: band.Turn, SetLength: band.SetLength, BandId: band.BandId})
+			if err != nil {
+				return err
+			}
+			for _, player := range band.Player {
+				if err := resolvePlayer(repositories.Player, player); err != nil {
+					return err
+				}
+				err = repositories.BandMember.Create(&BandMember{LiveId: id, Turn: band.Turn, MemberId: player.MemberId, MemberName: player.Name, MemberPart: player.Part})
+				if err != nil {
+					return err
+				}
+			}
+		}
+		checked, err := checkLiveConflicts(repositories, id)
+		if err != nil {
+			return err
+		}
+		conflicts = checked
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return conflicts, nil
+}
@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+// UnitOfWorkMock 与えられたリポジトリで fn をそのまま実行する UnitOfWork
+type UnitOfWorkMock struct {
+	repositories *Repositories
+}
+
+func (u *UnitOfWorkMock) Do(fn func(repositories *Repositories) error) error {
+	return fn(u.repositories)
+}
+
+func (m *BandRepositoryMock) Create(band *Band) error {
+	args := m.Called(band)
+	return args.Error(0)
+}
+
+func TestLineupRegister(t *testing.T) {
+	// given
+	drummer := Player{Name: "drummer", Part: Dr}
+	bassist := Player{Name: "bassist", Part: Ba}
+	bands := []*BandModel{
+		{Name: "band1", LiveId: 1, Turn: 1, Player: []*Player{&drummer}},
+		{Name: "band2", LiveId: 1, Turn: 2, Player: []*Player{&bassist}},
+	}
+	expectedError := fmt.Errorf("dummy message")
+
+	tests := []struct {
+		// テスト名
+		testName string
+		// bassist がメンバーとして登録されているか
+		bassistExists bool
+		// band2 登録時のエラー
+		bandError error
+		// 重複の確認時のエラー
+		checkError error
+		// BandMember 登録が呼ばれる回数
+		createMemberTimes int
+		// 戻り値の期待値(error)
+		expectedError error
+	}{
+		{
+			testName:          "正常系",
+			bassistExists:     true,
+			createMemberTimes: 2,
+			expectedError:     nil,
+		},
+		{
+			testName:          "異常系_Playerに登録されていないメンバー",
+			bassistExists:     false,
+			createMemberTimes: 1,
+			expectedError:     ErrPlayerNotFound,
+		},
+		{
+			testName:          "異常系_登録後の重複の確認時にエラー発生",
+			bassistExists:     true,
+			checkError:        expectedError,
+			createMemberTimes: 2,
+			expectedError:     expectedError,
+		},
+		{
+			testName:          "異常系_Band登録時にエラー発生",
+			bassistExists:     true,
+			bandError:         expectedError,
+			createMemberTimes: 1,
+			expectedError:     expectedError,
+		},
+	}
+
+	for _, tc := range tests {
+		bandRepository := new(BandRepositoryMock)
+		bandRepository.On("FindByLiveId", 1).Return([]*Band{}, nil)
+		bandRepository.
+			On("Create", &Band{Name: "band1", LiveId: 1, Turn: 1}).Return(nil).Once().
+			On("Create", &Band{Name: "band2", LiveId: 1, Turn: 2}).Return(tc.bandError).Once()
+		bandMemberRepository := new(BandMemberRepositoryMock)
+		bandMemberRepository.
+			On("Create", &BandMember{LiveId: 1, Turn: 1, MemberId: 1, MemberName: "drummer", MemberPart: Dr}).Return(nil).
+			On("Create", &BandMember{LiveId: 1, Turn: 2, MemberId: 2, MemberName: "bassist", MemberPart: Ba}).Return(nil)
+		bandMemberRepository.On("FindByLiveId", 1).Return([]*BandMember{}, nil)
+		playerRepository := new(PlayerRepositoryMock)
+		playerRepository.
+			On("FindByName", "drummer").Return(&Member{Id: 1, Name: "drummer", Part: []Part{Dr}}, nil).
+			On("FindByName", "bassist").Return(members(tc.bassistExists, &Member{Id: 2, Name: "bassist", Part: []Part{Ba}}), nil)
+		timetableService := new(TimetableServiceMock)
+		timetableService.On("Check", 1, mock.Anything).Return(nil)
+		repositories := conflictRepositories(bandMemberRepository, playerRepository, nil, tc.checkError)
+		repositories.Band = bandRepository
+		lineupService := NewLineupServiceImpl(&UnitOfWorkMock{repositories: repositories}, timetableService)
+
+		// when
+		conflicts, err := lineupService.Register(1, bands)
+
+		// then
+		assert.True(t, errors.Is(err, tc.expectedError), fmt.Sprintf("テスト名: %s", tc.testName))
+		assert.Empty(t, conflicts, fmt.Sprintf("テスト名: %s", tc.testName))
+		bandMemberRepository.AssertNumberOfCalls(t, "Create", tc.createMemberTimes)
+	}
+}
@@ -0,0 +1,251 @@
+package domain
+
+// LiveChangeListener ライブの内容(出演バンド、メンバー、編成のルールを含む)が変わったことを受け取る
+type LiveChangeListener interface {
+	LiveChanged(id int)
+}
+
+// TrackLiveChanges 出演バンド・バンドメンバー・編成のルール・メンバー名・バンド名の変更をライブの変更として扱うリポジトリの組と、
+// 変更を反映する flush を返す。flush は変更したライブのバージョンを1回ずつ上げ(LiveRepository.Touch)、その ID を changed に渡す。
+// 同じライブに何度書き込んでもバージョンは1回だけ上がるよう、flush はトランザクションの最後に1度だけ呼ぶ
+func TrackLiveChanges(repositories *Repositories, changed func(id int)) (tracked *Repositories, flush func() error) {
+	tracker := &liveTracker{live: repositories.Live, changed: changed, seen: map[int]bool{}, updated: map[int]bool{}}
+	return &Repositories{
+		Live:             &trackedLiveRepository{LiveRepository: repositories.Live, tracker: tracker},
+		Band:             &trackedBandRepository{BandRepository: repositories.Band, tracker: tracker},
+		BandMember:       &trackedBandMemberRepository{BandMemberRepository: repositories.BandMember, tracker: tracker},
+		Player:           &trackedPlayerRepository{PlayerRepository: repositories.Player, bandMember: repositories.BandMember, tracker: tracker},
+		Payment:          repositories.Payment,
+		LineupRule:       &trackedLineupRuleRepository{LineupRuleRepository: repositories.LineupRule, tracker: tracker},
+		BandProfile:      &trackedBandProfileRepository{BandProfileRepository: repositories.BandProfile, band: repositories.Band, tracker: tracker},
+		EntryApplication: repositories.EntryApplication,
+	}, tracker.flush
+}
+
+type liveTracker struct {
+	live    LiveRepository
+	changed func(id int)
+	// touched flush でバージョンを上げるライブ
+	touched []int
+	seen    map[int]bool
+	// updated ライブ自体を更新・削除したため、flush でバージョンを上げないライブ
+	updated map[int]bool
+}
+
+// touch 変更したライブを記録する。バージョンは flush でまとめて上げる
+func (t *liveTracker) touch(ids ...int) {
+	for _, id := range ids {
+		if t.seen[id] {
+			continue
+		}
+		t.seen[id] = true
+		t.touched = append(t.touched, id)
+	}
+}
+
+// update ライブ自体の更新・削除でバージョンが変わったことを記録し、変更を通知する
+func (t *liveTracker) update(id int) {
+	t.updated[id] = true
+	t.changed(id)
+}
+
+// flush 記録したライブのバージョンを1回ずつ上げて変更を通知する
+func (t *liveTracker) flush() error {
+	for _, id := range t.touched {
+		if t.updated[id] {
+			continue
+		}
+		if err := t.live.Touch(id); err != nil {
+			return err
+		}
+		t.changed(id)
+	}
+	return nil
+}
+
+type trackedLiveRepository struct {
+	LiveRepository
+	tracker *liveTracker
+}
+
+func (r *trackedLiveRepository) Patch(id int, version int, patch *LivePatch) error {
+	if err := r.LiveRepository.Patch(id, version, patch); err != nil {
+		return err
+	}
+	r.tracker.update(id)
+	return nil
+}
+
+func (r *trackedLiveRepository) Delete(id int, version int) error {
+	if err := r.LiveRepository.Delete(id, version); err != nil {
+		return err
+	}
+	r.tracker.update(id)
+	return nil
+}
+
+type trackedBandRepository struct {
+	BandRepository
+	tracker *liveTracker
+}
+
+func (r *trackedBandRepository) Create(band *Band) error {
+	if err := r.BandRepository.Create(band); err != nil {
+		return err
+	}
+	r.tracker.touch(band.LiveId)
+	return nil
+}
+
+func (r *trackedBandRepository) Patch(id int, turn int, version int, patch *BandPatch) error {
+	if err := r.BandRepository.Patch(id, turn, version, patch); err != nil {
+		return err
+	}
+	r.tracker.touch(id)
+	return nil
+}
+
+func (r *trackedBandRepository) Delete(id int, turn int, version int) error {
+	if err := r.BandRepository.Delete(id, turn, version); err != nil {
+		return err
+	}
+	r.tracker.touch(id)
+	return nil
+}
+
+// DeleteByLiveId ライブを削除する前に使うため、ライブのバージョンは上げずに通知だけする
+func (r *trackedBandRepository) DeleteByLiveId(id int) error {
+	if err := r.BandRepository.DeleteByLiveId(id); err != nil {
+		return err
+	}
+	r.tracker.changed(id)
+	return nil
+}
+
+type trackedBandMemberRepository struct {
+	BandMemberRepository
+	tracker *liveTracker
+}
+
+func (r *trackedBandMemberRepository) Create(bandMember *BandMember) error {
+	if err := r.BandMemberRepository.Create(bandMember); err != nil {
+		return err
+	}
+	r.tracker.touch(bandMember.LiveId)
+	return nil
+}
+
+func (r *trackedBandMemberRepository) Delete(bandMember *BandMember) error {
+	if err := r.BandMemberRepository.Delete(bandMember); err != nil {
+		return err
+	}
+	r.tracker.touch(bandMember.LiveId)
+	return nil
+}
+
+func (r *trackedBandMemberRepository) Update(current *BandMember, replacement *BandMember) error {
+	if err := r.BandMemberRepository.Update(current, replacement); err != nil {
+		return err
+	}
+	r.tracker.touch(current.LiveId, replacement.LiveId)
+	return nil
+}
+
+// DeleteByLiveId ライブを削除する前に使うため、ライブのバージョンは上げずに通知だけする
+func (r *trackedBandMemberRepository) DeleteByLiveId(id int) error {
+	if err := r.BandMemberRepository.DeleteByLiveId(id); err != nil {
+		return err
+	}
+	r.tracker.changed(id)
+	return nil
+}
+
+// trackedPlayerRepository メンバー名の変更は出演したすべてのライブの変更として扱う
+type trackedPlayerRepository struct {
+	PlayerRepository
+	bandMember BandMemberRepository
+	tracker    *liveTracker
+}
+
+func (r *trackedPlayerRepository) Rename(id int, name string) error {
+	if err := r.PlayerRepository.Rename(id, name); err != nil {
+		return err
+	}
+	appearances, err := r.bandMember.FindAppearances(id, nil, nil)
+	if err != nil {
+		return err
+	}
+	var ids []int
+	for _, appearance := range appearances {
+		ids = append(ids, appearance.LiveId)
+	}
+	r.tracker.touch(ids...)
+	return nil
+}
+
+type trackedLineupRuleRepository struct {
+	LineupRuleRepository
+	tracker *liveTracker
+}
+
+func (r *trackedLineupRuleRepository) Save(rule *LineupRule) error {
+	if err := r.LineupRuleRepository.Save(rule); err != nil {
+		return err
+	}
+	r.tracker.touch(rule.LiveId)
+	return nil
+}
+
+// trackedBandProfileRepository バンド名の変更はプロフィールに紐づく出演バンドがいるすべてのライブの変更として扱う
+type trackedBandProfileRepository struct {
+	BandProfileRepository
+	band    BandRepository
+	tracker *liveTracker
+}
+
+func (r *trackedBandProfileRepository) Rename(id int, name string) error {
+	if err := r.BandProfileRepository.Rename(id, name); err != nil {
+		return err
+	}
+	bands, err := r.band.FindByBandId(id)
+	if err != nil {
+		return err
+	}
+	var ids []int
+	for _, band := range bands {
+		ids = append(ids, band.LiveId)
+	}
+	r.tracker.touch(ids...)
+	return nil
+}
+
+// TrackingUnitOfWork トランザクション内の書き込みを TrackLiveChanges で追跡し、コミットの前に変更したライブのバージョンを上げ、
+// コミットした後に変更したライブを listener に通知する
+type TrackingUnitOfWork struct {
+	unitOfWork UnitOfWork
+	listener   LiveChangeListener
+}
+
+func NewTrackingUnitOfWork(unitOfWork UnitOfWork, listener LiveChangeListener) *TrackingUnitOfWork {
+	return &TrackingUnitOfWork{unitOfWork: unitOfWork, listener: listener}
+}
+
+func (u *TrackingUnitOfWork) Do(fn func(repositories *Repositories) error) error {
+	var changed []int
+	err := u.unitOfWork.Do(func(repositories *Repositories) error {
+		// やり直した場合に前回の変更を通知しないよう、実行のたびに空にする
+		changed = nil
+		tracked, flush := TrackLiveChanges(repositories, func(id int) { changed = append(changed, id) })
+		if err := fn(tracked); err != nil {
+			return err
+		}
+		return flush()
+	})
+	if err != nil {
+		return err
+	}
+	for _, id := range changed {
+		u.listener.LiveChanged(id)
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package domain
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func (m *LiveRepositoryMock) Touch(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func TestTrackLiveChanges(t *testing.T) {
+	// given
+	expectedError := fmt.Errorf("dummy message")
+
+	tests := []struct {
+		// テスト名
+		testName string
+		// 追跡するリポジトリへの書き込み
+		write func(repositories *Repositories) error
+		// Create の戻り値
+		createError error
+		// Touch するライブの ID
+		expectedTouched []int
+		// 変更を通知するライブの ID
+		expectedChanged []int
+		// 戻り値の期待値(error)
+		expectedError error
+	}{
+		{
+			testName:        "正常系_出演バンドの登録",
+			write:           func(r *Repositories) error { return r.Band.Create(&Band{Name: "band", LiveId: 1, Turn: 1}) },
+			expectedTouched: []int{1},
+			expectedChanged: []int{1},
+		},
+		{
+			testName:      "異常系_登録に失敗した場合は通知しない",
+			write:         func(r *Repositories) error { return r.Band.Create(&Band{Name: "band", LiveId: 1, Turn: 1}) },
+			createError:   expectedError,
+			expectedError: expectedError,
+		},
+		{
+			testName: "正常系_バンドメンバーの差し替え",
+			write: func(r *Repositories) error {
+				return r.BandMember.Update(&BandMember{LiveId: 1, Turn: 1, MemberId: 1, MemberPart: Dr}, &BandMember{LiveId: 1, Turn: 1, MemberId: 2, MemberPart: Dr})
+			},
+			expectedTouched: []int{1},
+			expectedChanged: []int{1},
+		},
+		{
+			testName: "正常系_同じライブへの複数の書き込みはバージョンを1回だけ上げる",
+			write: func(r *Repositories) error {
+				if err := r.Band.Create(&Band{Name: "band", LiveId: 1, Turn: 1}); err != nil {
+					return err
+				}
+				return r.BandMember.Update(&BandMember{LiveId: 1, Turn: 1, MemberId: 1, MemberPart: Dr}, &BandMember{LiveId: 1, Turn: 1, MemberId: 2, MemberPart: Dr})
+			},
+			expectedTouched: []int{1},
+			expectedChanged: []int{1},
+		},
+		{
+			testName: "正常系_ライブ自体を更新した場合はバージョンを重ねて上げない",
+			write: func(r *Repositories) error {
+				if err := r.Band.Create(&Band{Name: "band", LiveId: 1, Turn: 1}); err != nil {
+					return err
+				}
+				return r.Live.Patch(1, 1, &LivePatch{})
+			},
+			expectedChanged: []int{1},
+		},
+		{
+			testName:        "正常系_ライブの削除前の出演バンドの削除はバージョンを上げない",
+			write:           func(r *Repositories) error { return r.Band.DeleteByLiveId(1) },
+			expectedChanged: []int{1},
+		},
+		{
+			testName:        "正常系_メンバー名の変更は出演したライブをすべて変更する",
+			write:           func(r *Repositories) error { return r.Player.Rename(3, "renamed") },
+			expectedTouched: []int{1, 2},
+			expectedChanged: []int{1, 2},
+		},
+	}
+
+	for _, tc := range tests {
+		var touched []int
+		liveRepository := new(LiveRepositoryMock)
+		liveRepository.On("Touch", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			touched = append(touched, args.Int(0))
+		})
+		liveRepository.On("Patch", 1, 1, mock.Anything).Return(nil)
+		bandRepository := new(BandRepositoryMock)
+		bandRepository.On("Create", mock.Anything).Return(tc.createError)
+		bandRepository.On("DeleteByLiveId", 1).Return(nil)
+		bandMemberRepository := new(BandMemberRepositoryMock)
+		bandMemberRepository.On("Update", mock.Anything, mock.Anything).Return(nil)
+		bandMemberRepository.On("FindAppearances", 3, (*time.Time)(nil), (*time.Time)(nil)).Return([]*Appearance{
+			{LiveId: 1, Turn: 1, Part: Dr},
+			{LiveId: 1, Turn: 2, Part: Vo},
+			{LiveId: 2, Turn: 1, Part: Dr},
+		}, nil)
+		playerRepository := new(PlayerRepositoryMock)
+		playerRepository.On("Rename", 3, "renamed").Return(nil)
+		var changed []int
+		repositories, flush := TrackLiveChanges(&Repositories{
+			Live:       liveRepository,
+			Band:       bandRepository,
+			BandMember: bandMemberRepository,
+			Player:     playerRepository,
+		}, func(id int) { changed = append(changed, id) })
+
+		// when
+		err := tc.write(repositories)
+		if err == nil {
+			err = flush()
+		}
+
+		// then
+		assert.Equal(t, tc.expectedError, err, fmt.Sprintf("テスト名: %s", tc.testName))
+		assert.Equal(t, tc.expectedTouched, touched, fmt.Sprintf("テスト名: %s", tc.testName))
+		assert.Equal(t, tc.expectedChanged, changed, fmt.Sprintf("テスト名: %s", tc.testName))
+	}
+}
+
+type LiveChangeListenerMock struct {
+	changed []int
+}
+
+func (l *LiveChangeListenerMock) LiveChanged(id int) {
+	l.changed = append(l.changed, id)
+}
+
+func TestTrackingUnitOfWorkDo(t *testing.T) {
+	// given
+	expectedError := fmt.Errorf("dummy message")
+
+	tests := []struct {
+		// テスト名
+		testName string
+		// トランザクションの戻り値
+		fnError error
+		// 変更を通知するライブの ID
+		expectedChanged []int
+	}{
+		{
+			testName:        "正常系_コミット後に通知する",
+			expectedChanged: []int{1},
+		},
+		{
+			testName: "異常系_ロールバックした場合は通知しない",
+			fnError:  expectedError,
+		},
+	}
+
+	for _, tc := range tests {
+		liveRepository := new(LiveRepositoryMock)
+		liveRepository.On("Touch", 1).Return(nil)
+		bandRepository := new(BandRepositoryMock)
+		bandRepository.On("Create", mock.Anything).Return(nil)
+		listener := &LiveChangeListenerMock{}
+		unitOfWork := NewTrackingUnitOfWork(&UnitOfWorkMock{repositories: &Repositories{Live: liveRepository, Band: bandRepository}}, listener)
+
+		// when
+		err := unitOfWork.Do(func(repositories *Repositories) error {
+			if err := repositories.Band.Create(&Band{Name: "band", LiveId: 1, Turn: 1}); err != nil {
+				return err
+			}
+			assert.Empty(t, listener.changed, fmt.Sprintf("テスト名: %s", tc.testName))
+			return tc.fnError
+		})
+
+		// then
+		assert.Equal(t, tc.fnError, err, fmt.Sprintf("テスト名: %s", tc.testName))
+		assert.Equal(t, tc.expectedChanged, listener.changed, fmt.Sprintf("テスト名: %s", tc.testName))
+	}
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// CachedLiveDescService GetById の結果をライブごとにプロセス内に保持する LiveDescService。
+// LiveChanged で破棄するため、書き込みには TrackingUnitOfWork と AutoCommitWrites を通したリポジトリを使う。
+// 保持するのは最近読み込んだ size 件までとし、超えた場合は最も長く読み込まれていないライブから破棄する。
+// 返す LiveModel は呼び出し元の間で共有されるため変更しないこと
+type CachedLiveDescService struct {
+	liveDescService LiveDescService
+	size            int
+	mutex           sync.Mutex
+	liveModels      map[int]*list.Element
+	// recent 保持しているライブを最近読み込んだ順に並べる。要素は *LiveModel
+	recent *list.List
+	// generation LiveChanged のたびに増やす。読み込み中に破棄された結果を保持しないために使う
+	generation int
+}
+
+func NewCachedLiveDescService(liveDescService LiveDescService, size int) *CachedLiveDescService {
+	return &CachedLiveDescService{liveDescService: liveDescService, size: size, liveModels: map[int]*list.Element{}, recent: list.New()}
+}
+
+func (c *CachedLiveDescService) GetById(id int) (*LiveModel, error) {
+	c.mutex.Lock()
+	element, ok := c.liveModels[id]
+	if ok {
+		c.recent.MoveToFront(element)
+	}
+	generation := c.generation
+	c.mutex.Unlock()
+	if ok {
+		return element.Value.(*LiveModel), nil
+	}
+
+	liveModel, err := c.liveDescService.GetById(id)
+	if err != nil {
+		return nil, err
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.generation == generation {
+		c.put(id, liveModel)
+	}
+	return liveModel, nil
+}
+
+// put 保持する件数を超えた場合は最も長く読み込まれていないライブを破棄する
+func (c *CachedLiveDescService) put(id int, liveModel *LiveModel) {
+	if element, ok := c.liveModels[id]; ok {
+		element.Value = liveModel
+		c.recent.MoveToFront(element)
+		return
+	}
+	c.liveModels[id] = c.recent.PushFront(liveModel)
+	for c.recent.Len() > c.size {
+		oldest := c.recent.Back()
+		c.recent.Remove(oldest)
+		delete(c.liveModels, oldest.Value.(*LiveModel).Id)
+	}
+}
+
+// GetByPeriod 期間の一覧は保持せず、毎回読み込む
+func (c *CachedLiveDescService) GetByPeriod(start *time.Time, end *time.Time) ([]*LiveModel, error) {
+	return c.liveDescService.GetByPeriod(start, end)
+}
+
+// LiveChanged 変更されたライブの保持している結果を破棄する
+func (c *CachedLiveDescService) LiveChanged(id int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if element, ok := c.liveModels[id]; ok {
+		c.recent.Remove(element)
+		delete(c.liveModels, id)
+	}
+	c.generation++
+}
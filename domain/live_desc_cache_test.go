@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCachedLiveDescServiceGetById(t *testing.T) {
+	// given
+	first := &LiveModel{Id: 1, Name: "name", Version: 1}
+	second := &LiveModel{Id: 1, Name: "renamed", Version: 2}
+	liveDescService := new(LiveDescServiceMock)
+	liveDescService.On("GetById", 1).Return(first, nil).Once()
+	liveDescService.On("GetById", 1).Return(second, nil).Once()
+	cache := NewCachedLiveDescService(liveDescService, 10)
+
+	// when
+	cached1, err1 := cache.GetById(1)
+	cached2, err2 := cache.GetById(1)
+	cache.LiveChanged(1)
+	reloaded, err3 := cache.GetById(1)
+
+	// then
+	assert.Nil(t, err1)
+	assert.Nil(t, err2)
+	assert.Nil(t, err3)
+	assert.Equal(t, first, cached1)
+	assert.Equal(t, first, cached2, "2回目は読み込まずに保持している結果を返す")
+	assert.Equal(t, second, reloaded, "変更されたライブは読み込み直す")
+	liveDescService.AssertNumberOfCalls(t, "GetById", 2)
+}
+
+func TestCachedLiveDescServiceGetByIdError(t *testing.T) {
+	// given
+	notFound := fmt.Errorf("%w: live 1", ErrNotFound)
+	liveDescService := new(LiveDescServiceMock)
+	liveDescService.On("GetById", 1).Return((*LiveModel)(nil), notFound).Twice()
+	cache := NewCachedLiveDescService(liveDescService, 10)
+
+	// when
+	_, err1 := cache.GetById(1)
+	_, err2 := cache.GetById(1)
+
+	// then
+	assert.Equal(t, notFound, err1)
+	assert.Equal(t, notFound, err2, "エラーは保持しない")
+	liveDescService.AssertNumberOfCalls(t, "GetById", 2)
+}
+
+func TestCachedLiveDescServiceEviction(t *testing.T) {
+	// given
+	liveDescService := new(LiveDescServiceMock)
+	for id := 1; id <= 3; id++ {
+		liveDescService.On("GetById", id).Return(&LiveModel{Id: id}, nil)
+	}
+	cache := NewCachedLiveDescService(liveDescService, 2)
+
+	// when
+	_, _ = cache.GetById(1)
+	_, _ = cache.GetById(2)
+	_, _ = cache.GetById(1)
+	_, _ = cache.GetById(3)
+	_, _ = cache.GetById(1)
+	_, _ = cache.GetById(2)
+
+	// then
+	// 3 を読み込んだ時点で最も長く読み込まれていない 2 を破棄するため、1 は保持したまま 2 を読み込み直す
+	liveDescService.AssertNumberOfCalls(t, "GetById", 4)
+	assert.Equal(t, 2, cache.recent.Len(), "保持するのは2件まで")
+}
@@ -1,46 +1,27 @@
 package domain
 
+import (
+	"time"
+)
+
 type LiveDescService interface {
 	GetById(id int) (*LiveModel, error)
+	// GetByPeriod 期間内のライブを出演バンドとメンバーを含めて返す
+	GetByPeriod(start *time.Time, end *time.Time) ([]*LiveModel, error)
 }
 
 type LiveDescServiceImpl struct {
-	liveRepository       LiveRepository
-	bandRepository       BandRepository
-	bandMemberRepository BandMemberRepository
+	liveDescRepository LiveDescRepository
 }
 
-func NewLiveDescServiceImpl(liveRepository LiveRepository, bandRepository BandRepository, bandMemberRepository BandMemberRepository) *LiveDescServiceImpl {
-	return &LiveDescServiceImpl{liveRepository: liveRepository, bandRepository: bandRepository, bandMemberRepository: bandMemberRepository}
+func NewLiveDescServiceImpl(liveDescRepository LiveDescRepository) *LiveDescServiceImpl {
+	return &LiveDescServiceImpl{liveDescRepository: liveDescRepository}
 }
 
 func (i *LiveDescServiceImpl) GetById(id int) (*LiveModel, error) {
-	live, err := i.liveRepository.FindById(id)
-	if err != nil {
-		return nil, err
-	}
-
-	bands, err := i.bandRepository.FindByLiveId(live.Id)
-	if err != nil {
-		return nil, err
-	}
-
-	var bandModels []*BandModel
-	for _, band := range bands {
-		players, err := i.bandMemberRepository.FindByLiveIdAndTurn(band.LiveId, band.Turn)
-		if err != nil {
-			return nil, err
-		}
-		bandModels = append(bandModels, &BandModel{Name: band.Name, LiveId: band.LiveId, Turn: band.Turn, Player: players})
-	}
+	return i.liveDescRepository.FindById(id)
+}
 
-	return &LiveModel{
-		Id:             live.Id,
-		Name:           live.Name,
-		Location:       live.Location,
-		Date:           live.Date,
-		PerformanceFee: live.PerformanceFee,
-		EquipmentCost:  live.EquipmentCost,
-		Band:           bandModels,
-	}, nil
+func (i *LiveDescServiceImpl) GetByPeriod(start *time.Time, end *time.Time) ([]*LiveModel, error) {
+	return i.liveDescRepository.FindByPeriod(start, end)
 }
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
-	"strings"
 	"testing"
 	"time"
 )
@@ -19,6 +18,11 @@ func (m *LiveRepositoryMock) FindById(id int) (*Live, error) {
 	return args.Get(0).(*Live), args.Error(1)
 }
 
+func (m *LiveRepositoryMock) FindByIdForUpdate(id int) (*Live, error) {
+	args := m.Called(id)
+	return args.Get(0).(*Live), args.Error(1)
+}
+
 func (m *LiveRepositoryMock) FindByPeriod(start *time.Time, end *time.Time) ([]*Live, error) {
 	args := m.Called(start, end)
 	return args.Get(0).([]*Live), args.Error(1)
@@ -29,13 +33,13 @@ func (m *LiveRepositoryMock) Create(live *Live) error {
 	return args.Error(0)
 }
 
-func (m *LiveRepositoryMock) Update(live *Live) error {
-	args := m.Called(live)
+func (m *LiveRepositoryMock) Patch(id int, version int, patch *LivePatch) error {
+	args := m.Called(id, version, patch)
 	return args.Error(0)
 }
 
-func (m *LiveRepositoryMock) Delete(id int) error {
-	args := m.Called(id)
+func (m *LiveRepositoryMock) Delete(id int, version int) error {
+	args := m.Called(id, version)
 	return args.Error(0)
 }
 
@@ -54,26 +58,54 @@ type BandMemberRepositoryMock struct {
 	BandMemberRepository
 }
 
+func (m *BandRepositoryMock) DeleteByLiveId(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
 func (m *BandMemberRepositoryMock) FindByLiveIdAndTurn(id int, turn int) ([]*Player, error) {
 	args := m.Called(id, turn)
 	return args.Get(0).([]*Player), args.Error(1)
 }
 
+func (m *BandMemberRepositoryMock) FindByLiveId(id int) ([]*BandMember, error) {
+	args := m.Called(id)
+	return args.Get(0).([]*BandMember), args.Error(1)
+}
+
+func (m *BandMemberRepositoryMock) DeleteByLiveId(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
 var now = time.Now()
 
+type LiveDescRepositoryMock struct {
+	mock.Mock
+	LiveDescRepository
+}
+
+func (m *LiveDescRepositoryMock) FindById(id int) (*LiveModel, error) {
+	args := m.Called(id)
+	return args.Get(0).(*LiveModel), args.Error(1)
+}
+
+func (m *LiveDescRepositoryMock) FindByPeriod(start *time.Time, end *time.Time) ([]*LiveModel, error) {
+	args := m.Called(start, end)
+	return args.Get(0).([]*LiveModel), args.Error(1)
+}
+
 func TestGetByDate(t *testing.T) {
 	// given
-	live := Live{Id: 1, Name: "name", Location: "location", Date: now, PerformanceFee: 5500, EquipmentCost: 2000}
-	bands := []*Band{&Band{Name: "band1", LiveId: 1, Turn: 1}, &Band{Name: "band2", LiveId: 1, Turn: 2}}
 	players1 := []*Player{&Player{Name: "player1", Part: Ba}, &Player{Name: "player2", Part: Dr}}
 	players2 := []*Player{&Player{Name: "player3", Part: Gt}, &Player{Name: "player4", Part: Key}}
 	expectedLive := LiveModel{
-		Id:             live.Id,
-		Name:           live.Name,
-		Location:       live.Location,
-		Date:           live.Date,
-		PerformanceFee: live.PerformanceFee,
-		EquipmentCost:  live.EquipmentCost,
+		Id:             1,
+		Name:           "name",
+		Location:       "location",
+		Date:           now,
+		PerformanceFee: 5500,
+		EquipmentCost:  2000,
 		Band: []*BandModel{
 			&BandModel{Name: "band1", LiveId: 1, Turn: 1, Player: players1},
 			&BandModel{Name: "band2", LiveId: 1, Turn: 2, Player: players2},
@@ -81,120 +113,43 @@ func TestGetByDate(t *testing.T) {
 	}
 	expectedError := fmt.Errorf("dummy message")
 
-	// given
 	tests := []struct {
 		// テスト名
 		testName string
-		// LiveRepository のモックを返す関数
-		liveRepository func() *LiveRepositoryMock
-		// BandRepository のモックを返す関数
-		bandRepository func() *BandRepositoryMock
-		// BandMemberRepository のモックを返す関数
-		bandMemberRepository func() *BandMemberRepositoryMock
+		// LiveDescRepository が返すライブ
+		liveModel *LiveModel
+		// LiveDescRepository が返すエラー
+		repositoryError error
 		// 戻り値の期待値(LiveModel)
-		expectedLive LiveModel
+		expectedLive *LiveModel
 		// 戻り値の期待値(error)
 		expectedError error
 	}{
 		{
-			testName: "正常系",
-			liveRepository: func() *LiveRepositoryMock {
-				liveRepository := new(LiveRepositoryMock)
-				liveRepository.On("FindById", live.Id).Return(&live, nil).Once()
-				return liveRepository
-			},
-			bandRepository: func() *BandRepositoryMock {
-				bandRepository := new(BandRepositoryMock)
-				bandRepository.On("FindByLiveId", 1).Return(bands, nil).Once()
-				return bandRepository
-			},
-			bandMemberRepository: func() *BandMemberRepositoryMock {
-				bandMemberRepository := new(BandMemberRepositoryMock)
-				bandMemberRepository.
-					On("FindByLiveIdAndTurn", 1, 1).Return(players1, nil).Once().
-					On("FindByLiveIdAndTurn", 1, 2).Return(players2, nil).Once()
-				return bandMemberRepository
-			},
-			expectedLive:  expectedLive,
-			expectedError: nil,
-		},
-		{
-			testName: "異常系_Liveレコード取得処理でエラー発生",
-			liveRepository: func() *LiveRepositoryMock {
-				liveRepository := new(LiveRepositoryMock)
-				liveRepository.On("FindById", live.Id).Return(&Live{}, expectedError).Once()
-				return liveRepository
-			},
-			bandRepository: func() *BandRepositoryMock {
-				bandRepository := new(BandRepositoryMock)
-				bandRepository.On("FindByLiveId", mock.Anything).Times(0)
-				return bandRepository
-			},
-			bandMemberRepository: func() *BandMemberRepositoryMock {
-				bandMemberRepository := new(BandMemberRepositoryMock)
-				bandMemberRepository.On("FindByLiveIdAndTurn", mock.Anything, mock.Anything).Times(0)
-				return bandMemberRepository
-			},
-			expectedLive:  LiveModel{},
-			expectedError: expectedError,
-		},
-		{
-			testName: "異常系_Bandレコード取得処理でエラー発生",
-			liveRepository: func() *LiveRepositoryMock {
-				liveRepository := new(LiveRepositoryMock)
-				liveRepository.On("FindById", live.Id).Return(&live, nil).Once()
-				return liveRepository
-			},
-			bandRepository: func() *BandRepositoryMock {
-				bandRepository := new(BandRepositoryMock)
-				bandRepository.On("FindByLiveId", 1).Return([]*Band{}, expectedError).Once()
-				return bandRepository
-			},
-			bandMemberRepository: func() *BandMemberRepositoryMock {
-				bandMemberRepository := new(BandMemberRepositoryMock)
-				bandMemberRepository.On("FindByLiveIdAndTurn", mock.Anything, mock.Anything).Times(0)
-				return bandMemberRepository
-			},
-			expectedLive:  LiveModel{},
-			expectedError: expectedError,
+			testName:     "正常系",
+			liveModel:    &expectedLive,
+			expectedLive: &expectedLive,
 		},
 		{
-			testName: "異常系_BandMemberレコード取得処理でエラー発生",
-			liveRepository: func() *LiveRepositoryMock {
-				liveRepository := new(LiveRepositoryMock)
-				liveRepository.On("FindById", live.Id).Return(&live, nil).Once()
-				return liveRepository
-			},
-			bandRepository: func() *BandRepositoryMock {
-				bandRepository := new(BandRepositoryMock)
-				bandRepository.On("FindByLiveId", 1).Return(bands, nil).Once()
-				return bandRepository
-			},
-			bandMemberRepository: func() *BandMemberRepositoryMock {
-				bandMemberRepository := new(BandMemberRepositoryMock)
-				bandMemberRepository.
-					On("FindByLiveIdAndTurn", 1, 1).Return(players1, nil).Once().
-					On("FindByLiveIdAndTurn", 1, 2).Return([]*Player{}, expectedError).Once()
-				return bandMemberRepository
-			},
-			expectedLive:  LiveModel{},
-			expectedError: expectedError,
+			testName:        "異常系_ライブ取得処理でエラー発生",
+			liveModel:       nil,
+			repositoryError: expectedError,
+			expectedError:   expectedError,
 		},
 	}
 
 	for _, tc := range tests {
-		liveDescService := NewLiveDescServiceImpl(tc.liveRepository(), tc.bandRepository(), tc.bandMemberRepository())
+		liveDescRepository := new(LiveDescRepositoryMock)
+		liveDescRepository.On("FindById", 1).Return(tc.liveModel, tc.repositoryError).Once()
+		liveDescService := NewLiveDescServiceImpl(liveDescRepository)
 
 		// when
-		actual, err := liveDescService.GetById(live.Id)
+		actual, err := liveDescService.GetById(1)
 
 		// then
 		assertion := assert.New(t)
-		if strings.Contains(tc.testName, "正常系") {
-			assertion.Equal(&tc.expectedLive, actual, fmt.Sprintf("テスト名: %s", tc.testName))
-			assertion.Nil(err)
-		} else {
-			assertion.Equal(tc.expectedError, err, fmt.Sprintf("テスト名: %s", tc.testName))
-		}
+		assertion.Equal(tc.expectedLive, actual, fmt.Sprintf("テスト名: %s", tc.testName))
+		assertion.Equal(tc.expectedError, err, fmt.Sprintf("テスト名: %s", tc.testName))
+		liveDescRepository.AssertNumberOfCalls(t, "FindById", 1)
 	}
 }
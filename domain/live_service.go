@@ -1,47 +1,100 @@
 package domain
 
-import (
-	"time"
-)
-
 type LiveService interface {
-	GetByPeriod(start *time.Time, end *time.Time) ([]*Live, error)
 	Register(live *Live) error
-	Update(live *Live) error
-	Delete(id int) error
+	// Update patch で指定されたフィールドだけを更新し、更新後のライブを返す。
+	// ライブのバージョンが version と異なる場合は ErrPreconditionFailed を返す。
+	// 開演時刻・終演時刻・転換時間を変更する場合は、同じトランザクションで終演時刻を超えないか確認する
+	Update(id int, version int, patch *LivePatch) (*Live, error)
+	// Delete ライブのバージョンが version と異なる場合は ErrPreconditionFailed を返す
+	Delete(id int, version int) error
+	PreviewDelete(id int) (*LiveDeletion, error)
 }
 
 type LiveServiceImpl struct {
-	liveRepository LiveRepository
-}
-
-func NewLiveServiceImpl(liveRepository LiveRepository) *LiveServiceImpl {
-	return &LiveServiceImpl{liveRepository: liveRepository}
+	liveRepository   LiveRepository
+	unitOfWork       UnitOfWork
+	timetableService TimetableService
 }
 
-func (s *LiveServiceImpl) GetByPeriod(start *time.Time, end *time.Time) ([]*Live, error) {
-	lives, err := s.liveRepository.FindByPeriod(start, end)
-	if err != nil {
-		return nil, err
-	}
-	return lives, nil
+func NewLiveServiceImpl(liveRepository LiveRepository, unitOfWork UnitOfWork, timetableService TimetableService) *LiveServiceImpl {
+	return &LiveServiceImpl{liveRepository: liveRepository, unitOfWork: unitOfWork, timetableService: timetableService}
 }
 
+// Register ライブは下書きの状態で登録する
 func (s *LiveServiceImpl) Register(live *Live) error {
+	live.Status = LiveDraft
 	err := s.liveRepository.Create(live)
 	return verifyAndGetError(err)
 }
 
-func (s *LiveServiceImpl) Update(live *Live) error {
-	err := s.liveRepository.Update(live)
-	return verifyAndGetError(err)
+func (s *LiveServiceImpl) Update(id int, version int, patch *LivePatch) (*Live, error) {
+	var live *Live
+	err := s.unitOfWork.Do(func(repositories *Repositories) error {
+		if err := repositories.Live.Patch(id, version, patch); err != nil {
+			return err
+		}
+		updated, err := repositories.Live.FindById(id)
+		if err != nil {
+			return err
+		}
+		if patch.StartTime != nil || patch.CloseTime != nil || patch.Changeover != nil {
+			bands, err := repositories.Band.FindByLiveId(id)
+			if err != nil {
+				return err
+			}
+			if err := s.timetableService.Verify(updated, bands); err != nil {
+				return err
+			}
+		}
+		live = updated
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return live, nil
 }
 
-func (s *LiveServiceImpl) Delete(id int) error {
-	err := s.liveRepository.Delete(id)
+// Delete ライブと出演バンド、バンドメンバーを1つのトランザクションで削除する
+func (s *LiveServiceImpl) Delete(id int, version int) error {
+	err := s.unitOfWork.Do(func(repositories *Repositories) error {
+		if err := repositories.BandMember.DeleteByLiveId(id); err != nil {
+			return err
+		}
+		if err := repositories.Band.DeleteByLiveId(id); err != nil {
+			return err
+		}
+		return repositories.Live.Delete(id, version)
+	})
 	return verifyAndGetError(err)
 }
 
+// PreviewDelete Delete で削除されるレコードを削除せずに返す
+func (s *LiveServiceImpl) PreviewDelete(id int) (*LiveDeletion, error) {
+	var deletion *LiveDeletion
+	err := s.unitOfWork.Do(func(repositories *Repositories) error {
+		live, err := repositories.Live.FindById(id)
+		if err != nil {
+			return err
+		}
+		bands, err := repositories.Band.FindByLiveId(id)
+		if err != nil {
+			return err
+		}
+		bandMembers, err := repositories.BandMember.FindByLiveId(id)
+		if err != nil {
+			return err
+		}
+		deletion = &LiveDeletion{Live: live, Band: bands, BandMember: bandMembers}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deletion, nil
+}
+
 func verifyAndGetError(err error) error {
 	if err != nil {
 		return err
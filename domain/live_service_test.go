@@ -3,49 +3,10 @@ package domain
 import (
 	"fmt"
 	"github.com/stretchr/testify/assert"
-	"strings"
+	"github.com/stretchr/testify/mock"
 	"testing"
 )
 
-func TestGetByPeriod(t *testing.T) {
-	// given
-	live := Live{Id: 1, Name: "name", Location: "location", Date: now, PerformanceFee: 5500, EquipmentCost: 2000}
-
-	testCase := []struct {
-		testName      string
-		expectedLives []*Live
-		expectedError error
-	}{
-		{
-			testName:      "正常系",
-			expectedLives: []*Live{&live},
-			expectedError: nil,
-		},
-		{
-			testName:      "異常系_データアクセス時にエラー発生",
-			expectedLives: []*Live{},
-			expectedError: fmt.Errorf("dummy message"),
-		},
-	}
-
-	for _, tc := range testCase {
-		liveRepository := new(LiveRepositoryMock)
-		liveRepository.On("FindByPeriod", &now, &now).Return(tc.expectedLives, tc.expectedError).Once()
-		liveService := NewLiveServiceImpl(liveRepository)
-
-		// when
-		actual, err := liveService.GetByPeriod(&now, &now)
-
-		// then
-		if strings.Contains(tc.testName, "正常系") {
-			assert.Equal(t, tc.expectedLives, actual, fmt.Sprintf("テスト名: %s", tc.testName))
-			assert.Nil(t, err, fmt.Sprintf("テスト名: %s", tc.testName))
-		} else {
-			assert.Equal(t, tc.expectedError, err, fmt.Sprintf("テスト名: %s", tc.testName))
-		}
-	}
-}
-
 var testCase = []struct {
 	testName      string
 	expectedError error
@@ -67,7 +28,7 @@ func TestRegister(t *testing.T) {
 	for _, tc := range testCase {
 		liveRepository := new(LiveRepositoryMock)
 		liveRepository.On("Create", &live).Return(tc.expectedError).Once()
-		liveService := NewLiveServiceImpl(liveRepository)
+		liveService := NewLiveServiceImpl(liveRepository, nil, nil)
 
 		// when
 		actual := liveService.Register(&live)
@@ -79,34 +40,191 @@ func TestRegister(t *testing.T) {
 
 func TestUpdate(t *testing.T) {
 	// given
-	live := Live{Id: 1, Name: "name", Location: "location", Date: now, PerformanceFee: 5500, EquipmentCost: 2000}
+	name := "renamed"
+	patch := &LivePatch{Name: &name}
+	updated := &Live{Id: 1, Name: "renamed", Location: "location", Date: now, PerformanceFee: 5500, EquipmentCost: 2000, Version: 3}
+	notFound := fmt.Errorf("%w: live 1", ErrNotFound)
+
+	tests := []struct {
+		// テスト名
+		testName string
+		// Patch の戻り値
+		patchError error
+		// FindById の戻り値
+		found *Live
+		// FindById の戻り値(error)
+		findError error
+		// 戻り値の期待値
+		expected *Live
+		// 戻り値の期待値(error)
+		expectedError error
+	}{
+		{
+			testName: "正常系",
+			found:    updated,
+			expected: updated,
+		},
+		{
+			testName:      "異常系_存在しないライブ",
+			findError:     notFound,
+			expectedError: notFound,
+		},
+		{
+			testName:      "異常系_更新時にエラー発生",
+			patchError:    fmt.Errorf("dummy message"),
+			expectedError: fmt.Errorf("dummy message"),
+		},
+	}
 
-	for _, tc := range testCase {
+	for _, tc := range tests {
 		liveRepository := new(LiveRepositoryMock)
-		liveRepository.On("Update", &live).Return(tc.expectedError).Once()
-		liveService := NewLiveServiceImpl(liveRepository)
+		liveRepository.On("Patch", 1, 2, patch).Return(tc.patchError).Once()
+		liveRepository.On("FindById", 1).Return(tc.found, tc.findError).Maybe()
+		unitOfWork := &UnitOfWorkMock{repositories: &Repositories{Live: liveRepository}}
+		timetableService := new(TimetableServiceMock)
+		liveService := NewLiveServiceImpl(liveRepository, unitOfWork, timetableService)
 
 		// when
-		actual := liveService.Update(&live)
+		actual, err := liveService.Update(1, 2, patch)
 
 		// then
-		assert.Equal(t, tc.expectedError, actual, fmt.Sprintf("テスト名: %s", tc.testName))
+		assert.Equal(t, tc.expected, actual, fmt.Sprintf("テスト名: %s", tc.testName))
+		assert.Equal(t, tc.expectedError, err, fmt.Sprintf("テスト名: %s", tc.testName))
+		liveRepository.AssertExpectations(t)
+		timetableService.AssertNotCalled(t, "Verify", mock.Anything, mock.Anything)
+	}
+}
+
+func TestUpdateTimetable(t *testing.T) {
+	// given
+	closeTime := Clock(19 * 60)
+	patch := &LivePatch{CloseTime: &closeTime}
+	updated := &Live{Id: 1, Name: "name", StartTime: 18 * 60, CloseTime: closeTime, Version: 3}
+	bands := []*Band{{Name: "band1", LiveId: 1, Turn: 1, SetLength: 70}}
+
+	tests := []struct {
+		// テスト名
+		testName string
+		// Band.FindByLiveId の戻り値(error)
+		findBandError error
+		// Verify の戻り値
+		verifyError error
+		// 戻り値の期待値
+		expected *Live
+		// 戻り値の期待値(error)
+		expectedError error
+	}{
+		{
+			testName: "正常系",
+			expected: updated,
+		},
+		{
+			testName:      "異常系_終演時刻を超える",
+			verifyError:   ErrTimetableOverrun,
+			expectedError: ErrTimetableOverrun,
+		},
+		{
+			testName:      "異常系_出演バンドの取得時にエラー発生",
+			findBandError: fmt.Errorf("dummy message"),
+			expectedError: fmt.Errorf("dummy message"),
+		},
+	}
+
+	for _, tc := range tests {
+		liveRepository := new(LiveRepositoryMock)
+		liveRepository.On("Patch", 1, 2, patch).Return(nil).Once()
+		liveRepository.On("FindById", 1).Return(updated, nil).Once()
+		bandRepository := new(BandRepositoryMock)
+		bandRepository.On("FindByLiveId", 1).Return(bands, tc.findBandError).Once()
+		timetableService := new(TimetableServiceMock)
+		timetableService.On("Verify", updated, bands).Return(tc.verifyError).Maybe()
+		unitOfWork := &UnitOfWorkMock{repositories: &Repositories{Live: liveRepository, Band: bandRepository}}
+		liveService := NewLiveServiceImpl(liveRepository, unitOfWork, timetableService)
+
+		// when
+		actual, err := liveService.Update(1, 2, patch)
+
+		// then
+		assert.Equal(t, tc.expected, actual, fmt.Sprintf("テスト名: %s", tc.testName))
+		assert.Equal(t, tc.expectedError, err, fmt.Sprintf("テスト名: %s", tc.testName))
+		bandRepository.AssertExpectations(t)
 	}
 }
 
 func TestDelete(t *testing.T) {
 	// given
-	live := Live{Id: 1, Name: "name", Location: "location", Date: now, PerformanceFee: 5500, EquipmentCost: 2000}
+	live := Live{Id: 1, Name: "name", Location: "location", Date: now, PerformanceFee: 5500, EquipmentCost: 2000, Version: 1}
 
 	for _, tc := range testCase {
 		liveRepository := new(LiveRepositoryMock)
-		liveRepository.On("Delete", live.Id).Return(tc.expectedError).Once()
-		liveService := NewLiveServiceImpl(liveRepository)
+		liveRepository.On("Delete", live.Id, live.Version).Return(tc.expectedError).Once()
+		bandRepository := new(BandRepositoryMock)
+		bandRepository.On("DeleteByLiveId", live.Id).Return(nil).Once()
+		bandMemberRepository := new(BandMemberRepositoryMock)
+		bandMemberRepository.On("DeleteByLiveId", live.Id).Return(nil).Once()
+		unitOfWork := &UnitOfWorkMock{repositories: &Repositories{
+			Live:       liveRepository,
+			Band:       bandRepository,
+			BandMember: bandMemberRepository,
+		}}
+		liveService := NewLiveServiceImpl(liveRepository, unitOfWork, nil)
 
 		// when
-		actual := liveService.Delete(live.Id)
+		actual := liveService.Delete(live.Id, live.Version)
 
 		// then
 		assert.Equal(t, tc.expectedError, actual, fmt.Sprintf("テスト名: %s", tc.testName))
+		bandMemberRepository.AssertExpectations(t)
+		bandRepository.AssertExpectations(t)
 	}
 }
+
+func TestDeleteStopsOnBandMemberError(t *testing.T) {
+	// given
+	expectedError := fmt.Errorf("dummy message")
+	liveRepository := new(LiveRepositoryMock)
+	bandRepository := new(BandRepositoryMock)
+	bandMemberRepository := new(BandMemberRepositoryMock)
+	bandMemberRepository.On("DeleteByLiveId", 1).Return(expectedError).Once()
+	unitOfWork := &UnitOfWorkMock{repositories: &Repositories{
+		Live:       liveRepository,
+		Band:       bandRepository,
+		BandMember: bandMemberRepository,
+	}}
+	liveService := NewLiveServiceImpl(liveRepository, unitOfWork, nil)
+
+	// when
+	actual := liveService.Delete(1, 1)
+
+	// then
+	assert.Equal(t, expectedError, actual)
+	bandRepository.AssertNotCalled(t, "DeleteByLiveId", 1)
+	liveRepository.AssertNotCalled(t, "Delete", 1, 1)
+}
+
+func TestPreviewDelete(t *testing.T) {
+	// given
+	live := Live{Id: 1, Name: "name", Location: "location", Date: now, PerformanceFee: 5500, EquipmentCost: 2000}
+	bands := []*Band{{Name: "band1", LiveId: 1, Turn: 1}}
+	bandMembers := []*BandMember{{LiveId: 1, Turn: 1, MemberName: "drummer", MemberPart: Dr}}
+	liveRepository := new(LiveRepositoryMock)
+	liveRepository.On("FindById", 1).Return(&live, nil).Once()
+	bandRepository := new(BandRepositoryMock)
+	bandRepository.On("FindByLiveId", 1).Return(bands, nil).Once()
+	bandMemberRepository := new(BandMemberRepositoryMock)
+	bandMemberRepository.On("FindByLiveId", 1).Return(bandMembers, nil).Once()
+	unitOfWork := &UnitOfWorkMock{repositories: &Repositories{
+		Live:       liveRepository,
+		Band:       bandRepository,
+		BandMember: bandMemberRepository,
+	}}
+	liveService := NewLiveServiceImpl(liveRepository, unitOfWork, nil)
+
+	// when
+	actual, err := liveService.PreviewDelete(1)
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, &LiveDeletion{Live: &live, Band: bands, BandMember: bandMembers}, actual)
+	liveRepository.AssertNotCalled(t, "Delete", 1, 1)
+}
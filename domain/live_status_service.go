@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidTransition 現在の状態から指定した状態に遷移できない場合のエラー
+	ErrInvalidTransition = newError(ErrConflict, "invalid status transition")
+	// ErrTransitionRejected 遷移先の状態の条件をライブが満たしていない場合のエラー
+	ErrTransitionRejected = newError(ErrValidation, "status transition is rejected")
+)
+
+// LiveStatusService ライブの状態を遷移させる
+type LiveStatusService interface {
+	// Transition ライブを to の状態にし、更新後のライブを返す。
+	// ライブのバージョンが version と異なる場合は ErrPreconditionFailed、遷移できない場合は ErrInvalidTransition、
+	// 遷移先の条件を満たさない場合は ErrTransitionRejected などの ErrValidation に分類されるエラーを返す
+	Transition(id int, version int, to LiveStatus) (*Live, error)
+}
+
+type LiveStatusServiceImpl struct {
+	liveDescService   LiveDescService
+	lineupRuleService LineupRuleService
+	timetableService  TimetableService
+	unitOfWork        UnitOfWork
+	// now 現在時刻。開催日との比較に使う
+	now func() time.Time
+}
+
+func NewLiveStatusServiceImpl(liveDescService LiveDescService, lineupRuleService LineupRuleService, timetableService TimetableService, unitOfWork UnitOfWork, now func() time.Time) *LiveStatusServiceImpl {
+	return &LiveStatusServiceImpl{
+		liveDescService:   liveDescService,
+		lineupRuleService: lineupRuleService,
+		timetableService:  timetableService,
+		unitOfWork:        unitOfWork,
+		now:               now,
+	}
+}
+
+// Transition 条件の確認は出演バンドとメンバーを含めて読み込んだライブに対して行う。
+// 出演バンドやメンバーが変わるとライブのバージョンが上がるため、確認した後の変更はバージョンの不一致として検出される
+func (s *LiveStatusServiceImpl) Transition(id int, version int, to LiveStatus) (*Live, error) {
+	liveModel, err := s.liveDescService.GetById(id)
+	if err != nil {
+		return nil, err
+	}
+	if liveModel.Version != version {
+		return nil, fmt.Errorf("%w: live %d", ErrPreconditionFailed, id)
+	}
+	if !liveModel.Status.CanTransitionTo(to) {
+		return nil, fmt.Errorf("%w: from %s to %s", ErrInvalidTransition, liveModel.Status, to)
+	}
+	if err := s.checkGuard(liveModel, to); err != nil {
+		return nil, err
+	}
+
+	var live *Live
+	err = s.unitOfWork.Do(func(repositories *Repositories) error {
+		if err := repositories.Live.Patch(id, version, &LivePatch{Status: &to}); err != nil {
+			return err
+		}
+		updated, err := repositories.Live.FindById(id)
+		if err != nil {
+			return err
+		}
+		live = updated
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return live, nil
+}
+
+// checkGuard 遷移先の状態の条件を確認する。
+// 募集開始は開催日前、締め切りは出演バンドが1組以上、確定は全バンドが編成のルールを満たしタイムテーブルが終演時刻に収まること、
+// 開催済みは開催日以降であることを条件とする。中止には条件がない
+func (s *LiveStatusServiceImpl) checkGuard(liveModel *LiveModel, to LiveStatus) error {
+	switch to {
+	case LiveEntryOpen:
+		if dateOf(liveModel.Date).Before(dateOf(s.now())) {
+			return fmt.Errorf("%w: live date %s has passed", ErrTransitionRejected, liveModel.Date.Format("2006-01-02"))
+		}
+	case LiveEntryClosed:
+		if len(liveModel.Band) == 0 {
+			return fmt.Errorf("%w: no band has entered", ErrTransitionRejected)
+		}
+	case LiveConfirmed:
+		if len(liveModel.Band) == 0 {
+			return fmt.Errorf("%w: no band has entered", ErrTransitionRejected)
+		}
+		validations, err := s.lineupRuleService.Validate(liveModel)
+		if err != nil {
+			return err
+		}
+		var invalid []string
+		for _, validation := range validations {
+			if !validation.Valid {
+				invalid = append(invalid, fmt.Sprintf("turn %d (%s)", validation.Turn, strings.Join(validation.Violation, ", ")))
+			}
+		}
+		if len(invalid) > 0 {
+			return fmt.Errorf("%w: lineup rule is not satisfied: %s", ErrTransitionRejected, strings.Join(invalid, "; "))
+		}
+		var bands []*Band
+		for _, band := range liveModel.Band {
+			bands = append(bands, &Band{Name: band.Name, LiveId: band.LiveId, Turn: band.Turn, SetLength: band.SetLength})
+		}
+		if err := s.timetableService.Check(liveModel.Id, bands); err != nil {
+			return err
+		}
+	case LiveDone:
+		if dateOf(s.now()).Before(dateOf(liveModel.Date)) {
+			return fmt.Errorf("%w: live date %s has not come", ErrTransitionRejected, liveModel.Date.Format("2006-01-02"))
+		}
+	}
+	return nil
+}
+
+// dateOf 時刻をそのタイムゾーンでの日付にする
+func dateOf(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,173 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestLiveStatusCanTransitionTo(t *testing.T) {
+	// given
+	tests := []struct {
+		// テスト名
+		testName string
+		// 現在の状態
+		from LiveStatus
+		// 遷移先の状態
+		to LiveStatus
+		// 戻り値の期待値
+		expected bool
+	}{
+		{testName: "正常系_募集開始", from: LiveDraft, to: LiveEntryOpen, expected: true},
+		{testName: "正常系_確定", from: LiveEntryClosed, to: LiveConfirmed, expected: true},
+		{testName: "正常系_確定後の中止", from: LiveConfirmed, to: LiveCancelled, expected: true},
+		{testName: "異常系_状態を飛ばす", from: LiveDraft, to: LiveConfirmed},
+		{testName: "異常系_前の状態に戻す", from: LiveConfirmed, to: LiveEntryOpen},
+		{testName: "異常系_開催済みは中止できない", from: LiveDone, to: LiveCancelled},
+		{testName: "異常系_中止からは遷移できない", from: LiveCancelled, to: LiveDraft},
+	}
+
+	for _, tc := range tests {
+		// when
+		actual := tc.from.CanTransitionTo(tc.to)
+
+		// then
+		assert.Equal(t, tc.expected, actual, fmt.Sprintf("テスト名: %s", tc.testName))
+	}
+}
+
+func TestLiveStatusTransition(t *testing.T) {
+	// given
+	today := time.Date(2022, 1, 10, 21, 0, 0, 0, time.UTC)
+	drummer := &Player{MemberId: 1, Name: "drummer", Part: Dr}
+	bassist := &Player{MemberId: 2, Name: "bassist", Part: Ba}
+	validBand := &BandModel{Name: "band1", LiveId: 1, Turn: 1, SetLength: 30, Player: []*Player{drummer, bassist}}
+	invalidBand := &BandModel{Name: "band2", LiveId: 1, Turn: 2, SetLength: 30, Player: []*Player{drummer}}
+	overrun := fmt.Errorf("%w: over", ErrTimetableOverrun)
+
+	tests := []struct {
+		// テスト名
+		testName string
+		// 遷移前のライブ
+		liveModel *LiveModel
+		// 指定するバージョン
+		version int
+		// 遷移先の状態
+		to LiveStatus
+		// タイムテーブルの確認結果
+		checkError error
+		// 状態を更新するか
+		expectedPatch bool
+		// 戻り値の期待値(error)
+		expectedError error
+	}{
+		{
+			testName:      "正常系_募集開始",
+			liveModel:     &LiveModel{Id: 1, Date: today.AddDate(0, 0, 1), Version: 3, Status: LiveDraft},
+			version:       3,
+			to:            LiveEntryOpen,
+			expectedPatch: true,
+		},
+		{
+			testName:      "正常系_開催当日は募集できる",
+			liveModel:     &LiveModel{Id: 1, Date: time.Date(2022, 1, 10, 0, 0, 0, 0, time.UTC), Version: 3, Status: LiveDraft},
+			version:       3,
+			to:            LiveEntryOpen,
+			expectedPatch: true,
+		},
+		{
+			testName:      "異常系_開催日を過ぎたライブは募集できない",
+			liveModel:     &LiveModel{Id: 1, Date: today.AddDate(0, 0, -1), Version: 3, Status: LiveDraft},
+			version:       3,
+			to:            LiveEntryOpen,
+			expectedError: ErrTransitionRejected,
+		},
+		{
+			testName:      "異常系_バージョンが異なる",
+			liveModel:     &LiveModel{Id: 1, Date: today, Version: 4, Status: LiveDraft},
+			version:       3,
+			to:            LiveEntryOpen,
+			expectedError: ErrPreconditionFailed,
+		},
+		{
+			testName:      "異常系_遷移できない状態",
+			liveModel:     &LiveModel{Id: 1, Date: today, Version: 3, Status: LiveDraft},
+			version:       3,
+			to:            LiveDone,
+			expectedError: ErrInvalidTransition,
+		},
+		{
+			testName:      "異常系_出演バンドがいないと締め切れない",
+			liveModel:     &LiveModel{Id: 1, Date: today, Version: 3, Status: LiveEntryOpen},
+			version:       3,
+			to:            LiveEntryClosed,
+			expectedError: ErrTransitionRejected,
+		},
+		{
+			testName:      "正常系_確定",
+			liveModel:     &LiveModel{Id: 1, Date: today, Version: 3, Status: LiveEntryClosed, Band: []*BandModel{validBand}},
+			version:       3,
+			to:            LiveConfirmed,
+			expectedPatch: true,
+		},
+		{
+			testName:      "異常系_編成のルールを満たさないバンドがいると確定できない",
+			liveModel:     &LiveModel{Id: 1, Date: today, Version: 3, Status: LiveEntryClosed, Band: []*BandModel{validBand, invalidBand}},
+			version:       3,
+			to:            LiveConfirmed,
+			expectedError: ErrTransitionRejected,
+		},
+		{
+			testName:      "異常系_終演時刻を超えると確定できない",
+			liveModel:     &LiveModel{Id: 1, Date: today, Version: 3, Status: LiveEntryClosed, Band: []*BandModel{validBand}},
+			version:       3,
+			to:            LiveConfirmed,
+			checkError:    overrun,
+			expectedError: ErrTimetableOverrun,
+		},
+		{
+			testName:      "異常系_開催日より前は開催済みにできない",
+			liveModel:     &LiveModel{Id: 1, Date: today.AddDate(0, 0, 1), Version: 3, Status: LiveConfirmed},
+			version:       3,
+			to:            LiveDone,
+			expectedError: ErrTransitionRejected,
+		},
+		{
+			testName:      "正常系_中止には条件がない",
+			liveModel:     &LiveModel{Id: 1, Date: today.AddDate(0, 0, -1), Version: 3, Status: LiveEntryOpen},
+			version:       3,
+			to:            LiveCancelled,
+			expectedPatch: true,
+		},
+	}
+
+	for _, tc := range tests {
+		liveDescService := new(LiveDescServiceMock)
+		liveDescService.On("GetById", 1).Return(tc.liveModel, nil)
+		lineupRuleRepository := new(LineupRuleRepositoryMock)
+		lineupRuleRepository.On("FindByLiveId", 1).Return((*LineupRule)(nil), nil)
+		timetableService := new(TimetableServiceMock)
+		timetableService.On("Check", 1, mock.Anything).Return(tc.checkError)
+		liveRepository := new(LiveRepositoryMock)
+		liveRepository.On("Patch", 1, tc.version, &LivePatch{Status: &tc.to}).Return(nil)
+		liveRepository.On("FindById", 1).Return(&Live{Id: 1, Version: tc.version + 1, Status: tc.to}, nil)
+		service := NewLiveStatusServiceImpl(liveDescService, NewLineupRuleServiceImpl(lineupRuleRepository, nil), timetableService,
+			&UnitOfWorkMock{repositories: &Repositories{Live: liveRepository}}, func() time.Time { return today })
+
+		// when
+		live, err := service.Transition(1, tc.version, tc.to)
+
+		// then
+		assert.True(t, errors.Is(err, tc.expectedError), fmt.Sprintf("テスト名: %s", tc.testName))
+		if tc.expectedPatch {
+			assert.Equal(t, &Live{Id: 1, Version: tc.version + 1, Status: tc.to}, live, fmt.Sprintf("テスト名: %s", tc.testName))
+			liveRepository.AssertCalled(t, "Patch", 1, tc.version, &LivePatch{Status: &tc.to})
+		} else {
+			assert.Nil(t, live, fmt.Sprintf("テスト名: %s", tc.testName))
+			liveRepository.AssertNotCalled(t, "Patch", 1, tc.version, &LivePatch{Status: &tc.to})
+		}
+	}
+}
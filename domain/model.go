@@ -1,6 +1,9 @@
 package domain
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // Live ライブの構造体
 type Live struct {
@@ -16,6 +19,119 @@ type Live struct {
 	PerformanceFee int
 	// 1バンドあたりの機材費
 	EquipmentCost int
+	// 開場時刻
+	OpenTime Clock
+	// 開演時刻
+	StartTime Clock
+	// 終演時刻(これを超えるタイムテーブルは組めない)
+	CloseTime Clock
+	// 転換時間(分)。0 の場合はタイムテーブル作成時の既定値を使う
+	Changeover int
+	// 更新のたびに 1 増えるバージョン(楽観的排他制御に使う)。出演バンドやメンバーの変更でも増える
+	Version int
+	// 最終更新日時(秒単位)
+	UpdatedAt time.Time
+	// 状態
+	Status LiveStatus
+}
+
+// LivePatch ライブの部分更新。nil のフィールドは変更しない
+type LivePatch struct {
+	Name           *string
+	Location       *string
+	Date           *time.Time
+	PerformanceFee *int
+	EquipmentCost  *int
+	OpenTime       *Clock
+	StartTime      *Clock
+	CloseTime      *Clock
+	Changeover     *int
+	// 状態は LiveStatusService の遷移でのみ変更する
+	Status *LiveStatus
+}
+
+// Apply 指定されたフィールドを live に反映する
+func (p *LivePatch) Apply(live *Live) {
+	if p.Name != nil {
+		live.Name = *p.Name
+	}
+	if p.Location != nil {
+		live.Location = *p.Location
+	}
+	if p.Date != nil {
+		live.Date = *p.Date
+	}
+	if p.PerformanceFee != nil {
+		live.PerformanceFee = *p.PerformanceFee
+	}
+	if p.EquipmentCost != nil {
+		live.EquipmentCost = *p.EquipmentCost
+	}
+	if p.OpenTime != nil {
+		live.OpenTime = *p.OpenTime
+	}
+	if p.StartTime != nil {
+		live.StartTime = *p.StartTime
+	}
+	if p.CloseTime != nil {
+		live.CloseTime = *p.CloseTime
+	}
+	if p.Changeover != nil {
+		live.Changeover = *p.Changeover
+	}
+	if p.Status != nil {
+		live.Status = *p.Status
+	}
+}
+
+// LiveStatus ライブの状態
+type LiveStatus string
+
+const (
+	// LiveDraft 下書き。登録直後の状態
+	LiveDraft = LiveStatus("draft")
+	// LiveEntryOpen 出演バンドを募集している
+	LiveEntryOpen = LiveStatus("entry_open")
+	// LiveEntryClosed 募集を締め切り、出演順や編成を調整している
+	LiveEntryClosed = LiveStatus("entry_closed")
+	// LiveConfirmed 出演バンドとメンバーが確定した
+	LiveConfirmed = LiveStatus("confirmed")
+	// LiveDone 開催済み
+	LiveDone = LiveStatus("done")
+	// LiveCancelled 中止
+	LiveCancelled = LiveStatus("cancelled")
+)
+
+// liveTransitions 状態ごとに遷移できる状態。開催済みと中止からは遷移できない
+var liveTransitions = map[LiveStatus][]LiveStatus{
+	LiveDraft:       {LiveEntryOpen, LiveCancelled},
+	LiveEntryOpen:   {LiveEntryClosed, LiveCancelled},
+	LiveEntryClosed: {LiveConfirmed, LiveCancelled},
+	LiveConfirmed:   {LiveDone, LiveCancelled},
+}
+
+// IsValid 定義済みの状態か
+func (s LiveStatus) IsValid() bool {
+	switch s {
+	case LiveDraft, LiveEntryOpen, LiveEntryClosed, LiveConfirmed, LiveDone, LiveCancelled:
+		return true
+	}
+	return false
+}
+
+// CanTransitionTo to に遷移できるか
+func (s LiveStatus) CanTransitionTo(to LiveStatus) bool {
+	for _, next := range liveTransitions[s] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// AcceptsLineupChanges 出演バンドやバンドメンバーを変更できる状態か。確定した後は変更できない
+func (s LiveStatus) AcceptsLineupChanges() bool {
+	return s == LiveDraft || s == LiveEntryOpen || s == LiveEntryClosed
 }
 
 // Band バンドの構造体
@@ -26,11 +142,85 @@ type Band struct {
 	LiveId int
 	// 出演順
 	Turn int
+	// 持ち時間(分)
+	SetLength int
+	// バンドプロフィールの ID(0 の場合はプロフィールに紐づかない)
+	BandId int
+	// 更新のたびに 1 増えるバージョン(楽観的排他制御に使う)
+	Version int
+}
+
+// BandPatch 出演バンドの部分更新。nil のフィールドは変更しない。
+// 出演順はバンドメンバーと合わせて移動するため含めず、BandService で扱う
+type BandPatch struct {
+	Name      *string
+	SetLength *int
+	// 0 を指定するとプロフィールとの紐づけを外す
+	BandId *int
+}
+
+// Apply 指定されたフィールドを band に反映する
+func (p *BandPatch) Apply(band *Band) {
+	if p.Name != nil {
+		band.Name = *p.Name
+	}
+	if p.SetLength != nil {
+		band.SetLength = *p.SetLength
+	}
+	if p.BandId != nil {
+		band.BandId = *p.BandId
+	}
+}
+
+// Clock 0時からの経過分で表す時刻。日付をまたぐ場合は 24:30 のように24時以降で表す。
+// 0 は未設定を表す
+type Clock int
+
+// ParseClock "18:30" または "18:30:00" 形式の文字列を Clock に変換する。空文字は未設定とする
+func ParseClock(s string) (Clock, error) {
+	if s == "" {
+		return 0, nil
+	}
+	var hour, minute, second int
+	n, err := fmt.Sscanf(s, "%d:%d:%d", &hour, &minute, &second)
+	if n < 2 {
+		return 0, fmt.Errorf("invalid clock %q: %v", s, err)
+	}
+	if hour < 0 || minute < 0 || minute >= 60 {
+		return 0, fmt.Errorf("invalid clock %q", s)
+	}
+	return Clock(hour*60 + minute), nil
+}
+
+func (c Clock) String() string {
+	if c == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%02d:%02d", int(c)/60, int(c)%60)
+}
+
+// Add minutes 分後の時刻を返す
+func (c Clock) Add(minutes int) Clock {
+	return c + Clock(minutes)
+}
+
+func (c Clock) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+func (c *Clock) UnmarshalText(text []byte) error {
+	clock, err := ParseClock(string(text))
+	if err != nil {
+		return err
+	}
+	*c = clock
+	return nil
 }
 
 // Part 楽器パート構造体
 type Part string
 
+// 初期状態でパートのカタログに登録されているパート
 const (
 	Vo   = Part("Vo.")
 	Gt   = Part("Gt.")
@@ -40,10 +230,53 @@ const (
 	Dr   = Part("Dr.")
 )
 
-// Player Band メンバー構造体
+// PartCategory パートの分類
+type PartCategory string
+
+const (
+	Rhythm = PartCategory("rhythm")
+	Melody = PartCategory("melody")
+	Vocal  = PartCategory("vocal")
+)
+
+// PartDefinition パートのカタログに登録されたパート
+type PartDefinition struct {
+	// パートのコード(Gt. など)
+	Code Part
+	// 表示名
+	DisplayName string
+	// 分類
+	Category PartCategory
+	// 表示順
+	SortOrder int
+}
+
+// Player Band メンバー構造体。あるパートを担当するメンバーを表す
 type Player struct {
+	// メンバーID(0 の場合は名前から解決する)
+	MemberId int
+	Name     string
+	Part     Part
+}
+
+// Member メンバー。表示名は変更でき、担当できるパートを複数持つ
+type Member struct {
+	// メンバーID
+	Id int
+	// 表示名(メンバー間で一意)
 	Name string
-	Part Part
+	// 担当できるパート
+	Part []Part
+}
+
+// HasPart メンバーが part を担当できるか
+func (m *Member) HasPart(part Part) bool {
+	for _, p := range m.Part {
+		if p == part {
+			return true
+		}
+	}
+	return false
 }
 
 // LiveModel ライブの構造体
@@ -60,6 +293,20 @@ type LiveModel struct {
 	PerformanceFee int
 	// 1バンドあたりの機材費
 	EquipmentCost int
+	// 開場時刻
+	OpenTime Clock
+	// 開演時刻
+	StartTime Clock
+	// 終演時刻
+	CloseTime Clock
+	// 転換時間(分)
+	Changeover int
+	// バージョン
+	Version int
+	// 最終更新日時
+	UpdatedAt time.Time
+	// 状態
+	Status LiveStatus
 	// 参加するバンド
 	Band []*BandModel
 }
@@ -72,14 +319,408 @@ type BandModel struct {
 	LiveId int
 	// 出演順
 	Turn int
+	// 持ち時間(分)
+	SetLength int
+	// バンドプロフィールの ID(0 の場合はプロフィールに紐づかない)
+	BandId int
+	// バージョン
+	Version int
 	// メンバー
 	Player []*Player
 }
 
 // BandMember バンドメンバーの構造体
 type BandMember struct {
-	LiveId     int
-	Turn       int
+	LiveId int
+	Turn   int
+	// メンバーID(0 の場合は MemberName から解決する)
+	MemberId   int
 	MemberName string
 	MemberPart Part
 }
+
+// LiveDeletion ライブ削除時に削除されるレコードの一覧
+type LiveDeletion struct {
+	// ライブ
+	Live *Live
+	// 出演バンド
+	Band []*Band
+	// バンドメンバー
+	BandMember []*BandMember
+}
+
+// FeeRule 複数のバンドに出演するメンバーの出演料の数え方
+type FeeRule string
+
+const (
+	// ChargeOnce 出演バンド数に関わらず出演料は1回分
+	ChargeOnce = FeeRule("once")
+	// ChargePerAppearance 出演するバンドごとに出演料がかかる
+	ChargePerAppearance = FeeRule("per_appearance")
+)
+
+// IsValid 定義済みの数え方か
+func (r FeeRule) IsValid() bool {
+	return r == ChargeOnce || r == ChargePerAppearance
+}
+
+// Settlement ライブの精算結果
+type Settlement struct {
+	// ライブID
+	LiveId int
+	// 出演料の数え方
+	Rule FeeRule
+	// バンドごとの機材費の負担額
+	Band []*BandSettlement
+	// メンバーごとの支払額
+	Player []*PlayerSettlement
+	// 支払額の合計
+	Total int
+}
+
+// BandSettlement バンドごとの機材費の負担額
+type BandSettlement struct {
+	// バンド名
+	Name string
+	// 出演順
+	Turn int
+	// 機材費
+	EquipmentCost int
+	// メンバーごとの負担額
+	Share []*EquipmentShare
+	// メンバーがいないため誰にも割り当てられなかった機材費
+	Unassigned int
+}
+
+// EquipmentShare メンバー1人あたりの機材費の負担額
+type EquipmentShare struct {
+	// メンバーの名前
+	Name string
+	// 負担額
+	Amount int
+}
+
+// PlayerSettlement メンバーごとの支払額
+type PlayerSettlement struct {
+	// メンバーの名前
+	Name string
+	// 出演するバンド数
+	Appearances int
+	// 出演料
+	PerformanceFee int
+	// 機材費の負担額
+	EquipmentShare int
+	// 支払額
+	Total int
+}
+
+// PaymentKind 入金か返金か
+type PaymentKind string
+
+const (
+	// Paid 入金
+	Paid = PaymentKind("payment")
+	// Refund 返金
+	Refund = PaymentKind("refund")
+)
+
+// PaymentMethod 支払方法
+type PaymentMethod string
+
+const (
+	Cash     = PaymentMethod("cash")
+	Transfer = PaymentMethod("transfer")
+	Other    = PaymentMethod("other")
+)
+
+// Payment 出演料の入金・返金の記録
+type Payment struct {
+	// 支払ID
+	Id int
+	// ライブ ID
+	LiveId int
+	// 支払ったメンバーのID(0 の場合は PlayerName から解決する)
+	MemberId int
+	// 支払ったメンバーの名前
+	PlayerName string
+	// 入金か返金か
+	Kind PaymentKind
+	// 支払方法
+	Method PaymentMethod
+	// 金額(入金・返金とも正の値)
+	Amount int
+	// 支払日時
+	PaidAt time.Time
+	// 備考
+	Note string
+}
+
+// Reconciliation 期間内のライブの未払い残高
+type Reconciliation struct {
+	// ライブごとの残高
+	Live []*LiveReconciliation
+	// メンバーごとの残高(期間内のライブの合計)
+	Player []*PlayerBalance
+}
+
+// LiveReconciliation ライブごとの未払い残高
+type LiveReconciliation struct {
+	// ライブ
+	Live *Live
+	// メンバーごとの残高
+	Player []*PlayerBalance
+	// 請求額の合計
+	Due int
+	// 支払済み額の合計
+	Paid int
+	// 未払い額の合計
+	Outstanding int
+}
+
+// PlayerBalance メンバーごとの未払い残高
+type PlayerBalance struct {
+	// メンバーの名前
+	Name string
+	// 請求額
+	Due int
+	// 支払済み額(入金 - 返金)
+	Paid int
+	// 未払い額(請求額 - 支払済み額)
+	Outstanding int
+}
+
+// Timetable ライブのタイムテーブル
+type Timetable struct {
+	// ライブID
+	LiveId int
+	// 開場時刻
+	OpenTime Clock
+	// 開演時刻
+	StartTime Clock
+	// 終演時刻
+	CloseTime Clock
+	// 転換時間(分)
+	Changeover int
+	// バンドごとの出演時刻
+	Slot []*TimetableSlot
+	// 最後のバンドの終了時刻
+	EndTime Clock
+}
+
+// TimetableSlot バンドごとの出演時刻
+type TimetableSlot struct {
+	// バンド名
+	Name string
+	// 出演順
+	Turn int
+	// 開始時刻
+	Start Clock
+	// 終了時刻
+	End Clock
+}
+
+// ConflictKind 出演者の重複の種類
+type ConflictKind string
+
+const (
+	// DoubleBooking 同じ日の別のライブにも出演する
+	DoubleBooking = ConflictKind("double_booking")
+	// BackToBack 連続する出演順のバンドに続けて出演する
+	BackToBack = ConflictKind("back_to_back")
+)
+
+// Conflict 出演者の重複
+type Conflict struct {
+	// 重複の種類
+	Kind ConflictKind
+	// メンバーの名前
+	PlayerName string
+	// ライブID
+	LiveId int
+	// 出演順
+	Turn int
+	// 重複相手のライブID(BackToBack の場合は LiveId と同じ)
+	OtherLiveId int
+	// 重複相手の出演順
+	OtherTurn int
+}
+
+// LineupRule ライブごとのバンド編成のルール
+type LineupRule struct {
+	// ライブ ID
+	LiveId int
+	// パートごとの人数のルール
+	Part []*PartRule
+	// 最少人数(0 の場合は制限なし)
+	MinMembers int
+	// 最大人数(0 の場合は制限なし)
+	MaxMembers int
+	// Gt.Vo. を Vo. の人数に含めるか
+	GtVoCountsAsVo bool
+	// Gt.Vo. を Gt. の人数に含めるか
+	GtVoCountsAsGt bool
+}
+
+// PartRule パートごとの人数のルール
+type PartRule struct {
+	// パート
+	Part Part
+	// 必要な人数
+	Min int
+	// 最大人数(0 の場合は制限なし)
+	Max int
+}
+
+// BandValidation バンド編成のルールの確認結果
+type BandValidation struct {
+	// バンド名
+	Name string
+	// 出演順
+	Turn int
+	// ルールを満たしているか
+	Valid bool
+	// 満たしていないルール
+	Violation []string
+}
+
+// RunningOrderOption 出演順の最適化の条件
+type RunningOrderOption struct {
+	// トップバッターに固定するバンドの現在の出演順(0 の場合は固定しない)
+	Opener int
+	// トリに固定するバンドの現在の出演順(0 の場合は固定しない)
+	Headliner int
+	// 連続する出演順で同じメンバーが出演する場合の1人あたりのペナルティ
+	ConsecutivePenalty int
+	// 前後のバンドでメンバーが入れ替わる場合の1人あたりのペナルティ
+	ChangePenalty int
+}
+
+// RunningOrder 出演順の提案
+type RunningOrder struct {
+	// ライブID
+	LiveId int
+	// 現在の出演順を提案する並びで並べたもの
+	Turns []int
+	// 提案する並びのバンド
+	Band []*Band
+	// ペナルティの合計(小さいほど良い)
+	Score int
+	// ペナルティの内訳
+	Explanation []*ScoreItem
+}
+
+// ScoreItem 出演順のペナルティの内訳
+type ScoreItem struct {
+	// 前のバンドの現在の出演順
+	Turn int
+	// 後のバンドの現在の出演順
+	NextTurn int
+	// ペナルティの理由
+	Reason string
+	// ペナルティ
+	Penalty int
+}
+
+// BandProfile ライブをまたいで同じバンドを表すプロフィール
+type BandProfile struct {
+	// バンドID
+	Id int
+	// バンド名。紐づく出演バンドの名前もこの名前で表示する
+	Name string
+	// 既定のメンバー
+	Player []*Player
+}
+
+// Appearance メンバーの出演記録
+type Appearance struct {
+	// ライブ ID
+	LiveId int
+	// ライブ名
+	LiveName string
+	// 場所
+	Location string
+	// 日付
+	Date time.Time
+	// バンド名
+	BandName string
+	// 出演順
+	Turn int
+	// 担当したパート
+	Part Part
+}
+
+// PerformanceHistory メンバーの期間内の出演履歴
+type PerformanceHistory struct {
+	// メンバー
+	Member *Member
+	// 出演したライブの数
+	LiveCount int
+	// 一緒に出演したバンドごとの出演回数(初めて出演した順)
+	Band []*BandAppearance
+	// 出演記録(日付順)
+	Appearance []*Appearance
+}
+
+// BandAppearance バンドごとの出演回数
+type BandAppearance struct {
+	// バンド名
+	Name string
+	// 出演回数
+	Count int
+}
+
+// ApplicationStatus 出演申込の状態
+type ApplicationStatus string
+
+const (
+	// ApplicationPending 審査待ち
+	ApplicationPending = ApplicationStatus("pending")
+	// ApplicationWaitlisted 補欠。承認または却下できる
+	ApplicationWaitlisted = ApplicationStatus("waitlisted")
+	// ApplicationApproved 承認済み。出演バンドとして登録されている
+	ApplicationApproved = ApplicationStatus("approved")
+	// ApplicationRejected 却下
+	ApplicationRejected = ApplicationStatus("rejected")
+)
+
+// applicationTransitions 状態ごとに変更できる状態。承認済みと却下からは変更できない
+var applicationTransitions = map[ApplicationStatus][]ApplicationStatus{
+	ApplicationPending:    {ApplicationWaitlisted, ApplicationApproved, ApplicationRejected},
+	ApplicationWaitlisted: {ApplicationApproved, ApplicationRejected},
+}
+
+// CanChangeTo to に変更できるか
+func (s ApplicationStatus) CanChangeTo(to ApplicationStatus) bool {
+	for _, next := range applicationTransitions[s] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// EntryApplication バンドからの出演申込
+type EntryApplication struct {
+	// 申込ID
+	Id int
+	// ライブ ID
+	LiveId int
+	// 希望するバンド名
+	Name string
+	// バンドプロフィールの ID(0 の場合はプロフィールに紐づかない)
+	BandId int
+	// 出演するメンバー
+	Player []*Player
+	// 希望する出演順(0 の場合は希望なし)
+	PreferredTurn int
+	// 持ち時間(分)
+	SetLength int
+	// 備考
+	Note string
+	// 状態
+	Status ApplicationStatus
+	// 承認して登録した出演順(承認前は 0)
+	Turn int
+	// 申込日時
+	AppliedAt time.Time
+}
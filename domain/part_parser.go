@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// partAliases パートのコードを正規化した文字列ごとの別名。カタログに登録されているパートの別名だけが使われる
+var partAliases = map[string][]string{
+	"vo":   {"vocal", "vocals", "vox", "ボーカル", "ヴォーカル", "ボーカリスト", "歌"},
+	"gt":   {"g", "gtr", "guitar", "guitars", "ギター", "ギタリスト", "エレキ", "エレキギター"},
+	"gtvo": {"gv", "gvo", "vogt", "guitarvocal", "vocalguitar", "ギターボーカル", "ギタボ", "ボーカルギター"},
+	"key":  {"keys", "kb", "kbd", "keyboard", "keyboards", "piano", "pf", "synth", "キーボード", "キーボ", "ピアノ", "鍵盤", "シンセ"},
+	"ba":   {"b", "bass", "bassguitar", "ベース", "ベーシスト"},
+	"dr":   {"drs", "ds", "drum", "drums", "ドラム", "ドラムス", "ドラマー"},
+	"cho":  {"chorus", "backingvocal", "コーラス"},
+	"sax":  {"saxophone", "サックス", "サクソフォン"},
+	"tp":   {"trp", "trumpet", "トランペット"},
+	"perc": {"per", "percussion", "パーカッション"},
+	"dj":   {"ディージェイ"},
+}
+
+// PartParser パートの表記ゆれ(大文字小文字、全角半角、略称、英語名、日本語名)を吸収してカタログのパートに変換する
+type PartParser struct {
+	parts    map[string]Part
+	accepted []string
+}
+
+// NewPartParser カタログのパートのコード、表示名と既知の別名からパーサーを作る
+func NewPartParser(catalog []*PartDefinition) *PartParser {
+	parser := &PartParser{parts: map[string]Part{}}
+	for _, definition := range catalog {
+		for _, name := range partNames(definition) {
+			parser.add(name, definition.Code)
+		}
+		parser.accepted = append(parser.accepted, string(definition.Code))
+	}
+	return parser
+}
+
+// partNames パートを指す正規化した名前を、コード、表示名、既知の別名の順に返す
+func partNames(definition *PartDefinition) []string {
+	code := normalizePartName(string(definition.Code))
+	names := []string{code, normalizePartName(definition.DisplayName)}
+	for _, alias := range partAliases[code] {
+		names = append(names, normalizePartName(alias))
+	}
+	return names
+}
+
+// add 先に登録した対応を優先する
+func (p *PartParser) add(name string, part Part) {
+	if _, ok := p.parts[name]; name != "" && !ok {
+		p.parts[name] = part
+	}
+}
+
+// Parse 変換できない場合は受け付けるパートを列挙した ErrUnknownPart を返す
+func (p *PartParser) Parse(name string) (Part, error) {
+	if part, ok := p.parts[normalizePartName(name)]; ok {
+		return part, nil
+	}
+	return "", fmt.Errorf("%w: %q (accepted: %s)", ErrUnknownPart, name, strings.Join(p.accepted, ", "))
+}
+
+// ParseCode 表記ゆれを吸収せず、カタログのパートのコードと一致する場合だけ受け付ける。削除のような取り消せない操作に使う
+func (p *PartParser) ParseCode(code string) (Part, error) {
+	for _, accepted := range p.accepted {
+		if accepted == code {
+			return Part(code), nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q is not a part code (accepted: %s)", ErrUnknownPart, code, strings.Join(p.accepted, ", "))
+}
+
+// normalizePartName NFKC で全角英数字と半角カナの幅を揃えて小文字にし、空白と区切り記号を取り除く
+func normalizePartName(name string) string {
+	var builder strings.Builder
+	for _, r := range norm.NFKC.String(name) {
+		if unicode.IsSpace(r) || strings.ContainsRune(".・&/+-_", r) {
+			continue
+		}
+		builder.WriteRune(unicode.ToLower(r))
+	}
+	return builder.String()
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestPartParserParse(t *testing.T) {
+	// given
+	parser := NewPartParser([]*PartDefinition{
+		{Code: Vo, DisplayName: "Vocal", Category: Vocal, SortOrder: 1},
+		{Code: Gt, DisplayName: "Guitar", Category: Melody, SortOrder: 2},
+		{Code: GtVo, DisplayName: "Guitar & Vocal", Category: Vocal, SortOrder: 3},
+		{Code: Key, DisplayName: "Keyboard", Category: Melody, SortOrder: 4},
+		{Code: Ba, DisplayName: "Bass", Category: Rhythm, SortOrder: 5},
+		{Code: Dr, DisplayName: "Drums", Category: Rhythm, SortOrder: 6},
+		{Code: Part("Sax."), DisplayName: "Saxophone", Category: Melody, SortOrder: 7},
+	})
+
+	tests := []struct {
+		// テスト名
+		testName string
+		// 入力されたパート
+		name string
+		// 戻り値の期待値
+		expected Part
+		// 戻り値の期待値(error)
+		expectedError error
+	}{
+		{testName: "正常系_コード", name: "Gt.", expected: Gt},
+		{testName: "正常系_ピリオドなし", name: "Gt", expected: Gt},
+		{testName: "正常系_小文字", name: "gt.", expected: Gt},
+		{testName: "正常系_英語名", name: "Guitar", expected: Gt},
+		{testName: "正常系_日本語名", name: "ギター", expected: Gt},
+		{testName: "正常系_全角英字", name: "Ｇｔ．", expected: Gt},
+		{testName: "正常系_半角カナ", name: "ｷﾞﾀｰ", expected: Gt},
+		{testName: "正常系_前後の空白", name: " Dr. ", expected: Dr},
+		{testName: "正常系_Gt.Vo.の略称", name: "ギタボ", expected: GtVo},
+		{testName: "正常系_Gt.Vo.の表示名", name: "guitar & vocal", expected: GtVo},
+		{testName: "正常系_Gt.Vo.のコード", name: "gt.vo.", expected: GtVo},
+		{testName: "正常系_追加したパートの別名", name: "サックス", expected: Part("Sax.")},
+		{testName: "異常系_カタログにないパートの別名", name: "トランペット", expectedError: ErrUnknownPart},
+		{testName: "異常系_不明なパート", name: "kazoo", expectedError: ErrUnknownPart},
+		{testName: "異常系_空文字", name: "", expectedError: ErrUnknownPart},
+	}
+
+	for _, tc := range tests {
+		// when
+		actual, err := parser.Parse(tc.name)
+
+		// then
+		assert.True(t, errors.Is(err, tc.expectedError), fmt.Sprintf("テスト名: %s", tc.testName))
+		assert.Equal(t, tc.expected, actual, fmt.Sprintf("テスト名: %s", tc.testName))
+		if err != nil {
+			assert.True(t, strings.Contains(err.Error(), "Vo., Gt., Gt.Vo., Key., Ba., Dr., Sax."), fmt.Sprintf("テスト名: %s", tc.testName))
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"fmt"
+)
+
+var (
+	// ErrUnknownPart パートのカタログに登録されていないパートを指定した場合のエラー
+	ErrUnknownPart = newError(ErrBadRequest, "unknown part")
+	// ErrInvalidPart パートの登録内容が不正な場合のエラー
+	ErrInvalidPart = newError(ErrBadRequest, "invalid part")
+	// ErrPartInUse 使われているパートを削除しようとした場合のエラー
+	ErrPartInUse = newError(ErrConflict, "part is in use")
+	// ErrDuplicatePart 正規化したコードや表示名、別名が別のパートと重なる場合のエラー
+	ErrDuplicatePart = newError(ErrConflict, "part name is already used")
+)
+
+// PartCatalog 入力されたパートをカタログのパートに変換する
+type PartCatalog interface {
+	// Parse 表記ゆれを吸収してカタログのパートに変換する。変換できない場合は登録されているパートを列挙した ErrUnknownPart を返す
+	Parse(name string) (Part, error)
+}
+
+// PartService パートのカタログを管理する
+type PartService interface {
+	// Parser 現在のカタログを1回だけ読み込んでパーサーを作る。1つのリクエストの中では同じパーサーを使う
+	Parser() (PartCatalog, error)
+	GetAll() ([]*PartDefinition, error)
+	// Save 正規化したコードや表示名、別名が別のパートと重なる場合は ErrDuplicatePart を返す
+	Save(part *PartDefinition) error
+	Delete(code Part) error
+}
+
+type PartServiceImpl struct {
+	partRepository PartRepository
+}
+
+func NewPartServiceImpl(partRepository PartRepository) *PartServiceImpl {
+	return &PartServiceImpl{partRepository: partRepository}
+}
+
+func (p *PartServiceImpl) GetAll() ([]*PartDefinition, error) {
+	return p.partRepository.FindAll()
+}
+
+func (p *PartServiceImpl) Parser() (PartCatalog, error) {
+	parts, err := p.partRepository.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	return NewPartParser(parts), nil
+}
+
+func (p *PartServiceImpl) Save(part *PartDefinition) error {
+	if part.Code == "" {
+		return fmt.Errorf("%w: code is required", ErrInvalidPart)
+	}
+	switch part.Category {
+	case Rhythm, Melody, Vocal:
+	default:
+		return fmt.Errorf("%w: unknown category %s", ErrInvalidPart, part.Category)
+	}
+	if part.DisplayName == "" {
+		part.DisplayName = string(part.Code)
+	}
+	parts, err := p.partRepository.FindAll()
+	if err != nil {
+		return err
+	}
+	// 置き換える登録済みのパート自身とは重なってよい
+	var others []*PartDefinition
+	for _, e := range parts {
+		if e.Code != part.Code {
+			others = append(others, e)
+		}
+	}
+	parser := NewPartParser(others)
+	for _, name := range partNames(part) {
+		if used, ok := parser.parts[name]; ok {
+			return fmt.Errorf("%w: %q is already used by %s", ErrDuplicatePart, name, used)
+		}
+	}
+	return p.partRepository.Save(part)
+}
+
+// Delete 別名では削除せず、カタログのパートのコードだけを受け付ける
+func (p *PartServiceImpl) Delete(code Part) error {
+	parts, err := p.partRepository.FindAll()
+	if err != nil {
+		return err
+	}
+	if _, err := NewPartParser(parts).ParseCode(string(code)); err != nil {
+		return err
+	}
+	used, err := p.partRepository.IsUsed(code)
+	if err != nil {
+		return err
+	}
+	if used {
+		return fmt.Errorf("%w: %s", ErrPartInUse, code)
+	}
+	return p.partRepository.Delete(code)
+}
@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+type PartRepositoryMock struct {
+	mock.Mock
+	PartRepository
+}
+
+func (m *PartRepositoryMock) FindAll() ([]*PartDefinition, error) {
+	args := m.Called()
+	return args.Get(0).([]*PartDefinition), args.Error(1)
+}
+
+func (m *PartRepositoryMock) Save(part *PartDefinition) error {
+	args := m.Called(part)
+	return args.Error(0)
+}
+
+func (m *PartRepositoryMock) Delete(code Part) error {
+	args := m.Called(code)
+	return args.Error(0)
+}
+
+func (m *PartRepositoryMock) IsUsed(code Part) (bool, error) {
+	args := m.Called(code)
+	return args.Bool(0), args.Error(1)
+}
+
+var catalog = []*PartDefinition{
+	{Code: Vo, DisplayName: "Vocal", Category: Vocal, SortOrder: 1},
+	{Code: Gt, DisplayName: "Guitar", Category: Melody, SortOrder: 2},
+	{Code: Part("Sax."), DisplayName: "Saxophone", Category: Melody, SortOrder: 3},
+}
+
+func partRepositoryMock() *PartRepositoryMock {
+	partRepository := new(PartRepositoryMock)
+	partRepository.On("FindAll").Return(catalog, nil)
+	return partRepository
+}
+
+func TestPartSave(t *testing.T) {
+	// given
+	tests := []struct {
+		testName      string
+		part          PartDefinition
+		saveTimes     int
+		expectedError error
+	}{
+		{
+			testName:  "正常系",
+			part:      PartDefinition{Code: Part("Perc."), Category: Rhythm, SortOrder: 10},
+			saveTimes: 1,
+		},
+		{
+			testName:  "正常系_登録済みのパートを置き換える",
+			part:      PartDefinition{Code: Gt, DisplayName: "ギター", Category: Melody, SortOrder: 2},
+			saveTimes: 1,
+		},
+		{
+			testName:      "異常系_正規化したコードが別のパートのコードと重なる",
+			part:          PartDefinition{Code: Part("ＧＴ"), Category: Melody},
+			saveTimes:     0,
+			expectedError: ErrDuplicatePart,
+		},
+		{
+			testName:      "異常系_コードが別のパートの別名と重なる",
+			part:          PartDefinition{Code: Part("Vox"), Category: Vocal},
+			saveTimes:     0,
+			expectedError: ErrDuplicatePart,
+		},
+		{
+			testName:      "異常系_表示名が別のパートの別名と重なる",
+			part:          PartDefinition{Code: Part("Sx."), DisplayName: "サックス", Category: Melody},
+			saveTimes:     0,
+			expectedError: ErrDuplicatePart,
+		},
+		{
+			testName:      "異常系_不明な分類",
+			part:          PartDefinition{Code: Part("DJ"), Category: PartCategory("other")},
+			saveTimes:     0,
+			expectedError: ErrInvalidPart,
+		},
+	}
+
+	for _, tc := range tests {
+		partRepository := partRepositoryMock()
+		partRepository.On("Save", &tc.part).Return(nil)
+		partService := NewPartServiceImpl(partRepository)
+
+		// when
+		err := partService.Save(&tc.part)
+
+		// then
+		assert.True(t, errors.Is(err, tc.expectedError), fmt.Sprintf("テスト名: %s", tc.testName))
+		partRepository.AssertNumberOfCalls(t, "Save", tc.saveTimes)
+	}
+}
+
+func TestPartDelete(t *testing.T) {
+	// given
+	tests := []struct {
+		testName      string
+		code          Part
+		used          bool
+		deleteTimes   int
+		expectedError error
+	}{
+		{
+			testName:    "正常系",
+			code:        Part("Sax."),
+			used:        false,
+			deleteTimes: 1,
+		},
+		{
+			testName:      "異常系_別名では削除できない",
+			code:          Part("サックス"),
+			deleteTimes:   0,
+			expectedError: ErrUnknownPart,
+		},
+		{
+			testName:      "異常系_カタログにないパート",
+			code:          Part("Tp."),
+			deleteTimes:   0,
+			expectedError: ErrUnknownPart,
+		},
+		{
+			testName:      "異常系_使われているパート",
+			code:          Part("Sax."),
+			used:          true,
+			deleteTimes:   0,
+			expectedError: ErrPartInUse,
+		},
+	}
+
+	for _, tc := range tests {
+		partRepository := partRepositoryMock()
+		partRepository.On("IsUsed", Part("Sax.")).Return(tc.used, nil)
+		partRepository.On("Delete", Part("Sax.")).Return(nil)
+		partService := NewPartServiceImpl(partRepository)
+
+		// when
+		err := partService.Delete(tc.code)
+
+		// then
+		assert.True(t, errors.Is(err, tc.expectedError), fmt.Sprintf("テスト名: %s", tc.testName))
+		partRepository.AssertNumberOfCalls(t, "Delete", tc.deleteTimes)
+	}
+}
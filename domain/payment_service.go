@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// ErrInvalidPayment 支払の記録内容が不正な場合のエラー
+var ErrInvalidPayment = newError(ErrValidation, "invalid payment")
+
+// PaymentService 出演料の支払を記録し、未払い残高を照合する。
+// 支払は追記のみで、記録の誤りは返金で打ち消す
+type PaymentService interface {
+	Record(payment *Payment) error
+	GetByLiveId(id int) ([]*Payment, error)
+	Reconcile(start *time.Time, end *time.Time) (*Reconciliation, error)
+}
+
+type PaymentServiceImpl struct {
+	paymentRepository PaymentRepository
+	liveRepository    LiveRepository
+	playerRepository  PlayerRepository
+	unitOfWork        UnitOfWork
+	settlementService SettlementService
+}
+
+func NewPaymentServiceImpl(paymentRepository PaymentRepository, liveRepository LiveRepository, playerRepository PlayerRepository, unitOfWork UnitOfWork, settlementService SettlementService) *PaymentServiceImpl {
+	return &PaymentServiceImpl{
+		paymentRepository: paymentRepository,
+		liveRepository:    liveRepository,
+		playerRepository:  playerRepository,
+		unitOfWork:        unitOfWork,
+		settlementService: settlementService,
+	}
+}
+
+// Record 返金は支払済み額の確認と登録を同じトランザクションで行い、同じライブへの返金は1つずつ確認する
+func (p *PaymentServiceImpl) Record(payment *Payment) error {
+	if payment.Amount <= 0 {
+		return fmt.Errorf("%w: amount must be positive", ErrInvalidPayment)
+	}
+	switch payment.Kind {
+	case Paid, Refund:
+	default:
+		return fmt.Errorf("%w: unknown kind %s", ErrInvalidPayment, payment.Kind)
+	}
+	switch payment.Method {
+	case Cash, Transfer, Other:
+	default:
+		return fmt.Errorf("%w: unknown method %s", ErrInvalidPayment, payment.Method)
+	}
+	return p.unitOfWork.Do(func(repositories *Repositories) error {
+		if payment.Kind == Refund {
+			if _, err := repositories.Live.FindByIdForUpdate(payment.LiveId); err != nil {
+				return err
+			}
+			payments, err := repositories.Payment.FindByLiveId(payment.LiveId)
+			if err != nil {
+				return err
+			}
+			paid := paidAmounts(payments)[payment.PlayerName]
+			if payment.Amount > paid {
+				return fmt.Errorf("%w: refund %d exceeds paid amount %d of %s", ErrInvalidPayment, payment.Amount, paid, payment.PlayerName)
+			}
+		}
+		memberId, err := resolveMemberId(repositories.Player, payment.PlayerName)
+		if err != nil {
+			return err
+		}
+		payment.MemberId = memberId
+		return repositories.Payment.Create(payment)
+	})
+}
+
+func (p *PaymentServiceImpl) GetByLiveId(id int) ([]*Payment, error) {
+	return p.paymentRepository.FindByLiveId(id)
+}
+
+// Reconcile 期間内のライブについて、精算結果の請求額と支払の記録を突き合わせる
+func (p *PaymentServiceImpl) Reconcile(start *time.Time, end *time.Time) (*Reconciliation, error) {
+	lives, err := p.liveRepository.FindByPeriod(start, end)
+	if err != nil {
+		return nil, err
+	}
+	reconciliation := &Reconciliation{}
+	totals := map[string]*PlayerBalance{}
+	for _, live := range lives {
+		settlement, err := p.settlementService.Calculate(live.Id, "")
+		if err != nil {
+			return nil, err
+		}
+		payments, err := p.paymentRepository.FindByLiveId(live.Id)
+		if err != nil {
+			return nil, err
+		}
+		liveReconciliation := reconcileLive(live, settlement, payments)
+		for _, balance := range liveReconciliation.Player {
+			total, ok := totals[balance.Name]
+			if !ok {
+				total = &PlayerBalance{Name: balance.Name}
+				totals[balance.Name] = total
+				reconciliation.Player = append(reconciliation.Player, total)
+			}
+			total.Due += balance.Due
+			total.Paid += balance.Paid
+			total.Outstanding += balance.Outstanding
+		}
+		reconciliation.Live = append(reconciliation.Live, liveReconciliation)
+	}
+	return reconciliation, nil
+}
+
+// reconcileLive 1つのライブの請求額と支払済み額を突き合わせる。
+// 出演者でないのに支払の記録があるメンバーも請求額 0 として含める
+func reconcileLive(live *Live, settlement *Settlement, payments []*Payment) *LiveReconciliation {
+	paid := paidAmounts(payments)
+	liveReconciliation := &LiveReconciliation{Live: live}
+	seen := map[string]bool{}
+	add := func(name string, due int) {
+		seen[name] = true
+		balance := &PlayerBalance{Name: name, Due: due, Paid: paid[name], Outstanding: due - paid[name]}
+		liveReconciliation.Player = append(liveReconciliation.Player, balance)
+		liveReconciliation.Due += balance.Due
+		liveReconciliation.Paid += balance.Paid
+		liveReconciliation.Outstanding += balance.Outstanding
+	}
+	for _, player := range settlement.Player {
+		add(player.Name, player.Total)
+	}
+	for _, payment := range payments {
+		if !seen[payment.PlayerName] {
+			add(payment.PlayerName, 0)
+		}
+	}
+	return liveReconciliation
+}
+
+// paidAmounts メンバーごとの支払済み額(入金 - 返金)を返す
+func paidAmounts(payments []*Payment) map[string]int {
+	paid := map[string]int{}
+	for _, payment := range payments {
+		switch payment.Kind {
+		case Paid:
+			paid[payment.PlayerName] += payment.Amount
+		case Refund:
+			paid[payment.PlayerName] -= payment.Amount
+		}
+	}
+	return paid
+}
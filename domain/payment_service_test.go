@@ -0,0 +1,170 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+type PaymentRepositoryMock struct {
+	mock.Mock
+	PaymentRepository
+}
+
+func (m *PaymentRepositoryMock) FindByLiveId(id int) ([]*Payment, error) {
+	args := m.Called(id)
+	return args.Get(0).([]*Payment), args.Error(1)
+}
+
+func (m *PaymentRepositoryMock) Create(payment *Payment) error {
+	args := m.Called(payment)
+	return args.Error(0)
+}
+
+type SettlementServiceMock struct {
+	mock.Mock
+	SettlementService
+}
+
+func (m *SettlementServiceMock) Calculate(id int, rule FeeRule) (*Settlement, error) {
+	args := m.Called(id, rule)
+	return args.Get(0).(*Settlement), args.Error(1)
+}
+
+func TestPaymentRecord(t *testing.T) {
+	// given
+	payments := []*Payment{
+		{Id: 1, LiveId: 1, PlayerName: "drummer", Kind: Paid, Method: Cash, Amount: 3000, PaidAt: now},
+		{Id: 2, LiveId: 1, PlayerName: "drummer", Kind: Refund, Method: Cash, Amount: 500, PaidAt: now},
+	}
+
+	tests := []struct {
+		// テスト名
+		testName string
+		// 記録する支払
+		payment Payment
+		// 支払の登録が呼ばれる回数
+		createTimes int
+		// 戻り値の期待値(error)
+		expectedError error
+	}{
+		{
+			testName:    "正常系_一部入金",
+			payment:     Payment{LiveId: 1, PlayerName: "bassist", Kind: Paid, Method: Transfer, Amount: 1000, PaidAt: now},
+			createTimes: 1,
+		},
+		{
+			testName:    "正常系_支払済み額以内の返金",
+			payment:     Payment{LiveId: 1, PlayerName: "drummer", Kind: Refund, Method: Cash, Amount: 2500, PaidAt: now},
+			createTimes: 1,
+		},
+		{
+			testName:      "異常系_支払済み額を超える返金",
+			payment:       Payment{LiveId: 1, PlayerName: "drummer", Kind: Refund, Method: Cash, Amount: 2501, PaidAt: now},
+			expectedError: ErrInvalidPayment,
+		},
+		{
+			testName:      "異常系_金額が0",
+			payment:       Payment{LiveId: 1, PlayerName: "drummer", Kind: Paid, Method: Cash, Amount: 0, PaidAt: now},
+			expectedError: ErrInvalidPayment,
+		},
+		{
+			testName:      "異常系_登録されていないメンバー",
+			payment:       Payment{LiveId: 1, PlayerName: "unknown", Kind: Paid, Method: Cash, Amount: 100, PaidAt: now},
+			expectedError: ErrPlayerNotFound,
+		},
+		{
+			testName:      "異常系_不明な支払方法",
+			payment:       Payment{LiveId: 1, PlayerName: "drummer", Kind: Paid, Method: PaymentMethod("card"), Amount: 100, PaidAt: now},
+			expectedError: ErrInvalidPayment,
+		},
+	}
+
+	for _, tc := range tests {
+		paymentRepository := new(PaymentRepositoryMock)
+		paymentRepository.On("FindByLiveId", 1).Return(payments, nil)
+		paymentRepository.On("Create", &tc.payment).Return(nil)
+		playerRepository := new(PlayerRepositoryMock)
+		playerRepository.
+			On("FindByName", "drummer").Return(&Member{Id: 1, Name: "drummer", Part: []Part{Dr}}, nil).
+			On("FindByName", "bassist").Return(&Member{Id: 2, Name: "bassist", Part: []Part{Ba}}, nil).
+			On("FindByName", "unknown").Return((*Member)(nil), nil)
+		liveRepository := new(LiveRepositoryMock)
+		liveRepository.On("FindByIdForUpdate", 1).Return(&Live{Id: 1}, nil)
+		unitOfWork := &UnitOfWorkMock{repositories: &Repositories{Live: liveRepository, Player: playerRepository, Payment: paymentRepository}}
+		paymentService := NewPaymentServiceImpl(paymentRepository, nil, playerRepository, unitOfWork, nil)
+
+		// when
+		err := paymentService.Record(&tc.payment)
+
+		// then
+		assert.True(t, errors.Is(err, tc.expectedError), fmt.Sprintf("テスト名: %s", tc.testName))
+		paymentRepository.AssertNumberOfCalls(t, "Create", tc.createTimes)
+		if tc.payment.Kind == Refund {
+			liveRepository.AssertCalled(t, "FindByIdForUpdate", 1)
+		}
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	// given
+	live1 := Live{Id: 1, Name: "live1", Date: now}
+	live2 := Live{Id: 2, Name: "live2", Date: now}
+	liveRepository := new(LiveRepositoryMock)
+	liveRepository.On("FindByPeriod", &now, &now).Return([]*Live{&live1, &live2}, nil)
+	settlementService := new(SettlementServiceMock)
+	settlementService.
+		On("Calculate", 1, FeeRule("")).Return(&Settlement{Player: []*PlayerSettlement{
+		{Name: "drummer", Total: 3000}, {Name: "bassist", Total: 2500},
+	}}, nil).
+		On("Calculate", 2, FeeRule("")).Return(&Settlement{Player: []*PlayerSettlement{
+		{Name: "drummer", Total: 2000},
+	}}, nil)
+	paymentRepository := new(PaymentRepositoryMock)
+	paymentRepository.
+		On("FindByLiveId", 1).Return([]*Payment{
+		{LiveId: 1, PlayerName: "drummer", Kind: Paid, Amount: 3000},
+		{LiveId: 1, PlayerName: "bassist", Kind: Paid, Amount: 1000},
+		{LiveId: 1, PlayerName: "guitarist", Kind: Paid, Amount: 500},
+	}, nil).
+		On("FindByLiveId", 2).Return([]*Payment{
+		{LiveId: 2, PlayerName: "drummer", Kind: Paid, Amount: 2000},
+		{LiveId: 2, PlayerName: "drummer", Kind: Refund, Amount: 500},
+	}, nil)
+	paymentService := NewPaymentServiceImpl(paymentRepository, liveRepository, nil, nil, settlementService)
+
+	// when
+	actual, err := paymentService.Reconcile(&now, &now)
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, []*LiveReconciliation{
+		{
+			Live: &live1,
+			Player: []*PlayerBalance{
+				{Name: "drummer", Due: 3000, Paid: 3000, Outstanding: 0},
+				{Name: "bassist", Due: 2500, Paid: 1000, Outstanding: 1500},
+				{Name: "guitarist", Due: 0, Paid: 500, Outstanding: -500},
+			},
+			Due:         5500,
+			Paid:        4500,
+			Outstanding: 1000,
+		},
+		{
+			Live: &live2,
+			Player: []*PlayerBalance{
+				{Name: "drummer", Due: 2000, Paid: 1500, Outstanding: 500},
+			},
+			Due:         2000,
+			Paid:        1500,
+			Outstanding: 500,
+		},
+	}, actual.Live)
+	assert.Equal(t, []*PlayerBalance{
+		{Name: "drummer", Due: 5000, Paid: 4500, Outstanding: 500},
+		{Name: "bassist", Due: 2500, Paid: 1000, Outstanding: 1500},
+		{Name: "guitarist", Due: 0, Paid: 500, Outstanding: -500},
+	}, actual.Player)
+}
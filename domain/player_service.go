@@ -1,27 +1,165 @@
 package domain
 
+import (
+	"fmt"
+	"time"
+)
+
+// ErrMemberNameTaken 他のメンバーが使っている表示名を指定した場合のエラー
+var ErrMemberNameTaken = newError(ErrConflict, "member name is already taken")
+
 type PlayerService interface {
+	// Register 名前とパートでメンバーを登録する。同じ名前のメンバーが存在する場合はパートを追加する
 	Register(player *Player) error
+	// Delete 名前で指定したメンバーの担当できるパートから外す
 	Delete(player *Player) error
 	GetByPart(part *Part) ([]*Player, error)
+	GetById(id int) (*Member, error)
+	// Rename 表示名を変更する。出演履歴や支払の記録はメンバーIDで紐づくため、そのまま新しい名前で表示される
+	Rename(id int, name string) error
+	AddPart(id int, part Part) error
+	// RemovePart 担当できるパートから外す。過去の出演履歴のパートは変更しない
+	RemovePart(id int, part Part) error
+	// GetHistory 名前で指定したメンバーの期間内の出演履歴を返す
+	GetHistory(name string, start *time.Time, end *time.Time) (*PerformanceHistory, error)
 }
 
 type PlayerServiceImpl struct {
-	playerRepository PlayerRepository
+	playerRepository     PlayerRepository
+	bandMemberRepository BandMemberRepository
+	unitOfWork           UnitOfWork
 }
 
-func NewPlayerServiceImpl(playerRepository PlayerRepository) *PlayerServiceImpl {
-	return &PlayerServiceImpl{playerRepository: playerRepository}
+func NewPlayerServiceImpl(playerRepository PlayerRepository, bandMemberRepository BandMemberRepository, unitOfWork UnitOfWork) *PlayerServiceImpl {
+	return &PlayerServiceImpl{playerRepository: playerRepository, bandMemberRepository: bandMemberRepository, unitOfWork: unitOfWork}
 }
 
 func (p *PlayerServiceImpl) Register(player *Player) error {
-	return p.playerRepository.Create(player)
+	return p.unitOfWork.Do(func(repositories *Repositories) error {
+		member, err := repositories.Player.FindByName(player.Name)
+		if err != nil {
+			return err
+		}
+		if member == nil {
+			member = &Member{Name: player.Name, Part: []Part{player.Part}}
+			if err := repositories.Player.Create(member); err != nil {
+				return err
+			}
+		} else if !member.HasPart(player.Part) {
+			if err := repositories.Player.AddPart(member.Id, player.Part); err != nil {
+				return err
+			}
+		}
+		player.MemberId = member.Id
+		return nil
+	})
 }
 
 func (p *PlayerServiceImpl) Delete(player *Player) error {
-	return p.playerRepository.Delete(player)
+	return p.unitOfWork.Do(func(repositories *Repositories) error {
+		memberId, err := resolveMemberId(repositories.Player, player.Name)
+		if err != nil {
+			return err
+		}
+		return repositories.Player.RemovePart(memberId, player.Part)
+	})
 }
 
 func (p *PlayerServiceImpl) GetByPart(part *Part) ([]*Player, error) {
 	return p.playerRepository.FindByPart(part)
 }
+
+func (p *PlayerServiceImpl) GetById(id int) (*Member, error) {
+	return findMember(p.playerRepository, id)
+}
+
+func (p *PlayerServiceImpl) Rename(id int, name string) error {
+	return p.unitOfWork.Do(func(repositories *Repositories) error {
+		if _, err := findMember(repositories.Player, id); err != nil {
+			return err
+		}
+		other, err := repositories.Player.FindByName(name)
+		if err != nil {
+			return err
+		}
+		if other != nil && other.Id != id {
+			return fmt.Errorf("%w: %s", ErrMemberNameTaken, name)
+		}
+		return repositories.Player.Rename(id, name)
+	})
+}
+
+func (p *PlayerServiceImpl) AddPart(id int, part Part) error {
+	return p.unitOfWork.Do(func(repositories *Repositories) error {
+		member, err := findMember(repositories.Player, id)
+		if err != nil {
+			return err
+		}
+		if member.HasPart(part) {
+			return nil
+		}
+		return repositories.Player.AddPart(id, part)
+	})
+}
+
+func (p *PlayerServiceImpl) RemovePart(id int, part Part) error {
+	return p.unitOfWork.Do(func(repositories *Repositories) error {
+		member, err := findMember(repositories.Player, id)
+		if err != nil {
+			return err
+		}
+		if !member.HasPart(part) {
+			return fmt.Errorf("%w: %s(%s)", ErrPlayerNotFound, member.Name, part)
+		}
+		return repositories.Player.RemovePart(id, part)
+	})
+}
+
+func (p *PlayerServiceImpl) GetHistory(name string, start *time.Time, end *time.Time) (*PerformanceHistory, error) {
+	member, err := p.playerRepository.FindByName(name)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil {
+		return nil, fmt.Errorf("%w: %s", ErrPlayerNotFound, name)
+	}
+	appearances, err := p.bandMemberRepository.FindAppearances(member.Id, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	history := &PerformanceHistory{Member: member, Appearance: appearances}
+	lives := map[int]bool{}
+	bands := map[string]*BandAppearance{}
+	// 同じバンドで複数のパートを担当した場合も1回の出演として数える
+	counted := map[string]bool{}
+	for _, appearance := range appearances {
+		lives[appearance.LiveId] = true
+		key := fmt.Sprintf("%d/%d", appearance.LiveId, appearance.Turn)
+		if counted[key] {
+			continue
+		}
+		counted[key] = true
+		band, ok := bands[appearance.BandName]
+		if !ok {
+			band = &BandAppearance{Name: appearance.BandName}
+			bands[appearance.BandName] = band
+			history.Band = append(history.Band, band)
+		}
+		band.Count++
+	}
+	history.LiveCount = len(lives)
+	return history, nil
+}
+
+// findMember メンバーが存在するか確認する
+func findMember(playerRepository PlayerRepository, id int) (*Member, error) {
+	member, err := playerRepository.FindById(id)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil {
+		return nil, fmt.Errorf("%w: id %d", ErrPlayerNotFound, id)
+	}
+	return member, nil
+}
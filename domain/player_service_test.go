@@ -0,0 +1,231 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func (m *PlayerRepositoryMock) FindById(id int) (*Member, error) {
+	args := m.Called(id)
+	return args.Get(0).(*Member), args.Error(1)
+}
+
+func (m *PlayerRepositoryMock) Create(member *Member) error {
+	args := m.Called(member)
+	return args.Error(0)
+}
+
+func (m *PlayerRepositoryMock) Rename(id int, name string) error {
+	args := m.Called(id, name)
+	return args.Error(0)
+}
+
+func (m *PlayerRepositoryMock) AddPart(id int, part Part) error {
+	args := m.Called(id, part)
+	return args.Error(0)
+}
+
+func (m *PlayerRepositoryMock) RemovePart(id int, part Part) error {
+	args := m.Called(id, part)
+	return args.Error(0)
+}
+
+func (m *BandMemberRepositoryMock) FindAppearances(memberId int, start *time.Time, end *time.Time) ([]*Appearance, error) {
+	args := m.Called(memberId, start, end)
+	return args.Get(0).([]*Appearance), args.Error(1)
+}
+
+func TestPlayerRegister(t *testing.T) {
+	// given
+	tests := []struct {
+		// テスト名
+		testName string
+		// 同じ名前で登録されているメンバー
+		member *Member
+		// 登録するパート
+		part Part
+		// メンバー登録が呼ばれる回数
+		createTimes int
+		// パート追加が呼ばれる回数
+		addPartTimes int
+	}{
+		{
+			testName:    "正常系_新しいメンバー",
+			member:      nil,
+			part:        Gt,
+			createTimes: 1,
+		},
+		{
+			testName:     "正常系_既存のメンバーにパートを追加",
+			member:       &Member{Id: 1, Name: "player", Part: []Part{Vo}},
+			part:         Gt,
+			addPartTimes: 1,
+		},
+		{
+			testName: "正常系_登録済みのパート",
+			member:   &Member{Id: 1, Name: "player", Part: []Part{Gt}},
+			part:     Gt,
+		},
+	}
+
+	for _, tc := range tests {
+		playerRepository := new(PlayerRepositoryMock)
+		playerRepository.On("FindByName", "player").Return(tc.member, nil)
+		playerRepository.On("Create", mock.Anything).Return(nil)
+		playerRepository.On("AddPart", 1, tc.part).Return(nil)
+		playerService := NewPlayerServiceImpl(playerRepository, nil, &UnitOfWorkMock{repositories: &Repositories{Player: playerRepository}})
+
+		// when
+		err := playerService.Register(&Player{Name: "player", Part: tc.part})
+
+		// then
+		assert.Nil(t, err, fmt.Sprintf("テスト名: %s", tc.testName))
+		playerRepository.AssertNumberOfCalls(t, "Create", tc.createTimes)
+		playerRepository.AssertNumberOfCalls(t, "AddPart", tc.addPartTimes)
+	}
+}
+
+func TestPlayerRename(t *testing.T) {
+	// given
+	member := Member{Id: 1, Name: "player", Part: []Part{Gt}}
+
+	tests := []struct {
+		// テスト名
+		testName string
+		// 変更後の名前で登録されているメンバー
+		other *Member
+		// 名前の変更が呼ばれる回数
+		renameTimes int
+		// 戻り値の期待値(error)
+		expectedError error
+	}{
+		{
+			testName:    "正常系",
+			other:       nil,
+			renameTimes: 1,
+		},
+		{
+			testName:    "正常系_同じ名前",
+			other:       &member,
+			renameTimes: 1,
+		},
+		{
+			testName:      "異常系_他のメンバーが使っている名前",
+			other:         &Member{Id: 2, Name: "renamed"},
+			renameTimes:   0,
+			expectedError: ErrMemberNameTaken,
+		},
+	}
+
+	for _, tc := range tests {
+		playerRepository := new(PlayerRepositoryMock)
+		playerRepository.On("FindById", 1).Return(&member, nil)
+		playerRepository.On("FindByName", "renamed").Return(tc.other, nil)
+		playerRepository.On("Rename", 1, "renamed").Return(nil)
+		playerService := NewPlayerServiceImpl(playerRepository, nil, &UnitOfWorkMock{repositories: &Repositories{Player: playerRepository}})
+
+		// when
+		err := playerService.Rename(1, "renamed")
+
+		// then
+		assert.True(t, errors.Is(err, tc.expectedError), fmt.Sprintf("テスト名: %s", tc.testName))
+		playerRepository.AssertNumberOfCalls(t, "Rename", tc.renameTimes)
+	}
+}
+
+func TestPlayerRemovePart(t *testing.T) {
+	// given
+	member := Member{Id: 1, Name: "player", Part: []Part{Gt, Vo}}
+
+	tests := []struct {
+		testName        string
+		part            Part
+		removePartTimes int
+		expectedError   error
+	}{
+		{
+			testName:        "正常系",
+			part:            Vo,
+			removePartTimes: 1,
+		},
+		{
+			testName:        "異常系_担当していないパート",
+			part:            Dr,
+			removePartTimes: 0,
+			expectedError:   ErrPlayerNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		playerRepository := new(PlayerRepositoryMock)
+		playerRepository.On("FindById", 1).Return(&member, nil)
+		playerRepository.On("RemovePart", 1, tc.part).Return(nil)
+		playerService := NewPlayerServiceImpl(playerRepository, nil, &UnitOfWorkMock{repositories: &Repositories{Player: playerRepository}})
+
+		// when
+		err := playerService.RemovePart(1, tc.part)
+
+		// then
+		assert.True(t, errors.Is(err, tc.expectedError), fmt.Sprintf("テスト名: %s", tc.testName))
+		playerRepository.AssertNumberOfCalls(t, "RemovePart", tc.removePartTimes)
+	}
+}
+
+func TestPlayerGetHistory(t *testing.T) {
+	// given
+	member := Member{Id: 1, Name: "player", Part: []Part{Gt, Vo}}
+	first := time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)
+	second := time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC)
+	appearances := []*Appearance{
+		{LiveId: 1, LiveName: "live1", Date: first, BandName: "band1", Turn: 1, Part: Gt},
+		{LiveId: 1, LiveName: "live1", Date: first, BandName: "band1", Turn: 1, Part: Vo},
+		{LiveId: 1, LiveName: "live1", Date: first, BandName: "band2", Turn: 3, Part: Gt},
+		{LiveId: 2, LiveName: "live2", Date: second, BandName: "band1", Turn: 2, Part: Gt},
+	}
+
+	tests := []struct {
+		// テスト名
+		testName string
+		// 名前で登録されているメンバー
+		member *Member
+		// 戻り値の期待値
+		expected *PerformanceHistory
+		// 戻り値の期待値(error)
+		expectedError error
+	}{
+		{
+			testName: "正常系",
+			member:   &member,
+			expected: &PerformanceHistory{
+				Member:     &member,
+				LiveCount:  2,
+				Band:       []*BandAppearance{{Name: "band1", Count: 2}, {Name: "band2", Count: 1}},
+				Appearance: appearances,
+			},
+		},
+		{
+			testName:      "異常系_登録されていないメンバー",
+			member:        nil,
+			expectedError: ErrPlayerNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		playerRepository := new(PlayerRepositoryMock)
+		playerRepository.On("FindByName", "player").Return(tc.member, nil)
+		bandMemberRepository := new(BandMemberRepositoryMock)
+		bandMemberRepository.On("FindAppearances", 1, &first, (*time.Time)(nil)).Return(appearances, nil)
+		playerService := NewPlayerServiceImpl(playerRepository, bandMemberRepository, nil)
+
+		// when
+		actual, err := playerService.GetHistory("player", &first, nil)
+
+		// then
+		assert.Equal(t, tc.expected, actual, fmt.Sprintf("テスト名: %s", tc.testName))
+		assert.True(t, errors.Is(err, tc.expectedError), fmt.Sprintf("テスト名: %s", tc.testName))
+	}
+}
@@ -6,28 +6,127 @@ import (
 
 type LiveRepository interface {
 	FindById(id int) (*Live, error)
+	// FindByIdForUpdate UnitOfWork の中で使い、コミットするまで他のトランザクションからのライブへの書き込みを待たせる
+	FindByIdForUpdate(id int) (*Live, error)
 	FindByPeriod(start *time.Time, end *time.Time) ([]*Live, error)
 	Create(live *Live) error
-	Update(live *Live) error
-	Delete(id int) error
+	// Patch patch で指定されたカラムだけを更新し、バージョンを 1 増やす。
+	// バージョンが version と異なる場合は ErrPreconditionFailed を返す
+	Patch(id int, version int, patch *LivePatch) error
+	// Delete バージョンが version と異なる場合は ErrPreconditionFailed を返す
+	Delete(id int, version int) error
+	// Touch バージョンを 1 増やし、最終更新日時を現在時刻にする。ライブが存在しない場合は何もしない
+	Touch(id int) error
+}
+
+// LiveDescRepository ライブを出演バンドとメンバーを含めて読み込む。ライブの件数やバンドの数によらず一定の回数のクエリで取得する
+type LiveDescRepository interface {
+	FindById(id int) (*LiveModel, error)
+	// FindByPeriod 日付順に返す
+	FindByPeriod(start *time.Time, end *time.Time) ([]*LiveModel, error)
 }
 
 type BandRepository interface {
 	FindByLiveId(id int) ([]*Band, error)
+	// FindByBandId バンドプロフィールに紐づく出演バンドを返す
+	FindByBandId(id int) ([]*Band, error)
+	// Create ライブの中でまだ使っていないバージョンを割り当てて登録し、band.Version に設定する。
+	// 削除したバンドと同じ出演順で登録し直しても、以前のバージョンは使わない
 	Create(band *Band) error
-	Update(id int, turn int, band *Band) error
-	Delete(id int, turn int) error
+	// Patch patch で指定されたカラムだけを更新し、ライブの中でまだ使っていないバージョンに変える。
+	// バージョンが version と異なる場合は ErrPreconditionFailed を返す
+	Patch(id int, turn int, version int, patch *BandPatch) error
+	// Delete バージョンが version と異なる場合は ErrPreconditionFailed を返す
+	Delete(id int, turn int, version int) error
+	DeleteByLiveId(id int) error
 }
 
 type BandMemberRepository interface {
 	FindByLiveIdAndTurn(id int, turn int) ([]*Player, error)
+	FindByLiveId(id int) ([]*BandMember, error)
+	// FindAppearances メンバーの期間内の出演記録を日付順に返す
+	FindAppearances(memberId int, start *time.Time, end *time.Time) ([]*Appearance, error)
 	Create(bandMember *BandMember) error
+	// Delete バンドメンバーが存在しない場合は ErrNotFound を返す
 	Delete(bandMember *BandMember) error
-	Update(bandMember *BandMember, id int, turn int) error
+	// Update current を replacement で置き換える。current が存在しない場合は ErrNotFound を返す
+	Update(current *BandMember, replacement *BandMember) error
+	DeleteByLiveId(id int) error
 }
 
 type PlayerRepository interface {
-	Create(player *Player) error
-	Delete(player *Player) error
 	FindByPart(part *Part) ([]*Player, error)
+	// FindById メンバーが存在しない場合は nil を返す
+	FindById(id int) (*Member, error)
+	// FindByName メンバーが存在しない場合は nil を返す
+	FindByName(name string) (*Member, error)
+	// Create メンバーと担当できるパートを登録し、登録したメンバーの Id を設定する
+	Create(member *Member) error
+	Rename(id int, name string) error
+	AddPart(id int, part Part) error
+	// RemovePart 担当できるパートから外す。出演履歴のパートは変更しない
+	RemovePart(id int, part Part) error
+}
+
+type PaymentRepository interface {
+	FindByLiveId(id int) ([]*Payment, error)
+	Create(payment *Payment) error
+}
+
+type LineupRuleRepository interface {
+	// FindByLiveId ルールが設定されていない場合は nil を返す
+	FindByLiveId(id int) (*LineupRule, error)
+	// Save ライブのルールを rule で置き換える
+	Save(rule *LineupRule) error
+}
+
+type BandProfileRepository interface {
+	FindAll() ([]*BandProfile, error)
+	// FindById プロフィールが存在しない場合は nil を返す
+	FindById(id int) (*BandProfile, error)
+	// Create 登録したプロフィールの Id を設定する
+	Create(profile *BandProfile) error
+	Rename(id int, name string) error
+	// SaveRoster 既定のメンバーを players で置き換える
+	SaveRoster(id int, players []*Player) error
+}
+
+type PartRepository interface {
+	// FindAll 表示順に返す
+	FindAll() ([]*PartDefinition, error)
+	// Save パートを登録する。登録済みの場合は置き換える
+	Save(part *PartDefinition) error
+	Delete(code Part) error
+	// IsUsed メンバー、出演履歴、バンドプロフィール、編成のルール、出演申込のいずれかで使われているか
+	IsUsed(code Part) (bool, error)
+}
+
+type EntryApplicationRepository interface {
+	// FindByLiveId 申込順に返す。status を指定した場合はその状態の申込だけを返す
+	FindByLiveId(id int, status *ApplicationStatus) ([]*EntryApplication, error)
+	// FindById 申込が存在しない場合は nil を返す
+	FindById(id int) (*EntryApplication, error)
+	// Create 申込とメンバーを登録し、登録した申込の Id を設定する
+	Create(application *EntryApplication) error
+	// UpdateStatus 状態が current の場合だけ status に変更し、turn を登録した出演順として記録する。
+	// 状態が current と異なる場合は ErrConflict、申込が存在しない場合は ErrNotFound を返す
+	UpdateStatus(id int, current ApplicationStatus, status ApplicationStatus, turn int) error
+}
+
+// Repositories 1つのトランザクションを共有するリポジトリの組
+type Repositories struct {
+	Live             LiveRepository
+	Band             BandRepository
+	BandMember       BandMemberRepository
+	Player           PlayerRepository
+	Payment          PaymentRepository
+	LineupRule       LineupRuleRepository
+	BandProfile      BandProfileRepository
+	EntryApplication EntryApplicationRepository
+}
+
+// UnitOfWork 複数のリポジトリへの書き込みを1つのトランザクションとして実行する
+type UnitOfWork interface {
+	// Do fn がエラーを返した場合はロールバックし、それ以外の場合はコミットする
+	Do(fn func(repositories *Repositories) error) error
 }
@@ -0,0 +1,620 @@
+// Package repositorytest domain/repository.go のリポジトリの実装が共通で満たす振る舞いを確認するテスト
+package repositorytest
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"live-scheduler/domain"
+	"testing"
+	"time"
+)
+
+// Repositories 振る舞いを確認するリポジトリの組
+type Repositories struct {
+	Live             domain.LiveRepository
+	LiveDesc         domain.LiveDescRepository
+	Band             domain.BandRepository
+	BandMember       domain.BandMemberRepository
+	Player           domain.PlayerRepository
+	Payment          domain.PaymentRepository
+	LineupRule       domain.LineupRuleRepository
+	BandProfile      domain.BandProfileRepository
+	Part             domain.PartRepository
+	EntryApplication domain.EntryApplicationRepository
+	UnitOfWork       domain.UnitOfWork
+}
+
+// Factory データが登録されていない状態のリポジトリの組を返す。PartCatalog には初期状態のパートだけが登録されていること
+type Factory func(t *testing.T) *Repositories
+
+// missingId どの実装でも登録されていない ID
+const missingId = 999999
+
+// Run すべてのリポジトリの振る舞いを確認する。サブテストごとに factory で空の状態から始める
+func Run(t *testing.T, factory Factory) {
+	tests := []struct {
+		// テスト名
+		testName string
+		// 確認する振る舞い
+		test func(t *testing.T, r *Repositories)
+	}{
+		{testName: "Live", test: testLive},
+		{testName: "LiveDesc", test: testLiveDesc},
+		{testName: "Band", test: testBand},
+		{testName: "BandMember", test: testBandMember},
+		{testName: "Player", test: testPlayer},
+		{testName: "Payment", test: testPayment},
+		{testName: "LineupRule", test: testLineupRule},
+		{testName: "BandProfile", test: testBandProfile},
+		{testName: "Part", test: testPart},
+		{testName: "EntryApplication", test: testEntryApplication},
+		{testName: "UnitOfWork", test: testUnitOfWork},
+	}
+	for _, tc := range tests {
+		t.Run(tc.testName, func(t *testing.T) {
+			tc.test(t, factory(t))
+		})
+	}
+}
+
+func day(d int) time.Time {
+	return time.Date(2022, 1, d, 0, 0, 0, 0, time.UTC)
+}
+
+// createLive ライブを登録して ID を返す。Create は ID を設定しないため、日付と名前で検索する
+func createLive(t *testing.T, r *Repositories, name string, date time.Time) int {
+	require.Nil(t, r.Live.Create(&domain.Live{Name: name, Location: "location", Date: date, PerformanceFee: 5500, EquipmentCost: 2000, Status: domain.LiveDraft}))
+	lives, err := r.Live.FindByPeriod(&date, &date)
+	require.Nil(t, err)
+	for _, live := range lives {
+		if live.Name == name {
+			return live.Id
+		}
+	}
+	t.Fatalf("created live %s is not found", name)
+	return 0
+}
+
+func createMember(t *testing.T, r *Repositories, name string, parts ...domain.Part) int {
+	member := &domain.Member{Name: name, Part: parts}
+	require.Nil(t, r.Player.Create(member))
+	require.NotZero(t, member.Id)
+	return member.Id
+}
+
+func names(lives []*domain.Live) []string {
+	var names []string
+	for _, live := range lives {
+		names = append(names, live.Name)
+	}
+	return names
+}
+
+func testLive(t *testing.T, r *Repositories) {
+	_, err := r.Live.FindById(missingId)
+	assert.True(t, errors.Is(err, domain.ErrNotFound), "存在しないライブはエラー")
+
+	createLive(t, r, "second", day(2))
+	first := createLive(t, r, "first", day(1))
+	createLive(t, r, "third", day(3))
+	start, end := day(1), day(2)
+	lives, err := r.Live.FindByPeriod(&start, &end)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"first", "second"}, names(lives), "期間の両端を含み、日付順に返す")
+
+	live, err := r.Live.FindById(first)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, live.Version, "登録したライブのバージョンは 1")
+	assert.False(t, live.UpdatedAt.IsZero(), "登録日時を最終更新日時とする")
+	assert.Equal(t, domain.LiveDraft, live.Status)
+	name, openTime, startTime, changeover := "renamed", domain.Clock(17*60+30), domain.Clock(18*60), 10
+	assert.Nil(t, r.Live.Patch(first, 1, &domain.LivePatch{Name: &name, OpenTime: &openTime, StartTime: &startTime, Changeover: &changeover}))
+	live.Name, live.OpenTime, live.StartTime, live.Changeover, live.Version = name, openTime, startTime, changeover, 2
+	updated, err := r.Live.FindById(first)
+	assert.Nil(t, err)
+	assert.False(t, updated.UpdatedAt.Before(live.UpdatedAt), "最終更新日時を更新する")
+	live.UpdatedAt = updated.UpdatedAt
+	assert.Equal(t, live, updated, "指定したカラムだけを更新し、バージョンを 1 増やす")
+
+	unset, status := domain.Clock(0), domain.LiveEntryOpen
+	assert.Nil(t, r.Live.Patch(first, 2, &domain.LivePatch{OpenTime: &unset, Status: &status}))
+	live.OpenTime, live.Status, live.Version = 0, status, 3
+	updated, err = r.Live.FindById(first)
+	assert.Nil(t, err)
+	live.UpdatedAt = updated.UpdatedAt
+	assert.Equal(t, live, updated, "時刻は未設定に戻せる。状態を更新できる")
+	assert.Nil(t, r.Live.Patch(first, 3, &domain.LivePatch{}), "更新するカラムがなくてもエラーにしない")
+	assert.ErrorIs(t, r.Live.Patch(first, 3, &domain.LivePatch{Name: &name}), domain.ErrPreconditionFailed, "古いバージョンでは更新できない")
+	assert.ErrorIs(t, r.Live.Patch(missingId, 1, &domain.LivePatch{Name: &name}), domain.ErrNotFound, "存在しないライブ")
+
+	assert.Nil(t, r.Live.Touch(first))
+	touched, err := r.Live.FindById(first)
+	assert.Nil(t, err)
+	assert.Equal(t, 5, touched.Version, "Touch はバージョンを 1 増やす")
+	assert.False(t, touched.UpdatedAt.Before(updated.UpdatedAt), "Touch は最終更新日時を更新する")
+	assert.Nil(t, r.Live.Touch(missingId), "存在しないライブは何もしない")
+	err = r.UnitOfWork.Do(func(repositories *domain.Repositories) error {
+		locked, err := repositories.Live.FindByIdForUpdate(first)
+		if err != nil {
+			return err
+		}
+		assert.Equal(t, touched, locked, "FindById と同じ内容を返す")
+		_, err = repositories.Live.FindByIdForUpdate(missingId)
+		assert.ErrorIs(t, err, domain.ErrNotFound, "存在しないライブ")
+		return nil
+	})
+	assert.Nil(t, err)
+
+	require.Nil(t, r.Band.Create(&domain.Band{Name: "band", LiveId: first, Turn: 1}))
+	assert.ErrorIs(t, r.Live.Delete(first, 5), domain.ErrForeignKeyViolation, "出演バンドが登録されたライブは削除できない")
+	require.Nil(t, r.Band.Delete(first, 1, 1))
+	assert.ErrorIs(t, r.Live.Delete(first, 4), domain.ErrPreconditionFailed, "古いバージョンでは削除できない")
+	assert.ErrorIs(t, r.Live.Delete(missingId, 1), domain.ErrNotFound, "存在しないライブ")
+	assert.Nil(t, r.Live.Delete(first, 5))
+	_, err = r.Live.FindById(first)
+	assert.True(t, errors.Is(err, domain.ErrNotFound), "削除したライブはエラー")
+}
+
+func testLiveDesc(t *testing.T, r *Repositories) {
+	_, err := r.LiveDesc.FindById(missingId)
+	assert.True(t, errors.Is(err, domain.ErrNotFound), "存在しないライブはエラー")
+
+	later := createLive(t, r, "later", day(5))
+	empty := createLive(t, r, "empty", day(4))
+	drummer := createMember(t, r, "drummer", domain.Dr)
+	guitarist := createMember(t, r, "guitarist", domain.Gt, domain.Vo)
+	band2 := &domain.Band{Name: "band2", LiveId: later, Turn: 2, SetLength: 20}
+	require.Nil(t, r.Band.Create(band2))
+	band1 := &domain.Band{Name: "band1", LiveId: later, Turn: 1, SetLength: 30}
+	require.Nil(t, r.Band.Create(band1))
+	require.Nil(t, r.BandMember.Create(&domain.BandMember{LiveId: later, Turn: 1, MemberId: guitarist, MemberPart: domain.Vo}))
+	require.Nil(t, r.BandMember.Create(&domain.BandMember{LiveId: later, Turn: 1, MemberId: drummer, MemberPart: domain.Dr}))
+	require.Nil(t, r.BandMember.Create(&domain.BandMember{LiveId: later, Turn: 1, MemberId: guitarist, MemberPart: domain.Gt}))
+
+	liveModel, err := r.LiveDesc.FindById(later)
+	assert.Nil(t, err)
+	live, err := r.Live.FindById(later)
+	require.Nil(t, err)
+	assert.Equal(t, &domain.LiveModel{
+		Id: later, Name: "later", Location: "location", Date: day(5), PerformanceFee: 5500, EquipmentCost: 2000, Version: 1, UpdatedAt: live.UpdatedAt, Status: domain.LiveDraft,
+		Band: []*domain.BandModel{
+			{Name: "band1", LiveId: later, Turn: 1, SetLength: 30, Version: band1.Version, Player: []*domain.Player{
+				{MemberId: drummer, Name: "drummer", Part: domain.Dr},
+				{MemberId: guitarist, Name: "guitarist", Part: domain.Gt},
+				{MemberId: guitarist, Name: "guitarist", Part: domain.Vo},
+			}},
+			{Name: "band2", LiveId: later, Turn: 2, SetLength: 20, Version: band2.Version},
+		},
+	}, liveModel, "出演順、メンバーID、パートの順に返す")
+
+	start, end := day(4), day(5)
+	liveModels, err := r.LiveDesc.FindByPeriod(&start, &end)
+	assert.Nil(t, err)
+	require.Len(t, liveModels, 2)
+	assert.Equal(t, empty, liveModels[0].Id, "日付順に返す")
+	assert.Empty(t, liveModels[0].Band, "出演バンドがないライブも返す")
+	assert.Equal(t, liveModel, liveModels[1])
+}
+
+func testBand(t *testing.T, r *Repositories) {
+	live := createLive(t, r, "live", day(1))
+	band2 := &domain.Band{Name: "band2", LiveId: live, Turn: 2, SetLength: 20}
+	require.Nil(t, r.Band.Create(band2))
+	band1 := &domain.Band{Name: "band1", LiveId: live, Turn: 1, SetLength: 30}
+	require.Nil(t, r.Band.Create(band1))
+	assert.NotZero(t, band1.Version, "登録したバージョンを設定する")
+
+	assert.ErrorIs(t, r.Band.Create(&domain.Band{Name: "duplicated", LiveId: live, Turn: 1}), domain.ErrConflict, "出演順の重複")
+	assert.ErrorIs(t, r.Band.Create(&domain.Band{Name: "band", LiveId: missingId, Turn: 1}), domain.ErrForeignKeyViolation, "存在しないライブ")
+	assert.ErrorIs(t, r.Band.Create(&domain.Band{Name: "band", LiveId: live, Turn: 3, BandId: missingId}), domain.ErrForeignKeyViolation, "存在しないバンドプロフィール")
+
+	bands, err := r.Band.FindByLiveId(live)
+	assert.Nil(t, err)
+	assert.Equal(t, []*domain.Band{
+		{Name: "band1", LiveId: live, Turn: 1, SetLength: 30, Version: band1.Version},
+		{Name: "band2", LiveId: live, Turn: 2, SetLength: 20, Version: band2.Version},
+	}, bands, "出演順に返す")
+
+	profile := &domain.BandProfile{Name: "profile"}
+	require.Nil(t, r.BandProfile.Create(profile))
+	setLength := 25
+	require.Nil(t, r.Band.Patch(live, 2, band2.Version, &domain.BandPatch{SetLength: &setLength, BandId: &profile.Id}))
+	bands, err = r.Band.FindByBandId(profile.Id)
+	assert.Nil(t, err)
+	require.Len(t, bands, 1)
+	patched := bands[0].Version
+	assert.Equal(t, []*domain.Band{
+		{Name: "profile", LiveId: live, Turn: 2, SetLength: 25, BandId: profile.Id, Version: patched},
+	}, bands, "プロフィールに紐づくバンドはプロフィールの名前で返す")
+	assert.Greater(t, patched, band2.Version, "更新するとバージョンが増える")
+	noProfile := 0
+	require.Nil(t, r.Band.Patch(live, 2, patched, &domain.BandPatch{BandId: &noProfile}))
+	bands, err = r.Band.FindByLiveId(live)
+	assert.Nil(t, err)
+	require.Len(t, bands, 2)
+	current := bands[1].Version
+	assert.Equal(t, []*domain.Band{
+		{Name: "band1", LiveId: live, Turn: 1, SetLength: 30, Version: band1.Version},
+		{Name: "band2", LiveId: live, Turn: 2, SetLength: 25, Version: current},
+	}, bands, "指定したカラムだけを更新する")
+	assert.Greater(t, current, patched, "更新するとバージョンが増える")
+	missing := missingId
+	assert.ErrorIs(t, r.Band.Patch(live, 2, current, &domain.BandPatch{BandId: &missing}), domain.ErrForeignKeyViolation, "存在しないバンドプロフィール")
+	assert.ErrorIs(t, r.Band.Patch(live, 2, patched, &domain.BandPatch{SetLength: &setLength}), domain.ErrPreconditionFailed, "古いバージョンでは更新できない")
+	assert.ErrorIs(t, r.Band.Patch(live, 4, 1, &domain.BandPatch{SetLength: &setLength}), domain.ErrNotFound, "存在しない出演順")
+	assert.ErrorIs(t, r.Band.Delete(live, 2, patched), domain.ErrPreconditionFailed, "古いバージョンでは削除できない")
+	assert.ErrorIs(t, r.Band.Delete(live, 4, 1), domain.ErrNotFound, "存在しない出演順")
+
+	// 削除して同じ出演順で登録し直したバンドには、削除したバンドが使ったバージョンを割り当てない
+	deleted := &domain.Band{Name: "band4", LiveId: live, Turn: 4}
+	require.Nil(t, r.Band.Create(deleted))
+	stale := deleted.Version
+	require.Nil(t, r.Band.Patch(live, 4, stale, &domain.BandPatch{SetLength: &setLength}))
+	bands, err = r.Band.FindByLiveId(live)
+	assert.Nil(t, err)
+	require.Nil(t, r.Band.Delete(live, 4, bands[2].Version))
+	recreated := &domain.Band{Name: "band4", LiveId: live, Turn: 4}
+	require.Nil(t, r.Band.Create(recreated))
+	assert.Greater(t, recreated.Version, bands[2].Version, "削除したバンドより新しいバージョンになる")
+	assert.ErrorIs(t, r.Band.Patch(live, 4, stale, &domain.BandPatch{SetLength: &setLength}), domain.ErrPreconditionFailed, "削除したバンドのバージョンでは更新できない")
+	assert.ErrorIs(t, r.Band.Delete(live, 4, stale), domain.ErrPreconditionFailed, "削除したバンドのバージョンでは削除できない")
+	require.Nil(t, r.Band.Delete(live, 4, recreated.Version))
+
+	member := createMember(t, r, "drummer", domain.Dr)
+	require.Nil(t, r.BandMember.Create(&domain.BandMember{LiveId: live, Turn: 1, MemberId: member, MemberPart: domain.Dr}))
+	assert.ErrorIs(t, r.Band.Delete(live, 1, band1.Version), domain.ErrForeignKeyViolation, "メンバーが登録されたバンドは削除できない")
+
+	require.Nil(t, r.BandMember.DeleteByLiveId(live))
+	assert.Nil(t, r.Band.DeleteByLiveId(live))
+	bands, err = r.Band.FindByLiveId(live)
+	assert.Nil(t, err)
+	assert.Empty(t, bands)
+}
+
+func testBandMember(t *testing.T, r *Repositories) {
+	first := createLive(t, r, "first", day(1))
+	second := createLive(t, r, "second", day(2))
+	for _, live := range []int{first, second} {
+		require.Nil(t, r.Band.Create(&domain.Band{Name: "band1", LiveId: live, Turn: 1}))
+		require.Nil(t, r.Band.Create(&domain.Band{Name: "band2", LiveId: live, Turn: 2}))
+	}
+	drummer := createMember(t, r, "drummer", domain.Dr, domain.Vo)
+	guitarist := createMember(t, r, "guitarist", domain.Gt)
+	require.Nil(t, r.BandMember.Create(&domain.BandMember{LiveId: first, Turn: 2, MemberId: drummer, MemberPart: domain.Vo}))
+	require.Nil(t, r.BandMember.Create(&domain.BandMember{LiveId: first, Turn: 1, MemberId: guitarist, MemberPart: domain.Gt}))
+	require.Nil(t, r.BandMember.Create(&domain.BandMember{LiveId: first, Turn: 1, MemberId: drummer, MemberPart: domain.Dr}))
+	require.Nil(t, r.BandMember.Create(&domain.BandMember{LiveId: second, Turn: 1, MemberId: drummer, MemberPart: domain.Dr}))
+
+	tests := []struct {
+		// テスト名
+		testName string
+		// 登録するバンドメンバー
+		bandMember *domain.BandMember
+		// 戻り値の期待値(error)
+		expectedError error
+	}{
+		{
+			testName:      "異常系_主キーの重複",
+			bandMember:    &domain.BandMember{LiveId: first, Turn: 1, MemberId: drummer, MemberPart: domain.Dr},
+			expectedError: domain.ErrConflict,
+		},
+		{
+			testName:      "異常系_存在しないバンド",
+			bandMember:    &domain.BandMember{LiveId: first, Turn: 3, MemberId: drummer, MemberPart: domain.Dr},
+			expectedError: domain.ErrForeignKeyViolation,
+		},
+		{
+			testName:      "異常系_存在しないメンバー",
+			bandMember:    &domain.BandMember{LiveId: first, Turn: 1, MemberId: missingId, MemberPart: domain.Dr},
+			expectedError: domain.ErrForeignKeyViolation,
+		},
+		{
+			testName:      "異常系_カタログにないパート",
+			bandMember:    &domain.BandMember{LiveId: first, Turn: 1, MemberId: drummer, MemberPart: domain.Part("Unknown.")},
+			expectedError: domain.ErrForeignKeyViolation,
+		},
+	}
+	for _, tc := range tests {
+		assert.ErrorIs(t, r.BandMember.Create(tc.bandMember), tc.expectedError, fmt.Sprintf("テスト名: %s", tc.testName))
+	}
+
+	players, err := r.BandMember.FindByLiveIdAndTurn(first, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, []*domain.Player{
+		{MemberId: drummer, Name: "drummer", Part: domain.Dr},
+		{MemberId: guitarist, Name: "guitarist", Part: domain.Gt},
+	}, players, "メンバーID順に返す")
+
+	bandMembers, err := r.BandMember.FindByLiveId(first)
+	assert.Nil(t, err)
+	assert.Equal(t, []*domain.BandMember{
+		{LiveId: first, Turn: 1, MemberId: drummer, MemberName: "drummer", MemberPart: domain.Dr},
+		{LiveId: first, Turn: 1, MemberId: guitarist, MemberName: "guitarist", MemberPart: domain.Gt},
+		{LiveId: first, Turn: 2, MemberId: drummer, MemberName: "drummer", MemberPart: domain.Vo},
+	}, bandMembers, "出演順、メンバーID の順に返す")
+
+	appearances, err := r.BandMember.FindAppearances(drummer, nil, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []*domain.Appearance{
+		{LiveId: first, LiveName: "first", Location: "location", Date: day(1), BandName: "band1", Turn: 1, Part: domain.Dr},
+		{LiveId: first, LiveName: "first", Location: "location", Date: day(1), BandName: "band2", Turn: 2, Part: domain.Vo},
+		{LiveId: second, LiveName: "second", Location: "location", Date: day(2), BandName: "band1", Turn: 1, Part: domain.Dr},
+	}, appearances, "日付、出演順の順に返す")
+	start := day(2)
+	appearances, err = r.BandMember.FindAppearances(drummer, &start, nil)
+	assert.Nil(t, err)
+	assert.Len(t, appearances, 1, "期間で絞り込む")
+
+	current := &domain.BandMember{LiveId: first, Turn: 2, MemberId: drummer, MemberPart: domain.Vo}
+	replacement := &domain.BandMember{LiveId: first, Turn: 2, MemberId: guitarist, MemberPart: domain.Gt}
+	assert.Nil(t, r.BandMember.Update(current, replacement))
+	players, err = r.BandMember.FindByLiveIdAndTurn(first, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []*domain.Player{{MemberId: guitarist, Name: "guitarist", Part: domain.Gt}}, players)
+
+	assert.Nil(t, r.BandMember.Update(replacement, replacement), "同じ値で置き換える")
+	assert.ErrorIs(t, r.BandMember.Update(current, replacement), domain.ErrNotFound, "置き換え済みのメンバーは置き換えられない")
+
+	assert.Nil(t, r.BandMember.Delete(replacement))
+	players, err = r.BandMember.FindByLiveIdAndTurn(first, 2)
+	assert.Nil(t, err)
+	assert.Empty(t, players)
+	assert.ErrorIs(t, r.BandMember.Delete(replacement), domain.ErrNotFound, "削除済みのメンバーは削除できない")
+
+	assert.Nil(t, r.BandMember.DeleteByLiveId(first))
+	bandMembers, err = r.BandMember.FindByLiveId(first)
+	assert.Nil(t, err)
+	assert.Empty(t, bandMembers)
+	bandMembers, err = r.BandMember.FindByLiveId(second)
+	assert.Nil(t, err)
+	assert.Len(t, bandMembers, 1, "他のライブのメンバーは削除しない")
+}
+
+func testPlayer(t *testing.T, r *Repositories) {
+	member, err := r.Player.FindById(missingId)
+	assert.Nil(t, err)
+	assert.Nil(t, member, "存在しないメンバーは nil")
+	member, err = r.Player.FindByName("nobody")
+	assert.Nil(t, err)
+	assert.Nil(t, member, "存在しないメンバーは nil")
+
+	drummer := createMember(t, r, "drummer", domain.Vo, domain.Dr)
+	guitarist := createMember(t, r, "guitarist", domain.Gt)
+	assert.NotEqual(t, drummer, guitarist)
+	assert.ErrorIs(t, r.Player.Create(&domain.Member{Name: "drummer"}), domain.ErrConflict, "名前の重複")
+
+	member, err = r.Player.FindByName("drummer")
+	assert.Nil(t, err)
+	assert.Equal(t, &domain.Member{Id: drummer, Name: "drummer", Part: []domain.Part{domain.Dr, domain.Vo}}, member, "パートはコード順に返す")
+
+	assert.ErrorIs(t, r.Player.Rename(guitarist, "drummer"), domain.ErrConflict, "他のメンバーの名前には変更できない")
+	assert.Nil(t, r.Player.Rename(guitarist, "renamed"))
+	member, err = r.Player.FindById(guitarist)
+	assert.Nil(t, err)
+	assert.Equal(t, "renamed", member.Name)
+
+	assert.Nil(t, r.Player.AddPart(guitarist, domain.Vo))
+	assert.ErrorIs(t, r.Player.AddPart(guitarist, domain.Vo), domain.ErrConflict, "パートの重複")
+	assert.ErrorIs(t, r.Player.AddPart(guitarist, domain.Part("Unknown.")), domain.ErrForeignKeyViolation, "カタログにないパート")
+	assert.ErrorIs(t, r.Player.AddPart(missingId, domain.Vo), domain.ErrForeignKeyViolation, "存在しないメンバー")
+	vo := domain.Vo
+	players, err := r.Player.FindByPart(&vo)
+	assert.Nil(t, err)
+	assert.Equal(t, []*domain.Player{
+		{MemberId: drummer, Name: "drummer", Part: domain.Vo},
+		{MemberId: guitarist, Name: "renamed", Part: domain.Vo},
+	}, players, "メンバーID順に返す")
+
+	assert.Nil(t, r.Player.RemovePart(guitarist, domain.Vo))
+	players, err = r.Player.FindByPart(&vo)
+	assert.Nil(t, err)
+	assert.Len(t, players, 1)
+}
+
+func testPayment(t *testing.T, r *Repositories) {
+	live := createLive(t, r, "live", day(1))
+	member := createMember(t, r, "drummer", domain.Dr)
+	later := &domain.Payment{LiveId: live, MemberId: member, Kind: domain.Paid, Method: domain.Cash, Amount: 1000, PaidAt: time.Date(2022, 1, 3, 19, 0, 0, 0, time.UTC)}
+	earlier := &domain.Payment{LiveId: live, MemberId: member, Kind: domain.Refund, Method: domain.Transfer, Amount: 500, PaidAt: time.Date(2022, 1, 3, 18, 0, 0, 0, time.UTC), Note: "note"}
+	require.Nil(t, r.Payment.Create(later))
+	require.Nil(t, r.Payment.Create(earlier))
+	assert.NotZero(t, later.Id)
+	assert.NotEqual(t, later.Id, earlier.Id)
+	assert.ErrorIs(t, r.Payment.Create(&domain.Payment{LiveId: live, MemberId: missingId, Kind: domain.Paid, Method: domain.Cash, PaidAt: day(1)}), domain.ErrForeignKeyViolation, "存在しないメンバー")
+	assert.ErrorIs(t, r.Payment.Create(&domain.Payment{LiveId: missingId, MemberId: member, Kind: domain.Paid, Method: domain.Cash, PaidAt: day(1)}), domain.ErrForeignKeyViolation, "存在しないライブ")
+
+	payments, err := r.Payment.FindByLiveId(live)
+	assert.Nil(t, err)
+	earlier.PlayerName, later.PlayerName = "drummer", "drummer"
+	assert.Equal(t, []*domain.Payment{earlier, later}, payments, "支払日時の順に返す")
+}
+
+func testLineupRule(t *testing.T, r *Repositories) {
+	live := createLive(t, r, "live", day(1))
+	rule, err := r.LineupRule.FindByLiveId(live)
+	assert.Nil(t, err)
+	assert.Nil(t, rule, "ルールがない場合は nil")
+
+	require.Nil(t, r.LineupRule.Save(&domain.LineupRule{LiveId: live, MinMembers: 2, GtVoCountsAsVo: true, Part: []*domain.PartRule{
+		{Part: domain.Vo, Min: 1},
+		{Part: domain.Dr, Min: 1, Max: 1},
+	}}))
+	rule, err = r.LineupRule.FindByLiveId(live)
+	assert.Nil(t, err)
+	assert.Equal(t, &domain.LineupRule{LiveId: live, MinMembers: 2, GtVoCountsAsVo: true, Part: []*domain.PartRule{
+		{Part: domain.Dr, Min: 1, Max: 1},
+		{Part: domain.Vo, Min: 1},
+	}}, rule, "パートはコード順に返す")
+
+	require.Nil(t, r.LineupRule.Save(&domain.LineupRule{LiveId: live, MaxMembers: 5, Part: []*domain.PartRule{{Part: domain.Gt, Min: 2}}}))
+	rule, err = r.LineupRule.FindByLiveId(live)
+	assert.Nil(t, err)
+	assert.Equal(t, &domain.LineupRule{LiveId: live, MaxMembers: 5, Part: []*domain.PartRule{{Part: domain.Gt, Min: 2}}}, rule, "保存し直すと置き換える")
+
+	assert.ErrorIs(t, r.LineupRule.Save(&domain.LineupRule{LiveId: missingId}), domain.ErrForeignKeyViolation, "存在しないライブ")
+}
+
+func testBandProfile(t *testing.T, r *Repositories) {
+	profile, err := r.BandProfile.FindById(missingId)
+	assert.Nil(t, err)
+	assert.Nil(t, profile, "存在しないプロフィールは nil")
+
+	first := &domain.BandProfile{Name: "first"}
+	second := &domain.BandProfile{Name: "second"}
+	require.Nil(t, r.BandProfile.Create(first))
+	require.Nil(t, r.BandProfile.Create(second))
+	assert.NotEqual(t, first.Id, second.Id)
+
+	drummer := createMember(t, r, "drummer", domain.Dr)
+	guitarist := createMember(t, r, "guitarist", domain.Gt)
+	require.Nil(t, r.BandProfile.SaveRoster(first.Id, []*domain.Player{
+		{MemberId: guitarist, Part: domain.Gt},
+		{MemberId: drummer, Part: domain.Dr},
+	}))
+	require.Nil(t, r.BandProfile.Rename(second.Id, "renamed"))
+	profiles, err := r.BandProfile.FindAll()
+	assert.Nil(t, err)
+	assert.Equal(t, []*domain.BandProfile{
+		{Id: first.Id, Name: "first", Player: []*domain.Player{
+			{MemberId: drummer, Name: "drummer", Part: domain.Dr},
+			{MemberId: guitarist, Name: "guitarist", Part: domain.Gt},
+		}},
+		{Id: second.Id, Name: "renamed"},
+	}, profiles, "ID 順、メンバーはメンバーID順に返す")
+
+	require.Nil(t, r.BandProfile.SaveRoster(first.Id, []*domain.Player{{MemberId: drummer, Part: domain.Dr}}))
+	profile, err = r.BandProfile.FindById(first.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, []*domain.Player{{MemberId: drummer, Name: "drummer", Part: domain.Dr}}, profile.Player, "保存し直すと置き換える")
+
+	assert.ErrorIs(t, r.BandProfile.SaveRoster(first.Id, []*domain.Player{{MemberId: missingId, Part: domain.Dr}}), domain.ErrForeignKeyViolation, "存在しないメンバー")
+	assert.ErrorIs(t, r.BandProfile.SaveRoster(missingId, []*domain.Player{{MemberId: drummer, Part: domain.Dr}}), domain.ErrForeignKeyViolation, "存在しないプロフィール")
+}
+
+func testPart(t *testing.T, r *Repositories) {
+	parts, err := r.Part.FindAll()
+	assert.Nil(t, err)
+	var codes []domain.Part
+	for _, part := range parts {
+		codes = append(codes, part.Code)
+	}
+	assert.Equal(t, []domain.Part{domain.Vo, domain.Gt, domain.GtVo, domain.Key, domain.Ba, domain.Dr}, codes, "表示順に返す")
+
+	sax := domain.Part("Sax.")
+	require.Nil(t, r.Part.Save(&domain.PartDefinition{Code: sax, DisplayName: "Sax", Category: domain.Melody, SortOrder: 10}))
+	require.Nil(t, r.Part.Save(&domain.PartDefinition{Code: sax, DisplayName: "Saxophone", Category: domain.Melody, SortOrder: 10}))
+	parts, err = r.Part.FindAll()
+	assert.Nil(t, err)
+	require.Len(t, parts, 7)
+	assert.Equal(t, &domain.PartDefinition{Code: sax, DisplayName: "Saxophone", Category: domain.Melody, SortOrder: 10}, parts[6], "登録済みのパートは置き換える")
+
+	used, err := r.Part.IsUsed(sax)
+	assert.Nil(t, err)
+	assert.False(t, used)
+	member := createMember(t, r, "saxophonist", sax)
+	used, err = r.Part.IsUsed(sax)
+	assert.Nil(t, err)
+	assert.True(t, used)
+	assert.ErrorIs(t, r.Part.Delete(sax), domain.ErrForeignKeyViolation, "使われているパートは削除できない")
+
+	require.Nil(t, r.Player.RemovePart(member, sax))
+	assert.Nil(t, r.Part.Delete(sax))
+	parts, err = r.Part.FindAll()
+	assert.Nil(t, err)
+	assert.Len(t, parts, 6)
+}
+
+func testEntryApplication(t *testing.T, r *Repositories) {
+	application, err := r.EntryApplication.FindById(missingId)
+	assert.Nil(t, err)
+	assert.Nil(t, application, "存在しない申込は nil")
+
+	live := createLive(t, r, "live", day(1))
+	other := createLive(t, r, "other", day(2))
+	drummer := createMember(t, r, "drummer", domain.Dr)
+	guitarist := createMember(t, r, "guitarist", domain.Gt, domain.GtVo)
+	profile := &domain.BandProfile{Name: "profile"}
+	require.Nil(t, r.BandProfile.Create(profile))
+	appliedAt := time.Date(2022, 1, 10, 21, 0, 0, 0, time.UTC)
+	first := &domain.EntryApplication{LiveId: live, Name: "first", BandId: profile.Id, PreferredTurn: 2, SetLength: 20, Note: "note",
+		Status: domain.ApplicationPending, AppliedAt: appliedAt, Player: []*domain.Player{
+			{MemberId: guitarist, Part: domain.GtVo},
+			{MemberId: drummer, Part: domain.Dr},
+			{MemberId: guitarist, Part: domain.Gt},
+		}}
+	second := &domain.EntryApplication{LiveId: live, Name: "second", Status: domain.ApplicationPending, AppliedAt: appliedAt}
+	require.Nil(t, r.EntryApplication.Create(first))
+	require.Nil(t, r.EntryApplication.Create(second))
+	require.Nil(t, r.EntryApplication.Create(&domain.EntryApplication{LiveId: other, Name: "other", Status: domain.ApplicationPending, AppliedAt: appliedAt}))
+	assert.NotEqual(t, first.Id, second.Id)
+
+	application, err = r.EntryApplication.FindById(first.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, &domain.EntryApplication{Id: first.Id, LiveId: live, Name: "first", BandId: profile.Id, PreferredTurn: 2, SetLength: 20, Note: "note",
+		Status: domain.ApplicationPending, AppliedAt: appliedAt, Player: []*domain.Player{
+			{MemberId: drummer, Name: "drummer", Part: domain.Dr},
+			{MemberId: guitarist, Name: "guitarist", Part: domain.Gt},
+			{MemberId: guitarist, Name: "guitarist", Part: domain.GtVo},
+		}}, application, "メンバーはメンバーID、パートの順に返す")
+
+	require.Nil(t, r.EntryApplication.UpdateStatus(first.Id, domain.ApplicationPending, domain.ApplicationApproved, 3))
+	applications, err := r.EntryApplication.FindByLiveId(live, nil)
+	assert.Nil(t, err)
+	require.Len(t, applications, 2, "他のライブの申込は返さない")
+	assert.Equal(t, []string{"first", "second"}, []string{applications[0].Name, applications[1].Name}, "ID 順に返す")
+	assert.Equal(t, domain.ApplicationApproved, applications[0].Status)
+	assert.Equal(t, 3, applications[0].Turn)
+	pending := domain.ApplicationPending
+	applications, err = r.EntryApplication.FindByLiveId(live, &pending)
+	assert.Nil(t, err)
+	require.Len(t, applications, 1, "状態を指定した場合はその状態の申込だけを返す")
+	assert.Equal(t, second.Id, applications[0].Id)
+
+	assert.ErrorIs(t, r.EntryApplication.UpdateStatus(first.Id, domain.ApplicationPending, domain.ApplicationRejected, 0), domain.ErrConflict, "現在の状態が異なる")
+	assert.ErrorIs(t, r.EntryApplication.UpdateStatus(missingId, domain.ApplicationPending, domain.ApplicationRejected, 0), domain.ErrNotFound)
+	assert.ErrorIs(t, r.EntryApplication.Create(&domain.EntryApplication{LiveId: missingId, Name: "band", Status: domain.ApplicationPending, AppliedAt: appliedAt}),
+		domain.ErrForeignKeyViolation, "存在しないライブ")
+	assert.ErrorIs(t, r.EntryApplication.Create(&domain.EntryApplication{LiveId: live, Name: "band", BandId: missingId, Status: domain.ApplicationPending, AppliedAt: appliedAt}),
+		domain.ErrForeignKeyViolation, "存在しないプロフィール")
+	err = r.UnitOfWork.Do(func(repositories *domain.Repositories) error {
+		return repositories.EntryApplication.Create(&domain.EntryApplication{LiveId: live, Name: "band", Status: domain.ApplicationPending, AppliedAt: appliedAt,
+			Player: []*domain.Player{{MemberId: missingId, Part: domain.Dr}}})
+	})
+	assert.ErrorIs(t, err, domain.ErrForeignKeyViolation, "存在しないメンバー")
+
+	require.Nil(t, r.Live.Delete(live, 1))
+	applications, err = r.EntryApplication.FindByLiveId(live, nil)
+	assert.Nil(t, err)
+	assert.Empty(t, applications, "ライブを削除すると申込も削除する")
+}
+
+func testUnitOfWork(t *testing.T, r *Repositories) {
+	live := createLive(t, r, "live", day(1))
+	expectedError := fmt.Errorf("dummy message")
+
+	err := r.UnitOfWork.Do(func(repositories *domain.Repositories) error {
+		if err := repositories.Band.Create(&domain.Band{Name: "band", LiveId: live, Turn: 1}); err != nil {
+			return err
+		}
+		bands, err := repositories.Band.FindByLiveId(live)
+		if err != nil {
+			return err
+		}
+		assert.Len(t, bands, 1, "トランザクションの中では書き込みが見える")
+		return expectedError
+	})
+	assert.Equal(t, expectedError, err)
+	bands, err := r.Band.FindByLiveId(live)
+	assert.Nil(t, err)
+	assert.Empty(t, bands, "エラーの場合はロールバックする")
+
+	err = r.UnitOfWork.Do(func(repositories *domain.Repositories) error {
+		return repositories.Band.Create(&domain.Band{Name: "band", LiveId: live, Turn: 1})
+	})
+	assert.Nil(t, err)
+	bands, err = r.Band.FindByLiveId(live)
+	assert.Nil(t, err)
+	assert.Len(t, bands, 1, "成功した場合はコミットする")
+}
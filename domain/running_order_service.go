@@ -0,0 +1,261 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// DefaultConsecutivePenalty 連続出演のペナルティの既定値。入れ替わりよりも優先して避ける
+	DefaultConsecutivePenalty = 100
+	// DefaultChangePenalty メンバーの入れ替わりのペナルティの既定値
+	DefaultChangePenalty = 1
+	// exhaustiveLimit このバンド数以下の場合は全ての並びを調べる
+	exhaustiveLimit = 8
+)
+
+// ErrInvalidRunningOrderOption 出演順の最適化の条件が不正な場合のエラー
+var ErrInvalidRunningOrderOption = newError(ErrValidation, "invalid running order option")
+
+// RunningOrderService 出演順を自動で提案し、採用した並びを反映する
+type RunningOrderService interface {
+	// Propose 出演順を提案する。登録内容は変更しない
+	Propose(id int, option *RunningOrderOption) (*RunningOrder, error)
+	// Apply 現在の出演順を turns の並びに振り直す
+	Apply(id int, turns []int) error
+}
+
+type RunningOrderServiceImpl struct {
+	liveDescService LiveDescService
+	bandService     BandService
+}
+
+func NewRunningOrderServiceImpl(liveDescService LiveDescService, bandService BandService) *RunningOrderServiceImpl {
+	return &RunningOrderServiceImpl{liveDescService: liveDescService, bandService: bandService}
+}
+
+func (r *RunningOrderServiceImpl) Propose(id int, option *RunningOrderOption) (*RunningOrder, error) {
+	liveModel, err := r.liveDescService.GetById(id)
+	if err != nil {
+		return nil, err
+	}
+	return ProposeRunningOrder(liveModel, option)
+}
+
+func (r *RunningOrderServiceImpl) Apply(id int, turns []int) error {
+	return r.bandService.Reorder(id, turns)
+}
+
+// ProposeRunningOrder ペナルティの合計が最小になる出演順を探す。
+// バンド数が少ない場合は全ての並びを調べ、多い場合は現在の並びを初期解として2つの入れ替えで改善する。
+// ペナルティが同じ場合は現在の並びに近いものを選ぶ
+func ProposeRunningOrder(liveModel *LiveModel, option *RunningOrderOption) (*RunningOrder, error) {
+	bands := append([]*BandModel{}, liveModel.Band...)
+	sort.Slice(bands, func(i, j int) bool { return bands[i].Turn < bands[j].Turn })
+	n := len(bands)
+
+	if option.Opener != 0 && option.Opener == option.Headliner && n > 1 {
+		return nil, fmt.Errorf("%w: opener and headliner must be different bands", ErrInvalidRunningOrderOption)
+	}
+	fixed := map[int]int{}
+	for _, pin := range []struct {
+		turn     int
+		position int
+	}{{option.Opener, 0}, {option.Headliner, n - 1}} {
+		if pin.turn == 0 {
+			continue
+		}
+		index := -1
+		for i, band := range bands {
+			if band.Turn == pin.turn {
+				index = i
+			}
+		}
+		if index < 0 {
+			return nil, fmt.Errorf("%w: turn %d", ErrBandNotFound, pin.turn)
+		}
+		fixed[pin.position] = index
+	}
+
+	cost := penaltyMatrix(bands, option)
+	order := initialOrder(n, fixed)
+	if n <= exhaustiveLimit {
+		order = searchExhaustive(order, fixed, cost)
+	} else {
+		order = searchLocal(order, fixed, cost)
+	}
+
+	runningOrder := &RunningOrder{LiveId: liveModel.Id}
+	for _, index := range order {
+		band := bands[index]
+		runningOrder.Turns = append(runningOrder.Turns, band.Turn)
+		runningOrder.Band = append(runningOrder.Band, &Band{Name: band.Name, LiveId: band.LiveId, Turn: band.Turn, SetLength: band.SetLength, BandId: band.BandId})
+	}
+	for i := 1; i < len(order); i++ {
+		runningOrder.Explanation = append(runningOrder.Explanation, explain(bands[order[i-1]], bands[order[i]], option)...)
+	}
+	for _, item := range runningOrder.Explanation {
+		runningOrder.Score += item.Penalty
+	}
+	return runningOrder, nil
+}
+
+// penaltyMatrix cost[i][j] はバンド i の直後にバンド j が出演する場合のペナルティ
+func penaltyMatrix(bands []*BandModel, option *RunningOrderOption) [][]int {
+	cost := make([][]int, len(bands))
+	for i := range bands {
+		cost[i] = make([]int, len(bands))
+		for j := range bands {
+			if i == j {
+				continue
+			}
+			for _, item := range explain(bands[i], bands[j], option) {
+				cost[i][j] += item.Penalty
+			}
+		}
+	}
+	return cost
+}
+
+// explain 2つのバンドが続けて出演する場合のペナルティの内訳
+func explain(previous *BandModel, next *BandModel, option *RunningOrderOption) []*ScoreItem {
+	previousNames := map[string]bool{}
+	for _, name := range memberNames(previous) {
+		previousNames[name] = true
+	}
+	nextNames := map[string]bool{}
+	for _, name := range memberNames(next) {
+		nextNames[name] = true
+	}
+
+	var shared []string
+	changes := 0
+	for name := range previousNames {
+		if nextNames[name] {
+			shared = append(shared, name)
+		} else {
+			changes++
+		}
+	}
+	for name := range nextNames {
+		if !previousNames[name] {
+			changes++
+		}
+	}
+	sort.Strings(shared)
+
+	var items []*ScoreItem
+	if len(shared) > 0 && option.ConsecutivePenalty > 0 {
+		items = append(items, &ScoreItem{
+			Turn:     previous.Turn,
+			NextTurn: next.Turn,
+			Reason:   fmt.Sprintf("%s play in consecutive turns", strings.Join(shared, ", ")),
+			Penalty:  len(shared) * option.ConsecutivePenalty,
+		})
+	}
+	if changes > 0 && option.ChangePenalty > 0 {
+		items = append(items, &ScoreItem{
+			Turn:     previous.Turn,
+			NextTurn: next.Turn,
+			Reason:   fmt.Sprintf("%d members change over", changes),
+			Penalty:  changes * option.ChangePenalty,
+		})
+	}
+	return items
+}
+
+// initialOrder 固定したバンドを指定の位置に置き、残りを現在の並びで詰めた並び
+func initialOrder(n int, fixed map[int]int) []int {
+	pinned := map[int]bool{}
+	for _, index := range fixed {
+		pinned[index] = true
+	}
+	var free []int
+	for i := 0; i < n; i++ {
+		if !pinned[i] {
+			free = append(free, i)
+		}
+	}
+	order := make([]int, n)
+	for position := 0; position < n; position++ {
+		if index, ok := fixed[position]; ok {
+			order[position] = index
+			continue
+		}
+		order[position] = free[0]
+		free = free[1:]
+	}
+	return order
+}
+
+func totalPenalty(order []int, cost [][]int) int {
+	total := 0
+	for i := 1; i < len(order); i++ {
+		total += cost[order[i-1]][order[i]]
+	}
+	return total
+}
+
+// searchExhaustive 固定していない位置のバンドの全ての並びを辞書順に調べる
+func searchExhaustive(order []int, fixed map[int]int, cost [][]int) []int {
+	var positions, indexes []int
+	for position, index := range order {
+		if _, ok := fixed[position]; !ok {
+			positions = append(positions, position)
+			indexes = append(indexes, index)
+		}
+	}
+	best := append([]int{}, order...)
+	bestPenalty := totalPenalty(best, cost)
+	current := append([]int{}, order...)
+	used := make([]bool, len(indexes))
+
+	var search func(k int)
+	search = func(k int) {
+		if k == len(positions) {
+			if penalty := totalPenalty(current, cost); penalty < bestPenalty {
+				bestPenalty = penalty
+				copy(best, current)
+			}
+			return
+		}
+		for i, index := range indexes {
+			if used[i] {
+				continue
+			}
+			used[i] = true
+			current[positions[k]] = index
+			search(k + 1)
+			used[i] = false
+		}
+	}
+	search(0)
+	return best
+}
+
+// searchLocal 固定していない2つの位置の入れ替えで改善できなくなるまで繰り返す
+func searchLocal(order []int, fixed map[int]int, cost [][]int) []int {
+	best := append([]int{}, order...)
+	bestPenalty := totalPenalty(best, cost)
+	for improved := true; improved; {
+		improved = false
+		for i := 0; i < len(best); i++ {
+			for j := i + 1; j < len(best); j++ {
+				_, fixedI := fixed[i]
+				_, fixedJ := fixed[j]
+				if fixedI || fixedJ {
+					continue
+				}
+				best[i], best[j] = best[j], best[i]
+				if penalty := totalPenalty(best, cost); penalty < bestPenalty {
+					bestPenalty = penalty
+					improved = true
+				} else {
+					best[i], best[j] = best[j], best[i]
+				}
+			}
+		}
+	}
+	return best
+}
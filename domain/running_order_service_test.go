@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestProposeRunningOrder(t *testing.T) {
+	// given
+	// band1 と band2 は drummer が、band3 と band4 は bassist が掛け持ちしている
+	liveModel := LiveModel{
+		Id: 1,
+		Band: []*BandModel{
+			{Name: "band1", LiveId: 1, Turn: 1, Player: []*Player{{Name: "drummer", Part: Dr}, {Name: "vocal1", Part: Vo}}},
+			{Name: "band2", LiveId: 1, Turn: 2, Player: []*Player{{Name: "drummer", Part: Dr}, {Name: "vocal2", Part: Vo}}},
+			{Name: "band3", LiveId: 1, Turn: 3, Player: []*Player{{Name: "bassist", Part: Ba}, {Name: "vocal3", Part: Vo}}},
+			{Name: "band4", LiveId: 1, Turn: 4, Player: []*Player{{Name: "bassist", Part: Ba}, {Name: "vocal4", Part: Vo}}},
+		},
+	}
+
+	tests := []struct {
+		// テスト名
+		testName string
+		// 最適化の条件
+		option RunningOrderOption
+		// 戻り値の期待値(出演順)
+		expectedTurns []int
+		// 戻り値の期待値(ペナルティの合計)
+		expectedScore int
+		// 戻り値の期待値(error)
+		expectedError error
+	}{
+		{
+			testName:      "正常系_連続出演を避ける",
+			option:        RunningOrderOption{ConsecutivePenalty: DefaultConsecutivePenalty, ChangePenalty: DefaultChangePenalty},
+			expectedTurns: []int{1, 3, 2, 4},
+			expectedScore: 12,
+		},
+		{
+			testName:      "正常系_トップバッターとトリを固定",
+			option:        RunningOrderOption{Opener: 2, Headliner: 1, ConsecutivePenalty: DefaultConsecutivePenalty, ChangePenalty: DefaultChangePenalty},
+			expectedTurns: []int{2, 3, 4, 1},
+			expectedScore: 110,
+		},
+		{
+			testName:      "正常系_ペナルティなしの場合は現在の並び",
+			option:        RunningOrderOption{},
+			expectedTurns: []int{1, 2, 3, 4},
+			expectedScore: 0,
+		},
+		{
+			testName:      "異常系_存在しない出演順を固定",
+			option:        RunningOrderOption{Opener: 9},
+			expectedError: ErrBandNotFound,
+		},
+		{
+			testName:      "異常系_トップバッターとトリが同じバンド",
+			option:        RunningOrderOption{Opener: 1, Headliner: 1},
+			expectedError: ErrInvalidRunningOrderOption,
+		},
+	}
+
+	for _, tc := range tests {
+		// when
+		runningOrder, err := ProposeRunningOrder(&liveModel, &tc.option)
+
+		// then
+		assert.True(t, errors.Is(err, tc.expectedError), fmt.Sprintf("テスト名: %s", tc.testName))
+		if tc.expectedError != nil {
+			continue
+		}
+		assert.Equal(t, tc.expectedTurns, runningOrder.Turns, fmt.Sprintf("テスト名: %s", tc.testName))
+		assert.Equal(t, tc.expectedScore, runningOrder.Score, fmt.Sprintf("テスト名: %s", tc.testName))
+	}
+}
+
+func TestProposeRunningOrderLocalSearch(t *testing.T) {
+	// given
+	// 全ての並びを調べない数のバンドで、隣り合うバンドが全てメンバーを掛け持ちしている
+	liveModel := LiveModel{Id: 1}
+	for turn := 1; turn <= exhaustiveLimit+2; turn++ {
+		liveModel.Band = append(liveModel.Band, &BandModel{Name: fmt.Sprintf("band%d", turn), LiveId: 1, Turn: turn, Player: []*Player{
+			{Name: fmt.Sprintf("player%d", turn), Part: Gt},
+			{Name: fmt.Sprintf("player%d", turn+1), Part: Ba},
+		}})
+	}
+	option := RunningOrderOption{Opener: 1, ConsecutivePenalty: DefaultConsecutivePenalty}
+
+	// when
+	runningOrder, err := ProposeRunningOrder(&liveModel, &option)
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, 1, runningOrder.Turns[0])
+	assert.Len(t, runningOrder.Turns, exhaustiveLimit+2)
+	assert.Equal(t, 0, runningOrder.Score)
+}
+
+func TestRunningOrderApply(t *testing.T) {
+	// given
+	bandRepository := new(BandRepositoryMock)
+	bandRepository.On("FindByLiveId", 1).Return([]*Band{{Name: "band1", LiveId: 1, Turn: 1}, {Name: "band2", LiveId: 1, Turn: 2}}, nil)
+	bandRepository.On("Delete", 1, 1, 0).Return(nil)
+	bandRepository.On("Delete", 1, 2, 0).Return(nil)
+	bandRepository.On("Create", &Band{Name: "band1", LiveId: 1, Turn: 2}).Return(nil)
+	bandRepository.On("Create", &Band{Name: "band2", LiveId: 1, Turn: 1}).Return(nil)
+	bandMemberRepository := new(BandMemberRepositoryMock)
+	bandMemberRepository.On("FindByLiveId", 1).Return([]*BandMember{}, nil)
+	unitOfWork := &UnitOfWorkMock{repositories: &Repositories{Band: bandRepository, BandMember: bandMemberRepository}}
+	runningOrderService := NewRunningOrderServiceImpl(nil, NewBandServiceImpl(bandRepository, unitOfWork, nil))
+
+	// when
+	err := runningOrderService.Apply(1, []int{2, 1})
+
+	// then
+	assert.Nil(t, err)
+	bandRepository.AssertNumberOfCalls(t, "Create", 2)
+}
@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"fmt"
+)
+
+// ErrInvalidFeeRule 出演料の数え方が不正な場合のエラー
+var ErrInvalidFeeRule = newError(ErrValidation, "invalid fee rule")
+
+// SettlementService ライブの出演料と機材費を精算する
+type SettlementService interface {
+	// Calculate rule が空の場合はサービスに設定された数え方で精算する
+	Calculate(id int, rule FeeRule) (*Settlement, error)
+}
+
+type SettlementServiceImpl struct {
+	liveDescService LiveDescService
+	defaultRule     FeeRule
+}
+
+func NewSettlementServiceImpl(liveDescService LiveDescService, defaultRule FeeRule) *SettlementServiceImpl {
+	return &SettlementServiceImpl{liveDescService: liveDescService, defaultRule: defaultRule}
+}
+
+func (s *SettlementServiceImpl) Calculate(id int, rule FeeRule) (*Settlement, error) {
+	if rule == "" {
+		rule = s.defaultRule
+	}
+	if !rule.IsValid() {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidFeeRule, rule)
+	}
+	liveModel, err := s.liveDescService.GetById(id)
+	if err != nil {
+		return nil, err
+	}
+	return Settle(liveModel, rule), nil
+}
+
+// Settle ライブの出演者から精算結果を計算する。
+// 機材費はバンドのメンバーで均等に割り、割り切れない分は先に登録されたメンバーから1円ずつ負担する
+func Settle(liveModel *LiveModel, rule FeeRule) *Settlement {
+	settlement := &Settlement{LiveId: liveModel.Id, Rule: rule}
+	players := map[string]*PlayerSettlement{}
+	var names []string
+
+	for _, band := range liveModel.Band {
+		members := memberNames(band)
+		bandSettlement := &BandSettlement{Name: band.Name, Turn: band.Turn, EquipmentCost: liveModel.EquipmentCost}
+		if len(members) == 0 {
+			bandSettlement.Unassigned = liveModel.EquipmentCost
+		}
+		for i, name := range members {
+			amount := liveModel.EquipmentCost / len(members)
+			if i < liveModel.EquipmentCost%len(members) {
+				amount++
+			}
+			bandSettlement.Share = append(bandSettlement.Share, &EquipmentShare{Name: name, Amount: amount})
+
+			player, ok := players[name]
+			if !ok {
+				player = &PlayerSettlement{Name: name}
+				players[name] = player
+				names = append(names, name)
+			}
+			player.Appearances++
+			player.EquipmentShare += amount
+		}
+		settlement.Band = append(settlement.Band, bandSettlement)
+	}
+
+	for _, name := range names {
+		player := players[name]
+		player.PerformanceFee = liveModel.PerformanceFee
+		if rule == ChargePerAppearance {
+			player.PerformanceFee = liveModel.PerformanceFee * player.Appearances
+		}
+		player.Total = player.PerformanceFee + player.EquipmentShare
+		settlement.Player = append(settlement.Player, player)
+		settlement.Total += player.Total
+	}
+	return settlement
+}
+
+// memberNames バンドのメンバー名を重複なく登録順に返す(1人で複数パートを担当する場合も1人と数える)
+func memberNames(band *BandModel) []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, player := range band.Player {
+		if seen[player.Name] {
+			continue
+		}
+		seen[player.Name] = true
+		names = append(names, player.Name)
+	}
+	return names
+}
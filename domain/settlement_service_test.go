@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+type LiveDescServiceMock struct {
+	mock.Mock
+	LiveDescService
+}
+
+func (m *LiveDescServiceMock) GetById(id int) (*LiveModel, error) {
+	args := m.Called(id)
+	return args.Get(0).(*LiveModel), args.Error(1)
+}
+
+func TestSettle(t *testing.T) {
+	// given
+	liveModel := LiveModel{
+		Id:             1,
+		PerformanceFee: 2000,
+		EquipmentCost:  1000,
+		Band: []*BandModel{
+			{Name: "band1", LiveId: 1, Turn: 1, Player: []*Player{
+				{Name: "drummer", Part: Dr}, {Name: "singer", Part: Gt}, {Name: "singer", Part: Vo}, {Name: "bassist", Part: Ba},
+			}},
+			{Name: "band2", LiveId: 1, Turn: 2, Player: []*Player{
+				{Name: "drummer", Part: Dr}, {Name: "guitarist", Part: Gt},
+			}},
+			{Name: "band3", LiveId: 1, Turn: 3},
+		},
+	}
+	expectedBands := []*BandSettlement{
+		{Name: "band1", Turn: 1, EquipmentCost: 1000, Share: []*EquipmentShare{
+			{Name: "drummer", Amount: 334}, {Name: "singer", Amount: 333}, {Name: "bassist", Amount: 333},
+		}},
+		{Name: "band2", Turn: 2, EquipmentCost: 1000, Share: []*EquipmentShare{
+			{Name: "drummer", Amount: 500}, {Name: "guitarist", Amount: 500},
+		}},
+		{Name: "band3", Turn: 3, EquipmentCost: 1000, Unassigned: 1000},
+	}
+
+	tests := []struct {
+		// テスト名
+		testName string
+		// 出演料の数え方
+		rule FeeRule
+		// 戻り値の期待値(メンバーごとの支払額)
+		expectedPlayers []*PlayerSettlement
+		// 戻り値の期待値(支払額の合計)
+		expectedTotal int
+	}{
+		{
+			testName: "正常系_出演料は1回分",
+			rule:     ChargeOnce,
+			expectedPlayers: []*PlayerSettlement{
+				{Name: "drummer", Appearances: 2, PerformanceFee: 2000, EquipmentShare: 834, Total: 2834},
+				{Name: "singer", Appearances: 1, PerformanceFee: 2000, EquipmentShare: 333, Total: 2333},
+				{Name: "bassist", Appearances: 1, PerformanceFee: 2000, EquipmentShare: 333, Total: 2333},
+				{Name: "guitarist", Appearances: 1, PerformanceFee: 2000, EquipmentShare: 500, Total: 2500},
+			},
+			expectedTotal: 10000,
+		},
+		{
+			testName: "正常系_出演バンドごとに出演料",
+			rule:     ChargePerAppearance,
+			expectedPlayers: []*PlayerSettlement{
+				{Name: "drummer", Appearances: 2, PerformanceFee: 4000, EquipmentShare: 834, Total: 4834},
+				{Name: "singer", Appearances: 1, PerformanceFee: 2000, EquipmentShare: 333, Total: 2333},
+				{Name: "bassist", Appearances: 1, PerformanceFee: 2000, EquipmentShare: 333, Total: 2333},
+				{Name: "guitarist", Appearances: 1, PerformanceFee: 2000, EquipmentShare: 500, Total: 2500},
+			},
+			expectedTotal: 12000,
+		},
+	}
+
+	for _, tc := range tests {
+		// when
+		actual := Settle(&liveModel, tc.rule)
+
+		// then
+		assert.Equal(t, expectedBands, actual.Band, fmt.Sprintf("テスト名: %s", tc.testName))
+		assert.Equal(t, tc.expectedPlayers, actual.Player, fmt.Sprintf("テスト名: %s", tc.testName))
+		assert.Equal(t, tc.expectedTotal, actual.Total, fmt.Sprintf("テスト名: %s", tc.testName))
+	}
+}
+
+func TestCalculate(t *testing.T) {
+	// given
+	liveModel := LiveModel{Id: 1, PerformanceFee: 2000, EquipmentCost: 1000}
+
+	tests := []struct {
+		testName      string
+		rule          FeeRule
+		expectedRule  FeeRule
+		expectedError error
+	}{
+		{testName: "正常系_デフォルトの数え方", rule: "", expectedRule: ChargePerAppearance},
+		{testName: "正常系_数え方を指定", rule: ChargeOnce, expectedRule: ChargeOnce},
+		{testName: "異常系_不正な数え方", rule: FeeRule("twice"), expectedError: ErrInvalidFeeRule},
+	}
+
+	for _, tc := range tests {
+		liveDescService := new(LiveDescServiceMock)
+		liveDescService.On("GetById", 1).Return(&liveModel, nil)
+		settlementService := NewSettlementServiceImpl(liveDescService, ChargePerAppearance)
+
+		// when
+		actual, err := settlementService.Calculate(1, tc.rule)
+
+		// then
+		if tc.expectedError != nil {
+			assert.True(t, errors.Is(err, tc.expectedError), fmt.Sprintf("テスト名: %s", tc.testName))
+			continue
+		}
+		assert.Nil(t, err, fmt.Sprintf("テスト名: %s", tc.testName))
+		assert.Equal(t, tc.expectedRule, actual.Rule, fmt.Sprintf("テスト名: %s", tc.testName))
+	}
+}
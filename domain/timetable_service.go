@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+)
+
+var (
+	// ErrTimetableIncomplete 開演時刻や持ち時間が未設定でタイムテーブルを組めない場合のエラー
+	ErrTimetableIncomplete = newError(ErrValidation, "timetable is incomplete")
+	// ErrTimetableOverrun タイムテーブルが終演時刻を超える場合のエラー
+	ErrTimetableOverrun = newError(ErrValidation, "timetable overruns the closing time")
+)
+
+// TimetableService 開演時刻と持ち時間、転換時間からバンドごとの出演時刻を計算する
+type TimetableService interface {
+	GetByLiveId(id int) (*Timetable, error)
+	// Check bands を出演バンドとした場合に終演時刻を超えないか確認する。
+	// 開演時刻・終演時刻のいずれかが未設定の場合は確認しない。持ち時間が未設定のバンドは除いて確認する
+	Check(id int, bands []*Band) error
+	// Verify 取得済みの live と bands で Check と同じ確認をする。
+	// トランザクション内で読み直したライブを確認する場合に使う
+	Verify(live *Live, bands []*Band) error
+}
+
+type TimetableServiceImpl struct {
+	liveRepository    LiveRepository
+	bandRepository    BandRepository
+	defaultChangeover int
+}
+
+func NewTimetableServiceImpl(liveRepository LiveRepository, bandRepository BandRepository, defaultChangeover int) *TimetableServiceImpl {
+	return &TimetableServiceImpl{
+		liveRepository:    liveRepository,
+		bandRepository:    bandRepository,
+		defaultChangeover: defaultChangeover,
+	}
+}
+
+func (t *TimetableServiceImpl) GetByLiveId(id int) (*Timetable, error) {
+	live, err := t.liveRepository.FindById(id)
+	if err != nil {
+		return nil, err
+	}
+	bands, err := t.bandRepository.FindByLiveId(id)
+	if err != nil {
+		return nil, err
+	}
+	if live.StartTime == 0 {
+		return nil, fmt.Errorf("%w: start time of live %d is not set", ErrTimetableIncomplete, id)
+	}
+	for _, band := range bands {
+		if band.SetLength <= 0 {
+			return nil, fmt.Errorf("%w: set length of turn %d is not set", ErrTimetableIncomplete, band.Turn)
+		}
+	}
+	timetable := BuildTimetable(live, bands, t.defaultChangeover)
+	if err := verifyCloseTime(timetable); err != nil {
+		return nil, err
+	}
+	return timetable, nil
+}
+
+func (t *TimetableServiceImpl) Check(id int, bands []*Band) error {
+	live, err := t.liveRepository.FindById(id)
+	if err != nil {
+		return err
+	}
+	return t.Verify(live, bands)
+}
+
+func (t *TimetableServiceImpl) Verify(live *Live, bands []*Band) error {
+	if live.StartTime == 0 || live.CloseTime == 0 {
+		return nil
+	}
+	// 持ち時間が未設定のバンドだけを除き、残りのバンドで終演時刻を超えないか確認する
+	var scheduled []*Band
+	for _, band := range bands {
+		if band.SetLength > 0 {
+			scheduled = append(scheduled, band)
+		}
+	}
+	return verifyCloseTime(BuildTimetable(live, scheduled, t.defaultChangeover))
+}
+
+// BuildTimetable 出演順に、開演時刻から持ち時間と転換時間を積み上げて出演時刻を決める。
+// 転換時間はバンドとバンドの間にのみ入る
+func BuildTimetable(live *Live, bands []*Band, defaultChangeover int) *Timetable {
+	changeover := live.Changeover
+	if changeover == 0 {
+		changeover = defaultChangeover
+	}
+	sorted := append([]*Band{}, bands...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Turn < sorted[j].Turn })
+
+	timetable := &Timetable{
+		LiveId:     live.Id,
+		OpenTime:   live.OpenTime,
+		StartTime:  live.StartTime,
+		CloseTime:  live.CloseTime,
+		Changeover: changeover,
+		EndTime:    live.StartTime,
+	}
+	start := live.StartTime
+	for i, band := range sorted {
+		if i > 0 {
+			start = start.Add(changeover)
+		}
+		end := start.Add(band.SetLength)
+		timetable.Slot = append(timetable.Slot, &TimetableSlot{Name: band.Name, Turn: band.Turn, Start: start, End: end})
+		timetable.EndTime = end
+		start = end
+	}
+	return timetable
+}
+
+func verifyCloseTime(timetable *Timetable) error {
+	if timetable.CloseTime != 0 && timetable.EndTime > timetable.CloseTime {
+		return fmt.Errorf("%w: ends at %s but closes at %s (%d minutes over)",
+			ErrTimetableOverrun, timetable.EndTime, timetable.CloseTime, int(timetable.EndTime-timetable.CloseTime))
+	}
+	return nil
+}
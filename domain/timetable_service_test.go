@@ -0,0 +1,174 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+type TimetableServiceMock struct {
+	mock.Mock
+	TimetableService
+}
+
+func (m *TimetableServiceMock) Check(id int, bands []*Band) error {
+	args := m.Called(id, bands)
+	return args.Error(0)
+}
+
+func (m *TimetableServiceMock) Verify(live *Live, bands []*Band) error {
+	args := m.Called(live, bands)
+	return args.Error(0)
+}
+
+func TestTimetableGetByLiveId(t *testing.T) {
+	// given
+	bands := []*Band{
+		{Name: "band2", LiveId: 1, Turn: 2, SetLength: 30},
+		{Name: "band1", LiveId: 1, Turn: 1, SetLength: 25},
+	}
+
+	tests := []struct {
+		// テスト名
+		testName string
+		// ライブ
+		live Live
+		// 出演バンド
+		bands []*Band
+		// 戻り値の期待値(Timetable)
+		expectedTimetable *Timetable
+		// 戻り値の期待値(error)
+		expectedError error
+	}{
+		{
+			testName: "正常系_ライブの転換時間を使う",
+			live:     Live{Id: 1, OpenTime: 17*60 + 30, StartTime: 18 * 60, CloseTime: 21 * 60, Changeover: 15},
+			bands:    bands,
+			expectedTimetable: &Timetable{
+				LiveId: 1, OpenTime: 17*60 + 30, StartTime: 18 * 60, CloseTime: 21 * 60, Changeover: 15,
+				Slot: []*TimetableSlot{
+					{Name: "band1", Turn: 1, Start: 18 * 60, End: 18*60 + 25},
+					{Name: "band2", Turn: 2, Start: 18*60 + 40, End: 19*60 + 10},
+				},
+				EndTime: 19*60 + 10,
+			},
+		},
+		{
+			testName: "正常系_既定の転換時間を使う",
+			live:     Live{Id: 1, StartTime: 18 * 60},
+			bands:    bands,
+			expectedTimetable: &Timetable{
+				LiveId: 1, StartTime: 18 * 60, Changeover: 10,
+				Slot: []*TimetableSlot{
+					{Name: "band1", Turn: 1, Start: 18 * 60, End: 18*60 + 25},
+					{Name: "band2", Turn: 2, Start: 18*60 + 35, End: 19*60 + 5},
+				},
+				EndTime: 19*60 + 5,
+			},
+		},
+		{
+			testName:      "異常系_終演時刻を超える",
+			live:          Live{Id: 1, StartTime: 18 * 60, CloseTime: 19 * 60},
+			bands:         bands,
+			expectedError: ErrTimetableOverrun,
+		},
+		{
+			testName:      "異常系_開演時刻が未設定",
+			live:          Live{Id: 1},
+			bands:         bands,
+			expectedError: ErrTimetableIncomplete,
+		},
+		{
+			testName:      "異常系_持ち時間が未設定",
+			live:          Live{Id: 1, StartTime: 18 * 60},
+			bands:         []*Band{{Name: "band1", LiveId: 1, Turn: 1}},
+			expectedError: ErrTimetableIncomplete,
+		},
+	}
+
+	for _, tc := range tests {
+		liveRepository := new(LiveRepositoryMock)
+		liveRepository.On("FindById", 1).Return(&tc.live, nil)
+		bandRepository := new(BandRepositoryMock)
+		bandRepository.On("FindByLiveId", 1).Return(tc.bands, nil)
+		timetableService := NewTimetableServiceImpl(liveRepository, bandRepository, 10)
+
+		// when
+		actual, err := timetableService.GetByLiveId(1)
+
+		// then
+		assert.True(t, errors.Is(err, tc.expectedError), fmt.Sprintf("テスト名: %s", tc.testName))
+		assert.Equal(t, tc.expectedTimetable, actual, fmt.Sprintf("テスト名: %s", tc.testName))
+	}
+}
+
+func TestTimetableCheck(t *testing.T) {
+	// given
+	live := Live{Id: 1, StartTime: 18 * 60, CloseTime: 19 * 60}
+
+	tests := []struct {
+		testName      string
+		bands         []*Band
+		expectedError error
+	}{
+		{
+			testName:      "正常系_終演時刻ちょうど",
+			bands:         []*Band{{Turn: 1, SetLength: 25}, {Turn: 2, SetLength: 25}},
+			expectedError: nil,
+		},
+		{
+			testName:      "正常系_持ち時間が未設定のバンドは除いて確認する",
+			bands:         []*Band{{Turn: 1, SetLength: 25}, {Turn: 2}, {Turn: 3, SetLength: 25}},
+			expectedError: nil,
+		},
+		{
+			testName:      "異常系_持ち時間が未設定のバンドを除いても終演時刻を超える",
+			bands:         []*Band{{Turn: 1, SetLength: 90}, {Turn: 2}},
+			expectedError: ErrTimetableOverrun,
+		},
+		{
+			testName:      "異常系_終演時刻を超える",
+			bands:         []*Band{{Turn: 1, SetLength: 25}, {Turn: 2, SetLength: 26}},
+			expectedError: ErrTimetableOverrun,
+		},
+	}
+
+	for _, tc := range tests {
+		liveRepository := new(LiveRepositoryMock)
+		liveRepository.On("FindById", 1).Return(&live, nil)
+		timetableService := NewTimetableServiceImpl(liveRepository, nil, 10)
+
+		// when
+		err := timetableService.Check(1, tc.bands)
+
+		// then
+		assert.True(t, errors.Is(err, tc.expectedError), fmt.Sprintf("テスト名: %s", tc.testName))
+	}
+}
+
+func TestParseClock(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Clock
+		isError  bool
+	}{
+		{input: "18:30", expected: 18*60 + 30},
+		{input: "18:30:00", expected: 18*60 + 30},
+		{input: "24:15", expected: 24*60 + 15},
+		{input: "", expected: 0},
+		{input: "18", isError: true},
+		{input: "18:75", isError: true},
+	}
+
+	for _, tc := range tests {
+		actual, err := ParseClock(tc.input)
+		if tc.isError {
+			assert.NotNil(t, err, tc.input)
+			continue
+		}
+		assert.Nil(t, err, tc.input)
+		assert.Equal(t, tc.expected, actual, tc.input)
+	}
+}
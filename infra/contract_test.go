@@ -0,0 +1,138 @@
+package infra
+
+import (
+	"database/sql"
+	"errors"
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"live-scheduler/domain"
+	"live-scheduler/domain/repositorytest"
+	"os"
+	"testing"
+)
+
+// TestRepositoryContract TEST_MYSQL_DSN(例: root:pass@tcp(localhost:3306)/test?parseTime=true)のデータベースで実行する。
+// テーブルの行はテストごとに削除するため、アプリケーションのデータベースは指定しないこと
+// 未指定の場合はスキップする。CI では .github/workflows/test.yml で MySQL を起動して指定する
+func TestRepositoryContract(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+	migrations, err := LoadMigrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewMigrator(db, migrations).Up(); err != nil {
+		t.Fatal(err)
+	}
+
+	repositorytest.Run(t, func(t *testing.T) *repositorytest.Repositories {
+		deleteAll(t, db)
+		return &repositorytest.Repositories{
+			Live:             NewLiveRepositoryImpl(db),
+			LiveDesc:         NewLiveDescRepositoryImpl(db),
+			Band:             NewBandRepositoryImpl(db),
+			BandMember:       NewBandMemberRepositoryImpl(db),
+			Player:           NewPlayerRepositoryImpl(db),
+			Payment:          NewPaymentRepositoryImpl(db),
+			LineupRule:       NewLineupRuleRepositoryImpl(db),
+			BandProfile:      NewBandProfileRepositoryImpl(db),
+			Part:             NewPartRepositoryImpl(db),
+			EntryApplication: NewEntryApplicationRepositoryImpl(db),
+			UnitOfWork:       NewUnitOfWorkImpl(db),
+		}
+	})
+}
+
+// deleteAll 参照する側のテーブルから削除し、PartCatalog は初期状態のパートだけを残す
+func deleteAll(t *testing.T, db *sql.DB) {
+	for _, statement := range []string{
+		`DELETE FROM Payment`,
+		`DELETE FROM LineupPartRule`,
+		`DELETE FROM LineupRule`,
+		`DELETE FROM EntryApplicationMember`,
+		`DELETE FROM EntryApplication`,
+		`DELETE FROM BandMember`,
+		`DELETE FROM Band`,
+		`DELETE FROM BandProfileMember`,
+		`DELETE FROM BandProfile`,
+		`DELETE FROM MemberPart`,
+		`DELETE FROM Member`,
+		`DELETE FROM Live`,
+		`DELETE FROM PartCatalog WHERE code NOT IN ('Vo.', 'Gt.', 'Gt.Vo.', 'Key.', 'Ba.', 'Dr.')`,
+	} {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// TestMigrateBaselineData マイグレーション導入前のスキーマのデータを、最新のスキーマへ移せるか確認する
+func TestMigrateBaselineData(t *testing.T) {
+	// given
+	db := openTestDB(t)
+	defer db.Close()
+	migrations, err := LoadMigrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	migrator := NewMigrator(db, migrations)
+	// 他のテストが残したデータは初期状態のスキーマに戻せない場合があるため、最新のスキーマにしてから削除する
+	if _, err := migrator.Up(); err != nil {
+		t.Fatal(err)
+	}
+	deleteAll(t, db)
+	for {
+		if _, err := migrator.Down(); errors.Is(err, ErrNoMigration) {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := NewMigrator(db, migrations[:1]).Up(); err != nil {
+		t.Fatal(err)
+	}
+	for _, statement := range []string{
+		`INSERT INTO Live(name, location, date, performance_fee, equipment_cost) VALUES ('live', 'location', '2022-01-03', 5500, 2000)`,
+		`INSERT INTO Band VALUES ('band', LAST_INSERT_ID(), 1)`,
+		`INSERT INTO Player VALUES ('drummer', 'Dr.'), ('guitarist', 'Gt.'), ('guitarist', 'Vo.')`,
+		`INSERT INTO BandMember SELECT id, 1, 'drummer', 'Dr.' FROM Live`,
+		`INSERT INTO BandMember SELECT id, 1, 'guitarist', 'Vo.' FROM Live`,
+	} {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// when
+	_, err = migrator.Up()
+
+	// then
+	assert.Nil(t, err)
+	var liveId int
+	assert.Nil(t, db.QueryRow(`SELECT id FROM Live`).Scan(&liveId))
+	players, err := NewBandMemberRepositoryImpl(db).FindByLiveIdAndTurn(liveId, 1)
+	assert.Nil(t, err)
+	assert.Len(t, players, 2)
+	guitarist, err := NewPlayerRepositoryImpl(db).FindByName("guitarist")
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []domain.Part{domain.Gt, domain.Vo}, guitarist.Part)
+	// 出演したときのパートを出演履歴として残す
+	appearances, err := NewBandMemberRepositoryImpl(db).FindAppearances(guitarist.Id, nil, nil)
+	assert.Nil(t, err)
+	if assert.Len(t, appearances, 1) {
+		assert.Equal(t, domain.Vo, appearances[0].Part)
+	}
+}
+
+// openTestDB TEST_MYSQL_DSN のデータベースに接続する。未指定の場合はテストをスキップする
+func openTestDB(t *testing.T) *sql.DB {
+	dataSourceName := os.Getenv("TEST_MYSQL_DSN")
+	if dataSourceName == "" {
+		t.Skip("TEST_MYSQL_DSN is not set")
+	}
+	db, err := sql.Open("mysql", dataSourceName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
@@ -0,0 +1,33 @@
+package infra
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-sql-driver/mysql"
+	"live-scheduler/domain"
+)
+
+// MySQL のエラー番号
+const (
+	// errDupEntry 主キーまたは一意キーの重複
+	errDupEntry = 1062
+	// errRowIsReferenced 参照されている行の削除・変更
+	errRowIsReferenced = 1451
+	// errNoReferencedRow 参照先の行が存在しない
+	errNoReferencedRow = 1452
+)
+
+// translateError ドライバのエラーを domain のエラーの分類に変換する。分類できないエラーはそのまま返す
+func translateError(err error) error {
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		return err
+	}
+	switch mysqlErr.Number {
+	case errDupEntry:
+		return fmt.Errorf("%w: %s", domain.ErrConflict, mysqlErr.Message)
+	case errRowIsReferenced, errNoReferencedRow:
+		return fmt.Errorf("%w: %s", domain.ErrForeignKeyViolation, mysqlErr.Message)
+	}
+	return err
+}
@@ -0,0 +1,62 @@
+package infra
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"live-scheduler/domain"
+	"testing"
+)
+
+func TestTranslateError(t *testing.T) {
+	// given
+	otherError := fmt.Errorf("dummy message")
+	tests := []struct {
+		// テスト名
+		testName string
+		// ドライバのエラー
+		err error
+		// 分類の期待値
+		expectedKind error
+	}{
+		{
+			testName:     "正常系_キーの重複",
+			err:          &mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'name' for key 'Member.name'"},
+			expectedKind: domain.ErrConflict,
+		},
+		{
+			testName:     "正常系_参照されている行の削除",
+			err:          &mysql.MySQLError{Number: 1451, Message: "Cannot delete or update a parent row"},
+			expectedKind: domain.ErrForeignKeyViolation,
+		},
+		{
+			testName:     "正常系_参照先の行がない",
+			err:          &mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row"},
+			expectedKind: domain.ErrForeignKeyViolation,
+		},
+		{
+			testName:     "正常系_分類できないエラー",
+			err:          &mysql.MySQLError{Number: 1146, Message: "Table doesn't exist"},
+			expectedKind: nil,
+		},
+		{
+			testName:     "正常系_ドライバ以外のエラー",
+			err:          otherError,
+			expectedKind: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		// when
+		actual := translateError(tc.err)
+
+		// then
+		if tc.expectedKind == nil {
+			assert.Equal(t, tc.err, actual, tc.testName)
+			continue
+		}
+		assert.True(t, errors.Is(actual, tc.expectedKind), tc.testName)
+	}
+	assert.Nil(t, translateError(nil))
+}
@@ -0,0 +1,25 @@
+package memory
+
+import (
+	"live-scheduler/domain/repositorytest"
+	"testing"
+)
+
+func TestRepositoryContract(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) *repositorytest.Repositories {
+		store := NewStore()
+		return &repositorytest.Repositories{
+			Live:             NewLiveRepositoryImpl(store),
+			LiveDesc:         NewLiveDescRepositoryImpl(store),
+			Band:             NewBandRepositoryImpl(store),
+			BandMember:       NewBandMemberRepositoryImpl(store),
+			Player:           NewPlayerRepositoryImpl(store),
+			Payment:          NewPaymentRepositoryImpl(store),
+			LineupRule:       NewLineupRuleRepositoryImpl(store),
+			BandProfile:      NewBandProfileRepositoryImpl(store),
+			Part:             NewPartRepositoryImpl(store),
+			EntryApplication: NewEntryApplicationRepositoryImpl(store),
+			UnitOfWork:       NewUnitOfWorkImpl(store),
+		}
+	})
+}
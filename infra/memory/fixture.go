@@ -0,0 +1,151 @@
+package memory
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"live-scheduler/domain"
+	"time"
+)
+
+// Fixture 起動時に Store へ登録するデータ。メンバーやバンドプロフィールは名前で参照する
+type Fixture struct {
+	Member []struct {
+		Name string        `json:"name"`
+		Part []domain.Part `json:"part"`
+	} `json:"member"`
+	Band []struct {
+		Name   string          `json:"name"`
+		Member []fixturePlayer `json:"member"`
+	} `json:"band"`
+	Live []struct {
+		Name           string       `json:"name"`
+		Location       string       `json:"location"`
+		Date           string       `json:"date"`
+		PerformanceFee int          `json:"performance_fee"`
+		EquipmentCost  int          `json:"equipment_cost"`
+		OpenTime       domain.Clock `json:"open_time"`
+		StartTime      domain.Clock `json:"start_time"`
+		CloseTime      domain.Clock `json:"close_time"`
+		Changeover     int          `json:"changeover"`
+		// 状態(省略した場合は下書き)
+		Status domain.LiveStatus `json:"status"`
+		Band   []struct {
+			Name string `json:"name"`
+			// バンドプロフィールの名前(省略した場合はプロフィールに紐づかない)
+			Profile   string          `json:"profile"`
+			Turn      int             `json:"turn"`
+			SetLength int             `json:"set_length"`
+			Member    []fixturePlayer `json:"member"`
+		} `json:"band"`
+	} `json:"live"`
+}
+
+type fixturePlayer struct {
+	Name string      `json:"name"`
+	Part domain.Part `json:"part"`
+}
+
+// LoadFixture JSON のデータを Store に登録する。制約に違反するデータがある場合は何も登録しない
+func LoadFixture(store *Store, r io.Reader) error {
+	var fixture Fixture
+	if err := json.NewDecoder(r).Decode(&fixture); err != nil {
+		return err
+	}
+	return store.write(func(t *tables) error {
+		members := map[string]int{}
+		for _, member := range fixture.Member {
+			if err := t.requireUniqueName(0, member.Name); err != nil {
+				return err
+			}
+			t.memberSequence++
+			members[member.Name] = t.memberSequence
+			t.member[t.memberSequence] = member.Name
+			for _, part := range member.Part {
+				if err := t.insertMemberPart(t.memberSequence, part); err != nil {
+					return err
+				}
+			}
+		}
+		memberId := func(name string) (int, error) {
+			id, ok := members[name]
+			if !ok {
+				return 0, fmt.Errorf("%w: Member(%s)", ErrForeignKey, name)
+			}
+			return id, nil
+		}
+
+		profiles := map[string]int{}
+		for _, band := range fixture.Band {
+			t.bandProfileSequence++
+			profiles[band.Name] = t.bandProfileSequence
+			t.bandProfile[t.bandProfileSequence] = band.Name
+			for _, player := range band.Member {
+				id, err := memberId(player.Name)
+				if err != nil {
+					return err
+				}
+				if err := t.requirePart(player.Part); err != nil {
+					return err
+				}
+				t.bandProfileMember[bandProfileMemberKey{bandId: t.bandProfileSequence, memberId: id, part: player.Part}] = true
+			}
+		}
+
+		for _, live := range fixture.Live {
+			date, err := time.Parse(LAYOUT, live.Date)
+			if err != nil {
+				return err
+			}
+			status := live.Status
+			if status == "" {
+				status = domain.LiveDraft
+			}
+			if !status.IsValid() {
+				return fmt.Errorf("invalid status %q of live %s", status, live.Name)
+			}
+			t.liveSequence++
+			liveId := t.liveSequence
+			t.live[liveId] = domain.Live{
+				Id:             liveId,
+				Name:           live.Name,
+				Location:       live.Location,
+				Date:           date,
+				PerformanceFee: live.PerformanceFee,
+				EquipmentCost:  live.EquipmentCost,
+				OpenTime:       live.OpenTime,
+				StartTime:      live.StartTime,
+				CloseTime:      live.CloseTime,
+				Changeover:     live.Changeover,
+				Version:        1,
+				UpdatedAt:      now(),
+				Status:         status,
+			}
+			for _, band := range live.Band {
+				var bandId int
+				if band.Profile != "" {
+					id, ok := profiles[band.Profile]
+					if !ok {
+						return fmt.Errorf("%w: BandProfile(%s)", ErrForeignKey, band.Profile)
+					}
+					bandId = id
+				}
+				err := t.insertBand(domain.Band{Name: band.Name, LiveId: liveId, Turn: band.Turn, SetLength: band.SetLength, BandId: bandId, Version: t.nextBandVersion(liveId)})
+				if err != nil {
+					return err
+				}
+				for _, player := range band.Member {
+					id, err := memberId(player.Name)
+					if err != nil {
+						return err
+					}
+					err = t.insertBandMember(&domain.BandMember{LiveId: liveId, Turn: band.Turn, MemberId: id, MemberPart: player.Part})
+					if err != nil {
+						return err
+					}
+				}
+			}
+		}
+		return nil
+	}, allTables...)
+}
@@ -0,0 +1,1128 @@
+package memory
+
+import (
+	"fmt"
+	"live-scheduler/domain"
+	"sort"
+	"time"
+)
+
+const LAYOUT = "2006-01-02"
+
+// dateOnly DATE 型のカラムと同じく日付だけを残す
+func dateOnly(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// now 最終更新日時に記録する現在時刻。DATETIME 型のカラムと同じく秒単位にする
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Second)
+}
+
+// inPeriod 日付が start から end の範囲にあるか。nil の場合はその側を制限しない
+func inPeriod(date time.Time, start *time.Time, end *time.Time) bool {
+	day := date.Format(LAYOUT)
+	if start != nil && day < start.Format(LAYOUT) {
+		return false
+	}
+	if end != nil && day > end.Format(LAYOUT) {
+		return false
+	}
+	return true
+}
+
+// bandName バンドプロフィールに紐づく出演バンドはプロフィールのバンド名を返す
+func (t *tables) bandName(band domain.Band) string {
+	if name, ok := t.bandProfile[band.BandId]; ok {
+		return name
+	}
+	return band.Name
+}
+
+func (t *tables) requireLive(id int) error {
+	if _, ok := t.live[id]; !ok {
+		return fmt.Errorf("%w: Live(%d)", ErrForeignKey, id)
+	}
+	return nil
+}
+
+func (t *tables) requireBand(liveId int, turn int) error {
+	if _, ok := t.band[bandKey{liveId: liveId, turn: turn}]; !ok {
+		return fmt.Errorf("%w: Band(%d, %d)", ErrForeignKey, liveId, turn)
+	}
+	return nil
+}
+
+// checkLiveVersion ライブが存在し、バージョンが version と一致するか
+func (t *tables) checkLiveVersion(id int, version int) error {
+	live, ok := t.live[id]
+	if !ok {
+		return fmt.Errorf("%w: live %d", domain.ErrNotFound, id)
+	}
+	if live.Version != version {
+		return fmt.Errorf("%w: live %d", domain.ErrPreconditionFailed, id)
+	}
+	return nil
+}
+
+// checkBandVersion 出演バンドが存在し、バージョンが version と一致するか
+func (t *tables) checkBandVersion(liveId int, turn int, version int) error {
+	band, ok := t.band[bandKey{liveId: liveId, turn: turn}]
+	if !ok {
+		return fmt.Errorf("%w: band %d-%d", domain.ErrNotFound, liveId, turn)
+	}
+	if band.Version != version {
+		return fmt.Errorf("%w: band %d-%d", domain.ErrPreconditionFailed, liveId, turn)
+	}
+	return nil
+}
+
+func (t *tables) requireBandProfile(id int) error {
+	if _, ok := t.bandProfile[id]; !ok {
+		return fmt.Errorf("%w: BandProfile(%d)", ErrForeignKey, id)
+	}
+	return nil
+}
+
+func (t *tables) requireMember(id int) error {
+	if _, ok := t.member[id]; !ok {
+		return fmt.Errorf("%w: Member(%d)", ErrForeignKey, id)
+	}
+	return nil
+}
+
+func (t *tables) requirePart(part domain.Part) error {
+	if _, ok := t.partCatalog[part]; !ok {
+		return fmt.Errorf("%w: PartCatalog(%s)", ErrForeignKey, part)
+	}
+	return nil
+}
+
+// hasBandMember 出演バンドにメンバーが登録されているか(BandMember から Band への外部キー)
+func (t *tables) hasBandMember(liveId int, turn int) bool {
+	for key := range t.bandMember {
+		if key.liveId == liveId && key.turn == turn {
+			return true
+		}
+	}
+	return false
+}
+
+// players 出演バンドのメンバーをメンバーID、パートの順に返す
+func (t *tables) players(liveId int, turn int) []*domain.Player {
+	var players []*domain.Player
+	for _, bandMember := range t.bandMembers(liveId) {
+		if bandMember.Turn == turn {
+			players = append(players, &domain.Player{MemberId: bandMember.MemberId, Name: bandMember.MemberName, Part: bandMember.MemberPart})
+		}
+	}
+	return players
+}
+
+// bandMembers ライブのバンドメンバーを出演順、メンバーID、パートの順に返す
+func (t *tables) bandMembers(liveId int) []*domain.BandMember {
+	var bandMembers []*domain.BandMember
+	for key := range t.bandMember {
+		if key.liveId == liveId {
+			bandMembers = append(bandMembers, &domain.BandMember{
+				LiveId: key.liveId, Turn: key.turn, MemberId: key.memberId, MemberName: t.member[key.memberId], MemberPart: key.part,
+			})
+		}
+	}
+	sort.Slice(bandMembers, func(i, j int) bool {
+		a, b := bandMembers[i], bandMembers[j]
+		if a.Turn != b.Turn {
+			return a.Turn < b.Turn
+		}
+		if a.MemberId != b.MemberId {
+			return a.MemberId < b.MemberId
+		}
+		return a.MemberPart < b.MemberPart
+	})
+	return bandMembers
+}
+
+// bands ライブの出演バンドを出演順に返す
+func (t *tables) bands(liveId int) []*domain.Band {
+	var bands []*domain.Band
+	for _, band := range t.band {
+		if band.LiveId == liveId {
+			band := band
+			band.Name = t.bandName(band)
+			bands = append(bands, &band)
+		}
+	}
+	sort.Slice(bands, func(i, j int) bool { return bands[i].Turn < bands[j].Turn })
+	return bands
+}
+
+// lives 条件に合うライブを日付、ID の順に返す
+func (t *tables) lives(match func(live domain.Live) bool) []*domain.Live {
+	var lives []*domain.Live
+	for _, live := range t.live {
+		if match(live) {
+			live := live
+			lives = append(lives, &live)
+		}
+	}
+	sort.Slice(lives, func(i, j int) bool {
+		if !lives[i].Date.Equal(lives[j].Date) {
+			return lives[i].Date.Before(lives[j].Date)
+		}
+		return lives[i].Id < lives[j].Id
+	})
+	return lives
+}
+
+type LiveRepositoryImpl struct {
+	db db
+}
+
+func NewLiveRepositoryImpl(store *Store) *LiveRepositoryImpl {
+	return &LiveRepositoryImpl{db: store}
+}
+
+func (l *LiveRepositoryImpl) FindById(id int) (*domain.Live, error) {
+	var live *domain.Live
+	err := l.db.read(func(t *tables) error {
+		found, ok := t.live[id]
+		if !ok {
+			return fmt.Errorf("%w: live %d", domain.ErrNotFound, id)
+		}
+		live = &found
+		return nil
+	})
+	return live, err
+}
+
+// FindByIdForUpdate UnitOfWork は他の書き込みがあった場合にやり直すため、ロックせずに読み込む
+func (l *LiveRepositoryImpl) FindByIdForUpdate(id int) (*domain.Live, error) {
+	return l.FindById(id)
+}
+
+func (l *LiveRepositoryImpl) FindByPeriod(start *time.Time, end *time.Time) ([]*domain.Live, error) {
+	var lives []*domain.Live
+	err := l.db.read(func(t *tables) error {
+		lives = t.lives(func(live domain.Live) bool { return inPeriod(live.Date, start, end) })
+		return nil
+	})
+	return lives, err
+}
+
+func (l *LiveRepositoryImpl) Create(live *domain.Live) error {
+	return l.db.write(func(t *tables) error {
+		t.liveSequence++
+		created := *live
+		created.Id = t.liveSequence
+		created.Date = dateOnly(live.Date)
+		created.Version = 1
+		created.UpdatedAt = now()
+		t.live[created.Id] = created
+		return nil
+	}, liveTable)
+}
+
+func (l *LiveRepositoryImpl) Patch(id int, version int, patch *domain.LivePatch) error {
+	return l.db.write(func(t *tables) error {
+		if err := t.checkLiveVersion(id, version); err != nil {
+			return err
+		}
+		live := t.live[id]
+		patch.Apply(&live)
+		live.Date = dateOnly(live.Date)
+		live.Version++
+		live.UpdatedAt = now()
+		t.live[id] = live
+		return nil
+	}, liveTable)
+}
+
+func (l *LiveRepositoryImpl) Touch(id int) error {
+	return l.db.write(func(t *tables) error {
+		live, ok := t.live[id]
+		if !ok {
+			return nil
+		}
+		live.Version++
+		live.UpdatedAt = now()
+		t.live[id] = live
+		return nil
+	}, liveTable)
+}
+
+// Delete 支払、編成のルール、出演申込は合わせて削除する。出演バンドが残っている場合は削除できない
+func (l *LiveRepositoryImpl) Delete(id int, version int) error {
+	return l.db.write(func(t *tables) error {
+		if err := t.checkLiveVersion(id, version); err != nil {
+			return err
+		}
+		for key := range t.band {
+			if key.liveId == id {
+				return fmt.Errorf("%w: Band(%d, %d) references Live(%d)", ErrForeignKey, key.liveId, key.turn, id)
+			}
+		}
+		for paymentId, payment := range t.payment {
+			if payment.LiveId == id {
+				delete(t.payment, paymentId)
+			}
+		}
+		delete(t.lineupRule, id)
+		for key := range t.lineupPartRule {
+			if key.liveId == id {
+				delete(t.lineupPartRule, key)
+			}
+		}
+		for applicationId, application := range t.entryApplication {
+			if application.LiveId == id {
+				delete(t.entryApplication, applicationId)
+			}
+		}
+		for key := range t.entryApplicationMember {
+			if _, ok := t.entryApplication[key.applicationId]; !ok {
+				delete(t.entryApplicationMember, key)
+			}
+		}
+		delete(t.live, id)
+		delete(t.bandVersion, id)
+		return nil
+	}, liveTable, bandVersionTable, paymentTable, lineupRuleTable, lineupPartRuleTable, entryApplicationTable, entryApplicationMemberTable)
+}
+
+type LiveDescRepositoryImpl struct {
+	db db
+}
+
+func NewLiveDescRepositoryImpl(store *Store) *LiveDescRepositoryImpl {
+	return &LiveDescRepositoryImpl{db: store}
+}
+
+func (l *LiveDescRepositoryImpl) FindById(id int) (*domain.LiveModel, error) {
+	var liveModel *domain.LiveModel
+	err := l.db.read(func(t *tables) error {
+		live, ok := t.live[id]
+		if !ok {
+			return fmt.Errorf("%w: live %d", domain.ErrNotFound, id)
+		}
+		liveModel = t.liveModel(&live)
+		return nil
+	})
+	return liveModel, err
+}
+
+func (l *LiveDescRepositoryImpl) FindByPeriod(start *time.Time, end *time.Time) ([]*domain.LiveModel, error) {
+	var liveModels []*domain.LiveModel
+	err := l.db.read(func(t *tables) error {
+		for _, live := range t.lives(func(live domain.Live) bool { return inPeriod(live.Date, start, end) }) {
+			liveModels = append(liveModels, t.liveModel(live))
+		}
+		return nil
+	})
+	return liveModels, err
+}
+
+func (t *tables) liveModel(live *domain.Live) *domain.LiveModel {
+	liveModel := &domain.LiveModel{
+		Id:             live.Id,
+		Name:           live.Name,
+		Location:       live.Location,
+		Date:           live.Date,
+		PerformanceFee: live.PerformanceFee,
+		EquipmentCost:  live.EquipmentCost,
+		OpenTime:       live.OpenTime,
+		StartTime:      live.StartTime,
+		CloseTime:      live.CloseTime,
+		Changeover:     live.Changeover,
+		Version:        live.Version,
+		UpdatedAt:      live.UpdatedAt,
+		Status:         live.Status,
+	}
+	for _, band := range t.bands(live.Id) {
+		liveModel.Band = append(liveModel.Band, &domain.BandModel{
+			Name:      band.Name,
+			LiveId:    band.LiveId,
+			Turn:      band.Turn,
+			SetLength: band.SetLength,
+			BandId:    band.BandId,
+			Version:   band.Version,
+			Player:    t.players(band.LiveId, band.Turn),
+		})
+	}
+	return liveModel
+}
+
+type BandRepositoryImpl struct {
+	db db
+}
+
+func NewBandRepositoryImpl(store *Store) *BandRepositoryImpl {
+	return &BandRepositoryImpl{db: store}
+}
+
+func (b *BandRepositoryImpl) FindByLiveId(id int) ([]*domain.Band, error) {
+	var bands []*domain.Band
+	err := b.db.read(func(t *tables) error {
+		bands = t.bands(id)
+		return nil
+	})
+	return bands, err
+}
+
+func (b *BandRepositoryImpl) FindByBandId(id int) ([]*domain.Band, error) {
+	var bands []*domain.Band
+	err := b.db.read(func(t *tables) error {
+		for _, band := range t.band {
+			if band.BandId == id {
+				band := band
+				band.Name = t.bandName(band)
+				bands = append(bands, &band)
+			}
+		}
+		sort.Slice(bands, func(i, j int) bool {
+			if bands[i].LiveId != bands[j].LiveId {
+				return bands[i].LiveId < bands[j].LiveId
+			}
+			return bands[i].Turn < bands[j].Turn
+		})
+		return nil
+	})
+	return bands, err
+}
+
+func (b *BandRepositoryImpl) Create(band *domain.Band) error {
+	var version int
+	err := b.db.write(func(t *tables) error {
+		created := *band
+		created.Version = t.nextBandVersion(band.LiveId)
+		version = created.Version
+		return t.insertBand(created)
+	}, bandTable, bandVersionTable)
+	if err != nil {
+		return err
+	}
+	band.Version = version
+	return nil
+}
+
+// nextBandVersion Live.band_version と同じく、ライブの中でまだ使っていないバンドのバージョンを返す
+func (t *tables) nextBandVersion(liveId int) int {
+	t.bandVersion[liveId]++
+	return t.bandVersion[liveId]
+}
+
+func (t *tables) insertBand(band domain.Band) error {
+	key := bandKey{liveId: band.LiveId, turn: band.Turn}
+	if _, ok := t.band[key]; ok {
+		return fmt.Errorf("%w: Band(%d, %d)", ErrDuplicateKey, band.LiveId, band.Turn)
+	}
+	if err := t.requireLive(band.LiveId); err != nil {
+		return err
+	}
+	if band.BandId != 0 {
+		if err := t.requireBandProfile(band.BandId); err != nil {
+			return err
+		}
+	}
+	t.band[key] = band
+	return nil
+}
+
+func (b *BandRepositoryImpl) Patch(id int, turn int, version int, patch *domain.BandPatch) error {
+	return b.db.write(func(t *tables) error {
+		if err := t.checkBandVersion(id, turn, version); err != nil {
+			return err
+		}
+		key := bandKey{liveId: id, turn: turn}
+		band := t.band[key]
+		patch.Apply(&band)
+		band.Version = t.nextBandVersion(id)
+		delete(t.band, key)
+		return t.insertBand(band)
+	}, bandTable, bandVersionTable)
+}
+
+func (b *BandRepositoryImpl) Delete(id int, turn int, version int) error {
+	return b.db.write(func(t *tables) error {
+		if err := t.checkBandVersion(id, turn, version); err != nil {
+			return err
+		}
+		if t.hasBandMember(id, turn) {
+			return fmt.Errorf("%w: BandMember references Band(%d, %d)", ErrForeignKey, id, turn)
+		}
+		delete(t.band, bandKey{liveId: id, turn: turn})
+		return nil
+	}, bandTable)
+}
+
+func (b *BandRepositoryImpl) DeleteByLiveId(id int) error {
+	return b.db.write(func(t *tables) error {
+		for key := range t.band {
+			if key.liveId != id {
+				continue
+			}
+			if t.hasBandMember(key.liveId, key.turn) {
+				return fmt.Errorf("%w: BandMember references Band(%d, %d)", ErrForeignKey, key.liveId, key.turn)
+			}
+			delete(t.band, key)
+		}
+		return nil
+	}, bandTable)
+}
+
+type BandMemberRepositoryImpl struct {
+	db db
+}
+
+func NewBandMemberRepositoryImpl(store *Store) *BandMemberRepositoryImpl {
+	return &BandMemberRepositoryImpl{db: store}
+}
+
+func (b *BandMemberRepositoryImpl) FindByLiveIdAndTurn(id int, turn int) ([]*domain.Player, error) {
+	var players []*domain.Player
+	err := b.db.read(func(t *tables) error {
+		players = t.players(id, turn)
+		return nil
+	})
+	return players, err
+}
+
+func (b *BandMemberRepositoryImpl) FindByLiveId(id int) ([]*domain.BandMember, error) {
+	var bandMembers []*domain.BandMember
+	err := b.db.read(func(t *tables) error {
+		bandMembers = t.bandMembers(id)
+		return nil
+	})
+	return bandMembers, err
+}
+
+func (b *BandMemberRepositoryImpl) FindAppearances(memberId int, start *time.Time, end *time.Time) ([]*domain.Appearance, error) {
+	var appearances []*domain.Appearance
+	err := b.db.read(func(t *tables) error {
+		for key := range t.bandMember {
+			if key.memberId != memberId {
+				continue
+			}
+			band := t.band[bandKey{liveId: key.liveId, turn: key.turn}]
+			live := t.live[key.liveId]
+			if !inPeriod(live.Date, start, end) {
+				continue
+			}
+			appearances = append(appearances, &domain.Appearance{
+				LiveId:   live.Id,
+				LiveName: live.Name,
+				Location: live.Location,
+				Date:     live.Date,
+				BandName: t.bandName(band),
+				Turn:     key.turn,
+				Part:     key.part,
+			})
+		}
+		sort.Slice(appearances, func(i, j int) bool {
+			a, b := appearances[i], appearances[j]
+			if !a.Date.Equal(b.Date) {
+				return a.Date.Before(b.Date)
+			}
+			if a.LiveId != b.LiveId {
+				return a.LiveId < b.LiveId
+			}
+			if a.Turn != b.Turn {
+				return a.Turn < b.Turn
+			}
+			return a.Part < b.Part
+		})
+		return nil
+	})
+	return appearances, err
+}
+
+func (b *BandMemberRepositoryImpl) Create(bandMember *domain.BandMember) error {
+	return b.db.write(func(t *tables) error {
+		return t.insertBandMember(bandMember)
+	}, bandMemberTable)
+}
+
+func (t *tables) insertBandMember(bandMember *domain.BandMember) error {
+	key := bandMemberKey{liveId: bandMember.LiveId, turn: bandMember.Turn, memberId: bandMember.MemberId, part: bandMember.MemberPart}
+	if t.bandMember[key] {
+		return fmt.Errorf("%w: BandMember(%d, %d, %d, %s)", ErrDuplicateKey, key.liveId, key.turn, key.memberId, key.part)
+	}
+	if err := t.requireBand(key.liveId, key.turn); err != nil {
+		return err
+	}
+	if err := t.requireMember(key.memberId); err != nil {
+		return err
+	}
+	if err := t.requirePart(key.part); err != nil {
+		return err
+	}
+	t.bandMember[key] = true
+	return nil
+}
+
+func (b *BandMemberRepositoryImpl) Delete(bandMember *domain.BandMember) error {
+	return b.db.write(func(t *tables) error {
+		key := bandMemberKey{liveId: bandMember.LiveId, turn: bandMember.Turn, memberId: bandMember.MemberId, part: bandMember.MemberPart}
+		if err := t.checkBandMember(key); err != nil {
+			return err
+		}
+		delete(t.bandMember, key)
+		return nil
+	}, bandMemberTable)
+}
+
+func (b *BandMemberRepositoryImpl) Update(current *domain.BandMember, replacement *domain.BandMember) error {
+	return b.db.write(func(t *tables) error {
+		key := bandMemberKey{liveId: current.LiveId, turn: current.Turn, memberId: current.MemberId, part: current.MemberPart}
+		if err := t.checkBandMember(key); err != nil {
+			return err
+		}
+		delete(t.bandMember, key)
+		return t.insertBandMember(replacement)
+	}, bandMemberTable)
+}
+
+// checkBandMember バンドメンバーが存在するか
+func (t *tables) checkBandMember(key bandMemberKey) error {
+	if !t.bandMember[key] {
+		return fmt.Errorf("%w: band member %d-%d %d %s", domain.ErrNotFound, key.liveId, key.turn, key.memberId, key.part)
+	}
+	return nil
+}
+
+func (b *BandMemberRepositoryImpl) DeleteByLiveId(id int) error {
+	return b.db.write(func(t *tables) error {
+		for key := range t.bandMember {
+			if key.liveId == id {
+				delete(t.bandMember, key)
+			}
+		}
+		return nil
+	}, bandMemberTable)
+}
+
+type PlayerRepositoryImpl struct {
+	db db
+}
+
+func NewPlayerRepositoryImpl(store *Store) *PlayerRepositoryImpl {
+	return &PlayerRepositoryImpl{db: store}
+}
+
+func (p *PlayerRepositoryImpl) FindByPart(part *domain.Part) ([]*domain.Player, error) {
+	var players []*domain.Player
+	err := p.db.read(func(t *tables) error {
+		for key := range t.memberPart {
+			if key.part == *part {
+				players = append(players, &domain.Player{MemberId: key.memberId, Name: t.member[key.memberId], Part: key.part})
+			}
+		}
+		sort.Slice(players, func(i, j int) bool { return players[i].MemberId < players[j].MemberId })
+		return nil
+	})
+	return players, err
+}
+
+func (p *PlayerRepositoryImpl) FindById(id int) (*domain.Member, error) {
+	var member *domain.Member
+	err := p.db.read(func(t *tables) error {
+		member = t.findMember(func(memberId int, name string) bool { return memberId == id })
+		return nil
+	})
+	return member, err
+}
+
+func (p *PlayerRepositoryImpl) FindByName(name string) (*domain.Member, error) {
+	var member *domain.Member
+	err := p.db.read(func(t *tables) error {
+		member = t.findMember(func(memberId int, memberName string) bool { return memberName == name })
+		return nil
+	})
+	return member, err
+}
+
+// findMember 条件に合うメンバーを担当できるパートを含めて返す。存在しない場合は nil を返す
+func (t *tables) findMember(match func(id int, name string) bool) *domain.Member {
+	for id, name := range t.member {
+		if !match(id, name) {
+			continue
+		}
+		member := &domain.Member{Id: id, Name: name}
+		for key := range t.memberPart {
+			if key.memberId == id {
+				member.Part = append(member.Part, key.part)
+			}
+		}
+		sort.Slice(member.Part, func(i, j int) bool { return member.Part[i] < member.Part[j] })
+		return member
+	}
+	return nil
+}
+
+// requireUniqueName 他のメンバーが同じ名前を使っていないか(Member.name の一意キー)
+func (t *tables) requireUniqueName(id int, name string) error {
+	for memberId, memberName := range t.member {
+		if memberId != id && memberName == name {
+			return fmt.Errorf("%w: Member.name(%s)", ErrDuplicateKey, name)
+		}
+	}
+	return nil
+}
+
+func (p *PlayerRepositoryImpl) Create(member *domain.Member) error {
+	var id int
+	err := p.db.write(func(t *tables) error {
+		if err := t.requireUniqueName(0, member.Name); err != nil {
+			return err
+		}
+		t.memberSequence++
+		id = t.memberSequence
+		t.member[id] = member.Name
+		for _, part := range member.Part {
+			if err := t.insertMemberPart(id, part); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, memberTable, memberPartTable)
+	if err != nil {
+		return err
+	}
+	member.Id = id
+	return nil
+}
+
+func (p *PlayerRepositoryImpl) Rename(id int, name string) error {
+	return p.db.write(func(t *tables) error {
+		if _, ok := t.member[id]; !ok {
+			return nil
+		}
+		if err := t.requireUniqueName(id, name); err != nil {
+			return err
+		}
+		t.member[id] = name
+		return nil
+	}, memberTable)
+}
+
+func (p *PlayerRepositoryImpl) AddPart(id int, part domain.Part) error {
+	return p.db.write(func(t *tables) error {
+		return t.insertMemberPart(id, part)
+	}, memberPartTable)
+}
+
+func (t *tables) insertMemberPart(id int, part domain.Part) error {
+	key := memberPartKey{memberId: id, part: part}
+	if t.memberPart[key] {
+		return fmt.Errorf("%w: MemberPart(%d, %s)", ErrDuplicateKey, id, part)
+	}
+	if err := t.requireMember(id); err != nil {
+		return err
+	}
+	if err := t.requirePart(part); err != nil {
+		return err
+	}
+	t.memberPart[key] = true
+	return nil
+}
+
+func (p *PlayerRepositoryImpl) RemovePart(id int, part domain.Part) error {
+	return p.db.write(func(t *tables) error {
+		delete(t.memberPart, memberPartKey{memberId: id, part: part})
+		return nil
+	}, memberPartTable)
+}
+
+type PaymentRepositoryImpl struct {
+	db db
+}
+
+func NewPaymentRepositoryImpl(store *Store) *PaymentRepositoryImpl {
+	return &PaymentRepositoryImpl{db: store}
+}
+
+func (p *PaymentRepositoryImpl) FindByLiveId(id int) ([]*domain.Payment, error) {
+	var payments []*domain.Payment
+	err := p.db.read(func(t *tables) error {
+		for _, payment := range t.payment {
+			if payment.LiveId == id {
+				payment := payment
+				payment.PlayerName = t.member[payment.MemberId]
+				payments = append(payments, &payment)
+			}
+		}
+		sort.Slice(payments, func(i, j int) bool {
+			if !payments[i].PaidAt.Equal(payments[j].PaidAt) {
+				return payments[i].PaidAt.Before(payments[j].PaidAt)
+			}
+			return payments[i].Id < payments[j].Id
+		})
+		return nil
+	})
+	return payments, err
+}
+
+func (p *PaymentRepositoryImpl) Create(payment *domain.Payment) error {
+	var id int
+	err := p.db.write(func(t *tables) error {
+		if err := t.requireLive(payment.LiveId); err != nil {
+			return err
+		}
+		if err := t.requireMember(payment.MemberId); err != nil {
+			return err
+		}
+		t.paymentSequence++
+		id = t.paymentSequence
+		created := *payment
+		created.Id = id
+		created.PlayerName = ""
+		created.PaidAt = payment.PaidAt.Truncate(time.Second)
+		t.payment[id] = created
+		return nil
+	}, paymentTable)
+	if err != nil {
+		return err
+	}
+	payment.Id = id
+	return nil
+}
+
+type LineupRuleRepositoryImpl struct {
+	db db
+}
+
+func NewLineupRuleRepositoryImpl(store *Store) *LineupRuleRepositoryImpl {
+	return &LineupRuleRepositoryImpl{db: store}
+}
+
+func (l *LineupRuleRepositoryImpl) FindByLiveId(id int) (*domain.LineupRule, error) {
+	var rule *domain.LineupRule
+	err := l.db.read(func(t *tables) error {
+		found, ok := t.lineupRule[id]
+		if !ok {
+			return nil
+		}
+		rule = &found
+		for key, partRule := range t.lineupPartRule {
+			if key.liveId == id {
+				partRule := partRule
+				rule.Part = append(rule.Part, &partRule)
+			}
+		}
+		sort.Slice(rule.Part, func(i, j int) bool { return rule.Part[i].Part < rule.Part[j].Part })
+		return nil
+	})
+	return rule, err
+}
+
+func (l *LineupRuleRepositoryImpl) Save(rule *domain.LineupRule) error {
+	return l.db.write(func(t *tables) error {
+		if err := t.requireLive(rule.LiveId); err != nil {
+			return err
+		}
+		saved := *rule
+		saved.Part = nil
+		t.lineupRule[rule.LiveId] = saved
+		for key := range t.lineupPartRule {
+			if key.liveId == rule.LiveId {
+				delete(t.lineupPartRule, key)
+			}
+		}
+		for _, partRule := range rule.Part {
+			key := lineupPartRuleKey{liveId: rule.LiveId, part: partRule.Part}
+			if _, ok := t.lineupPartRule[key]; ok {
+				return fmt.Errorf("%w: LineupPartRule(%d, %s)", ErrDuplicateKey, rule.LiveId, partRule.Part)
+			}
+			if err := t.requirePart(partRule.Part); err != nil {
+				return err
+			}
+			t.lineupPartRule[key] = *partRule
+		}
+		return nil
+	}, lineupRuleTable, lineupPartRuleTable)
+}
+
+type BandProfileRepositoryImpl struct {
+	db db
+}
+
+func NewBandProfileRepositoryImpl(store *Store) *BandProfileRepositoryImpl {
+	return &BandProfileRepositoryImpl{db: store}
+}
+
+func (b *BandProfileRepositoryImpl) FindAll() ([]*domain.BandProfile, error) {
+	var profiles []*domain.BandProfile
+	err := b.db.read(func(t *tables) error {
+		for id, name := range t.bandProfile {
+			profiles = append(profiles, &domain.BandProfile{Id: id, Name: name, Player: t.roster(id)})
+		}
+		sort.Slice(profiles, func(i, j int) bool { return profiles[i].Id < profiles[j].Id })
+		return nil
+	})
+	return profiles, err
+}
+
+func (b *BandProfileRepositoryImpl) FindById(id int) (*domain.BandProfile, error) {
+	var profile *domain.BandProfile
+	err := b.db.read(func(t *tables) error {
+		if name, ok := t.bandProfile[id]; ok {
+			profile = &domain.BandProfile{Id: id, Name: name, Player: t.roster(id)}
+		}
+		return nil
+	})
+	return profile, err
+}
+
+// roster バンドプロフィールの既定のメンバーをメンバーID、パートの順に返す
+func (t *tables) roster(id int) []*domain.Player {
+	var players []*domain.Player
+	for key := range t.bandProfileMember {
+		if key.bandId == id {
+			players = append(players, &domain.Player{MemberId: key.memberId, Name: t.member[key.memberId], Part: key.part})
+		}
+	}
+	sort.Slice(players, func(i, j int) bool {
+		if players[i].MemberId != players[j].MemberId {
+			return players[i].MemberId < players[j].MemberId
+		}
+		return players[i].Part < players[j].Part
+	})
+	return players
+}
+
+func (b *BandProfileRepositoryImpl) Create(profile *domain.BandProfile) error {
+	var id int
+	err := b.db.write(func(t *tables) error {
+		t.bandProfileSequence++
+		id = t.bandProfileSequence
+		t.bandProfile[id] = profile.Name
+		return nil
+	}, bandProfileTable)
+	if err != nil {
+		return err
+	}
+	profile.Id = id
+	return nil
+}
+
+func (b *BandProfileRepositoryImpl) Rename(id int, name string) error {
+	return b.db.write(func(t *tables) error {
+		if _, ok := t.bandProfile[id]; ok {
+			t.bandProfile[id] = name
+		}
+		return nil
+	}, bandProfileTable)
+}
+
+func (b *BandProfileRepositoryImpl) SaveRoster(id int, players []*domain.Player) error {
+	return b.db.write(func(t *tables) error {
+		for key := range t.bandProfileMember {
+			if key.bandId == id {
+				delete(t.bandProfileMember, key)
+			}
+		}
+		for _, player := range players {
+			key := bandProfileMemberKey{bandId: id, memberId: player.MemberId, part: player.Part}
+			if t.bandProfileMember[key] {
+				return fmt.Errorf("%w: BandProfileMember(%d, %d, %s)", ErrDuplicateKey, id, player.MemberId, player.Part)
+			}
+			if err := t.requireBandProfile(id); err != nil {
+				return err
+			}
+			if err := t.requireMember(player.MemberId); err != nil {
+				return err
+			}
+			if err := t.requirePart(player.Part); err != nil {
+				return err
+			}
+			t.bandProfileMember[key] = true
+		}
+		return nil
+	}, bandProfileMemberTable)
+}
+
+type PartRepositoryImpl struct {
+	db db
+}
+
+func NewPartRepositoryImpl(store *Store) *PartRepositoryImpl {
+	return &PartRepositoryImpl{db: store}
+}
+
+func (p *PartRepositoryImpl) FindAll() ([]*domain.PartDefinition, error) {
+	var parts []*domain.PartDefinition
+	err := p.db.read(func(t *tables) error {
+		for _, part := range t.partCatalog {
+			part := part
+			parts = append(parts, &part)
+		}
+		sort.Slice(parts, func(i, j int) bool {
+			if parts[i].SortOrder != parts[j].SortOrder {
+				return parts[i].SortOrder < parts[j].SortOrder
+			}
+			return parts[i].Code < parts[j].Code
+		})
+		return nil
+	})
+	return parts, err
+}
+
+func (p *PartRepositoryImpl) Save(part *domain.PartDefinition) error {
+	return p.db.write(func(t *tables) error {
+		t.partCatalog[part.Code] = *part
+		return nil
+	}, partCatalogTable)
+}
+
+func (p *PartRepositoryImpl) Delete(code domain.Part) error {
+	return p.db.write(func(t *tables) error {
+		if t.isUsed(code) {
+			return fmt.Errorf("%w: PartCatalog(%s) is referenced", ErrForeignKey, code)
+		}
+		delete(t.partCatalog, code)
+		return nil
+	}, partCatalogTable)
+}
+
+func (p *PartRepositoryImpl) IsUsed(code domain.Part) (bool, error) {
+	var used bool
+	err := p.db.read(func(t *tables) error {
+		used = t.isUsed(code)
+		return nil
+	})
+	return used, err
+}
+
+func (t *tables) isUsed(code domain.Part) bool {
+	for key := range t.memberPart {
+		if key.part == code {
+			return true
+		}
+	}
+	for key := range t.bandMember {
+		if key.part == code {
+			return true
+		}
+	}
+	for key := range t.bandProfileMember {
+		if key.part == code {
+			return true
+		}
+	}
+	for key := range t.lineupPartRule {
+		if key.part == code {
+			return true
+		}
+	}
+	for key := range t.entryApplicationMember {
+		if key.part == code {
+			return true
+		}
+	}
+	return false
+}
+
+type EntryApplicationRepositoryImpl struct {
+	db db
+}
+
+func NewEntryApplicationRepositoryImpl(store *Store) *EntryApplicationRepositoryImpl {
+	return &EntryApplicationRepositoryImpl{db: store}
+}
+
+func (e *EntryApplicationRepositoryImpl) FindByLiveId(id int, status *domain.ApplicationStatus) ([]*domain.EntryApplication, error) {
+	var applications []*domain.EntryApplication
+	err := e.db.read(func(t *tables) error {
+		for applicationId, application := range t.entryApplication {
+			if application.LiveId != id || (status != nil && application.Status != *status) {
+				continue
+			}
+			applications = append(applications, t.entryApplicationWithMembers(applicationId))
+		}
+		sort.Slice(applications, func(i, j int) bool { return applications[i].Id < applications[j].Id })
+		return nil
+	})
+	return applications, err
+}
+
+func (e *EntryApplicationRepositoryImpl) FindById(id int) (*domain.EntryApplication, error) {
+	var application *domain.EntryApplication
+	err := e.db.read(func(t *tables) error {
+		if _, ok := t.entryApplication[id]; ok {
+			application = t.entryApplicationWithMembers(id)
+		}
+		return nil
+	})
+	return application, err
+}
+
+// entryApplicationWithMembers 申込にメンバーをメンバーID、パートの順に設定して返す
+func (t *tables) entryApplicationWithMembers(id int) *domain.EntryApplication {
+	application := t.entryApplication[id]
+	application.Player = nil
+	for key := range t.entryApplicationMember {
+		if key.applicationId == id {
+			application.Player = append(application.Player, &domain.Player{MemberId: key.memberId, Name: t.member[key.memberId], Part: key.part})
+		}
+	}
+	sort.Slice(application.Player, func(i, j int) bool {
+		if application.Player[i].MemberId != application.Player[j].MemberId {
+			return application.Player[i].MemberId < application.Player[j].MemberId
+		}
+		return application.Player[i].Part < application.Player[j].Part
+	})
+	return &application
+}
+
+func (e *EntryApplicationRepositoryImpl) Create(application *domain.EntryApplication) error {
+	var id int
+	err := e.db.write(func(t *tables) error {
+		if err := t.requireLive(application.LiveId); err != nil {
+			return err
+		}
+		if application.BandId != 0 {
+			if err := t.requireBandProfile(application.BandId); err != nil {
+				return err
+			}
+		}
+		t.entryApplicationSequence++
+		id = t.entryApplicationSequence
+		created := *application
+		created.Id = id
+		created.Player = nil
+		created.AppliedAt = application.AppliedAt.Truncate(time.Second)
+		t.entryApplication[id] = created
+		for _, player := range application.Player {
+			key := entryApplicationMemberKey{applicationId: id, memberId: player.MemberId, part: player.Part}
+			if t.entryApplicationMember[key] {
+				return fmt.Errorf("%w: EntryApplicationMember(%d, %d, %s)", ErrDuplicateKey, id, player.MemberId, player.Part)
+			}
+			if err := t.requireMember(player.MemberId); err != nil {
+				return err
+			}
+			if err := t.requirePart(player.Part); err != nil {
+				return err
+			}
+			t.entryApplicationMember[key] = true
+		}
+		return nil
+	}, entryApplicationTable, entryApplicationMemberTable)
+	if err != nil {
+		return err
+	}
+	application.Id = id
+	return nil
+}
+
+func (e *EntryApplicationRepositoryImpl) UpdateStatus(id int, current domain.ApplicationStatus, status domain.ApplicationStatus, turn int) error {
+	return e.db.write(func(t *tables) error {
+		application, ok := t.entryApplication[id]
+		if !ok {
+			return fmt.Errorf("%w: entry application %d", domain.ErrNotFound, id)
+		}
+		if application.Status != current {
+			return fmt.Errorf("%w: entry application %d is not %s", domain.ErrConflict, id, current)
+		}
+		application.Status, application.Turn = status, turn
+		t.entryApplication[id] = application
+		return nil
+	}, entryApplicationTable)
+}
@@ -0,0 +1,227 @@
+package memory
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"live-scheduler/domain"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+var date = time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC)
+
+func TestConstraints(t *testing.T) {
+	// given
+	store := NewStore()
+	liveRepository := NewLiveRepositoryImpl(store)
+	bandRepository := NewBandRepositoryImpl(store)
+	bandMemberRepository := NewBandMemberRepositoryImpl(store)
+	playerRepository := NewPlayerRepositoryImpl(store)
+	assert.Nil(t, liveRepository.Create(&domain.Live{Name: "live", Date: date}))
+	assert.Nil(t, bandRepository.Create(&domain.Band{Name: "band", LiveId: 1, Turn: 1}))
+	member := &domain.Member{Name: "drummer", Part: []domain.Part{domain.Dr}}
+	assert.Nil(t, playerRepository.Create(member))
+	assert.Nil(t, bandMemberRepository.Create(&domain.BandMember{LiveId: 1, Turn: 1, MemberId: member.Id, MemberPart: domain.Dr}))
+
+	tests := []struct {
+		// テスト名
+		testName string
+		// 実行する書き込み
+		write func() error
+		// 戻り値の期待値(error)
+		expectedError error
+	}{
+		{
+			testName:      "異常系_主キーの重複",
+			write:         func() error { return bandRepository.Create(&domain.Band{Name: "other", LiveId: 1, Turn: 1}) },
+			expectedError: ErrDuplicateKey,
+		},
+		{
+			testName:      "異常系_一意キーの重複",
+			write:         func() error { return playerRepository.Create(&domain.Member{Name: "drummer"}) },
+			expectedError: ErrDuplicateKey,
+		},
+		{
+			testName:      "異常系_存在しないライブを参照",
+			write:         func() error { return bandRepository.Create(&domain.Band{Name: "band", LiveId: 2, Turn: 1}) },
+			expectedError: ErrForeignKey,
+		},
+		{
+			testName:      "異常系_カタログにないパートを参照",
+			write:         func() error { return playerRepository.AddPart(member.Id, domain.Part("Sax.")) },
+			expectedError: ErrForeignKey,
+		},
+		{
+			testName:      "異常系_メンバーが登録されたバンドを削除",
+			write:         func() error { return bandRepository.Delete(1, 1, 1) },
+			expectedError: ErrForeignKey,
+		},
+		{
+			testName:      "異常系_出演バンドが登録されたライブを削除",
+			write:         func() error { return liveRepository.Delete(1, 1) },
+			expectedError: ErrForeignKey,
+		},
+	}
+
+	for _, tc := range tests {
+		// when
+		err := tc.write()
+
+		// then
+		assert.True(t, errors.Is(err, tc.expectedError), fmt.Sprintf("テスト名: %s", tc.testName))
+	}
+	// 失敗した書き込みは何も変更しない
+	bands, _ := bandRepository.FindByLiveId(1)
+	assert.Equal(t, []*domain.Band{{Name: "band", LiveId: 1, Turn: 1, Version: 1}}, bands)
+	found, _ := playerRepository.FindById(member.Id)
+	assert.Equal(t, []domain.Part{domain.Dr}, found.Part)
+}
+
+func TestAutoIncrement(t *testing.T) {
+	// given
+	store := NewStore()
+	playerRepository := NewPlayerRepositoryImpl(store)
+	first := &domain.Member{Name: "first"}
+	second := &domain.Member{Name: "second"}
+
+	// when
+	assert.Nil(t, playerRepository.Create(first))
+	assert.Nil(t, playerRepository.Create(second))
+
+	// then
+	assert.Equal(t, 1, first.Id)
+	assert.Equal(t, 2, second.Id)
+}
+
+func TestUnitOfWorkRollback(t *testing.T) {
+	// given
+	store := NewStore()
+	liveRepository := NewLiveRepositoryImpl(store)
+	assert.Nil(t, liveRepository.Create(&domain.Live{Name: "live", Date: date}))
+	unitOfWork := NewUnitOfWorkImpl(store)
+	expectedError := fmt.Errorf("dummy message")
+
+	// when
+	err := unitOfWork.Do(func(repositories *domain.Repositories) error {
+		if err := repositories.Band.Create(&domain.Band{Name: "band", LiveId: 1, Turn: 1}); err != nil {
+			return err
+		}
+		// トランザクションの中では書き込みが見える
+		bands, err := repositories.Band.FindByLiveId(1)
+		if err != nil {
+			return err
+		}
+		assert.Len(t, bands, 1)
+		return expectedError
+	})
+
+	// then
+	assert.Equal(t, expectedError, err)
+	bands, _ := NewBandRepositoryImpl(store).FindByLiveId(1)
+	assert.Empty(t, bands)
+}
+
+func TestUnitOfWorkConcurrent(t *testing.T) {
+	// given
+	store := NewStore()
+	playerRepository := NewPlayerRepositoryImpl(store)
+	unitOfWork := NewUnitOfWorkImpl(store)
+	count := 50
+
+	// when
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			err := unitOfWork.Do(func(repositories *domain.Repositories) error {
+				return repositories.Player.Create(&domain.Member{Name: fmt.Sprintf("player%d", i), Part: []domain.Part{domain.Gt}})
+			})
+			assert.Nil(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	// then
+	gt := domain.Gt
+	players, err := playerRepository.FindByPart(&gt)
+	assert.Nil(t, err)
+	assert.Len(t, players, count)
+	seen := map[int]bool{}
+	for _, player := range players {
+		assert.False(t, seen[player.MemberId], "ID は重複しない")
+		seen[player.MemberId] = true
+	}
+}
+
+func TestUnitOfWorkRetryLimit(t *testing.T) {
+	// given
+	store := NewStore()
+	playerRepository := NewPlayerRepositoryImpl(store)
+	unitOfWork := NewUnitOfWorkImpl(store)
+	attempts := 0
+
+	// when
+	err := unitOfWork.Do(func(repositories *domain.Repositories) error {
+		attempts++
+		// 毎回コミットの前に他の書き込みを行い、競合させる
+		return playerRepository.Create(&domain.Member{Name: fmt.Sprintf("player%d", attempts)})
+	})
+
+	// then
+	assert.True(t, errors.Is(err, domain.ErrConflict))
+	assert.Equal(t, maxAttempts, attempts)
+}
+
+func TestUnitOfWorkFailedWrite(t *testing.T) {
+	// given
+	store := NewStore()
+	liveRepository := NewLiveRepositoryImpl(store)
+	assert.Nil(t, liveRepository.Create(&domain.Live{Name: "live", Date: date}))
+	unitOfWork := NewUnitOfWorkImpl(store)
+
+	// when
+	err := unitOfWork.Do(func(repositories *domain.Repositories) error {
+		if err := repositories.Band.Create(&domain.Band{Name: "band", LiveId: 1, Turn: 1}); err != nil {
+			return err
+		}
+		// 失敗した書き込みのエラーを無視しても、以降の読み書きとコミットは失敗する
+		_ = repositories.Band.Create(&domain.Band{Name: "other", LiveId: 2, Turn: 1})
+		_, err := repositories.Band.FindByLiveId(1)
+		assert.True(t, errors.Is(err, ErrForeignKey))
+		return nil
+	})
+
+	// then
+	assert.True(t, errors.Is(err, ErrForeignKey))
+	bands, _ := NewBandRepositoryImpl(store).FindByLiveId(1)
+	assert.Empty(t, bands)
+}
+
+func TestLoadFixture(t *testing.T) {
+	// given
+	store := NewStore()
+	file, err := os.Open("fixture.json")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer file.Close()
+
+	// when
+	err = LoadFixture(store, file)
+
+	// then
+	assert.Nil(t, err)
+	liveModel, err := NewLiveDescRepositoryImpl(store).FindById(1)
+	assert.Nil(t, err)
+	assert.Len(t, liveModel.Band, 2)
+	assert.Equal(t, 1, liveModel.Band[0].BandId)
+	assert.Equal(t, []*domain.Player{
+		{MemberId: 1, Name: "drummer", Part: domain.Dr},
+		{MemberId: 2, Name: "bassist", Part: domain.Ba},
+		{MemberId: 3, Name: "guitarist", Part: domain.Gt},
+	}, liveModel.Band[0].Player)
+}
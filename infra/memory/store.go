@@ -0,0 +1,359 @@
+package memory
+
+import (
+	"fmt"
+	"live-scheduler/domain"
+	"sync"
+)
+
+var (
+	// ErrDuplicateKey 主キーまたは一意キーが重複する場合のエラー
+	ErrDuplicateKey = fmt.Errorf("%w: duplicate entry", domain.ErrConflict)
+	// ErrForeignKey 参照先が存在しない、または参照されている行を削除・変更しようとした場合のエラー
+	ErrForeignKey = fmt.Errorf("%w: foreign key constraint fails", domain.ErrForeignKeyViolation)
+)
+
+type bandKey struct {
+	liveId int
+	turn   int
+}
+
+type memberPartKey struct {
+	memberId int
+	part     domain.Part
+}
+
+type bandMemberKey struct {
+	liveId   int
+	turn     int
+	memberId int
+	part     domain.Part
+}
+
+type bandProfileMemberKey struct {
+	bandId   int
+	memberId int
+	part     domain.Part
+}
+
+type entryApplicationMemberKey struct {
+	applicationId int
+	memberId      int
+	part          domain.Part
+}
+
+type lineupPartRuleKey struct {
+	liveId int
+	part   domain.Part
+}
+
+// tables README のスキーマと同じ構成のテーブル。Store に公開したテーブルは変更せず、書き込みは複製に対して行う
+type tables struct {
+	live                     map[int]domain.Live
+	band                     map[bandKey]domain.Band
+	bandVersion              map[int]int
+	bandProfile              map[int]string
+	partCatalog              map[domain.Part]domain.PartDefinition
+	member                   map[int]string
+	memberPart               map[memberPartKey]bool
+	bandProfileMember        map[bandProfileMemberKey]bool
+	bandMember               map[bandMemberKey]bool
+	payment                  map[int]domain.Payment
+	lineupRule               map[int]domain.LineupRule
+	lineupPartRule           map[lineupPartRuleKey]domain.PartRule
+	entryApplication         map[int]domain.EntryApplication
+	entryApplicationMember   map[entryApplicationMemberKey]bool
+	liveSequence             int
+	bandProfileSequence      int
+	memberSequence           int
+	paymentSequence          int
+	entryApplicationSequence int
+}
+
+func newTables() *tables {
+	return &tables{
+		live:                   map[int]domain.Live{},
+		band:                   map[bandKey]domain.Band{},
+		bandVersion:            map[int]int{},
+		bandProfile:            map[int]string{},
+		partCatalog:            map[domain.Part]domain.PartDefinition{},
+		member:                 map[int]string{},
+		memberPart:             map[memberPartKey]bool{},
+		bandProfileMember:      map[bandProfileMemberKey]bool{},
+		bandMember:             map[bandMemberKey]bool{},
+		payment:                map[int]domain.Payment{},
+		lineupRule:             map[int]domain.LineupRule{},
+		lineupPartRule:         map[lineupPartRuleKey]domain.PartRule{},
+		entryApplication:       map[int]domain.EntryApplication{},
+		entryApplicationMember: map[entryApplicationMemberKey]bool{},
+	}
+}
+
+// table 書き込みで複製するテーブル
+type table int
+
+const (
+	liveTable table = iota
+	bandTable
+	bandVersionTable
+	bandProfileTable
+	partCatalogTable
+	memberTable
+	memberPartTable
+	bandProfileMemberTable
+	bandMemberTable
+	paymentTable
+	lineupRuleTable
+	lineupPartRuleTable
+	entryApplicationTable
+	entryApplicationMemberTable
+)
+
+// allTables フィクスチャの読み込みなど、全てのテーブルに書き込む場合に使う
+var allTables = []table{
+	liveTable, bandTable, bandVersionTable, bandProfileTable, partCatalogTable, memberTable, memberPartTable, bandProfileMemberTable,
+	bandMemberTable, paymentTable, lineupRuleTable, lineupPartRuleTable, entryApplicationTable, entryApplicationMemberTable,
+}
+
+// clone written のテーブルだけを複製する。他のテーブルは元のテーブルと共有する
+func (t *tables) clone(written ...table) *tables {
+	c := *t
+	for _, w := range written {
+		c.cloneTable(w)
+	}
+	return &c
+}
+
+func (t *tables) cloneTable(w table) {
+	switch w {
+	case liveTable:
+		live := make(map[int]domain.Live, len(t.live))
+		for k, v := range t.live {
+			live[k] = v
+		}
+		t.live = live
+	case bandTable:
+		band := make(map[bandKey]domain.Band, len(t.band))
+		for k, v := range t.band {
+			band[k] = v
+		}
+		t.band = band
+	case bandVersionTable:
+		bandVersion := make(map[int]int, len(t.bandVersion))
+		for k, v := range t.bandVersion {
+			bandVersion[k] = v
+		}
+		t.bandVersion = bandVersion
+	case bandProfileTable:
+		bandProfile := make(map[int]string, len(t.bandProfile))
+		for k, v := range t.bandProfile {
+			bandProfile[k] = v
+		}
+		t.bandProfile = bandProfile
+	case partCatalogTable:
+		partCatalog := make(map[domain.Part]domain.PartDefinition, len(t.partCatalog))
+		for k, v := range t.partCatalog {
+			partCatalog[k] = v
+		}
+		t.partCatalog = partCatalog
+	case memberTable:
+		member := make(map[int]string, len(t.member))
+		for k, v := range t.member {
+			member[k] = v
+		}
+		t.member = member
+	case memberPartTable:
+		memberPart := make(map[memberPartKey]bool, len(t.memberPart))
+		for k, v := range t.memberPart {
+			memberPart[k] = v
+		}
+		t.memberPart = memberPart
+	case bandProfileMemberTable:
+		bandProfileMember := make(map[bandProfileMemberKey]bool, len(t.bandProfileMember))
+		for k, v := range t.bandProfileMember {
+			bandProfileMember[k] = v
+		}
+		t.bandProfileMember = bandProfileMember
+	case bandMemberTable:
+		bandMember := make(map[bandMemberKey]bool, len(t.bandMember))
+		for k, v := range t.bandMember {
+			bandMember[k] = v
+		}
+		t.bandMember = bandMember
+	case paymentTable:
+		payment := make(map[int]domain.Payment, len(t.payment))
+		for k, v := range t.payment {
+			payment[k] = v
+		}
+		t.payment = payment
+	case lineupRuleTable:
+		lineupRule := make(map[int]domain.LineupRule, len(t.lineupRule))
+		for k, v := range t.lineupRule {
+			lineupRule[k] = v
+		}
+		t.lineupRule = lineupRule
+	case lineupPartRuleTable:
+		lineupPartRule := make(map[lineupPartRuleKey]domain.PartRule, len(t.lineupPartRule))
+		for k, v := range t.lineupPartRule {
+			lineupPartRule[k] = v
+		}
+		t.lineupPartRule = lineupPartRule
+	case entryApplicationTable:
+		entryApplication := make(map[int]domain.EntryApplication, len(t.entryApplication))
+		for k, v := range t.entryApplication {
+			entryApplication[k] = v
+		}
+		t.entryApplication = entryApplication
+	case entryApplicationMemberTable:
+		entryApplicationMember := make(map[entryApplicationMemberKey]bool, len(t.entryApplicationMember))
+		for k, v := range t.entryApplicationMember {
+			entryApplicationMember[k] = v
+		}
+		t.entryApplicationMember = entryApplicationMember
+	}
+}
+
+// db テーブルの読み書き。書き込みは SQL の1文と同じく、エラーの場合は何も変更しない。
+// written には fn が変更する全てのテーブルを指定する。指定していないテーブルは変更してはならない
+type db interface {
+	read(fn func(t *tables) error) error
+	write(fn func(t *tables) error, written ...table) error
+}
+
+// Store プロセス内でテーブルを保持する。読み込みは公開済みのテーブルをロックなしで参照し、書き込みは1つずつ行う
+type Store struct {
+	mu      sync.Mutex
+	tables  *tables
+	version int
+}
+
+// NewStore マイグレーションと同じ初期状態のパートを登録した Store を返す
+func NewStore() *Store {
+	t := newTables()
+	for i, part := range []domain.PartDefinition{
+		{Code: domain.Vo, DisplayName: "Vocal", Category: domain.Vocal},
+		{Code: domain.Gt, DisplayName: "Guitar", Category: domain.Melody},
+		{Code: domain.GtVo, DisplayName: "Guitar & Vocal", Category: domain.Vocal},
+		{Code: domain.Key, DisplayName: "Keyboard", Category: domain.Melody},
+		{Code: domain.Ba, DisplayName: "Bass", Category: domain.Rhythm},
+		{Code: domain.Dr, DisplayName: "Drums", Category: domain.Rhythm},
+	} {
+		part.SortOrder = i + 1
+		t.partCatalog[part.Code] = part
+	}
+	return &Store{tables: t}
+}
+
+// snapshot 公開済みのテーブルとそのバージョンを返す
+func (s *Store) snapshot() (*tables, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables, s.version
+}
+
+func (s *Store) read(fn func(t *tables) error) error {
+	t, _ := s.snapshot()
+	return fn(t)
+}
+
+func (s *Store) write(fn func(t *tables) error, written ...table) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	next := s.tables.clone(written...)
+	if err := fn(next); err != nil {
+		return err
+	}
+	s.tables = next
+	s.version++
+	return nil
+}
+
+// commit base のバージョンから他の書き込みがなければ next を公開する
+func (s *Store) commit(next *tables, base int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.version != base {
+		return false
+	}
+	s.tables = next
+	s.version++
+	return true
+}
+
+// transaction UnitOfWork の中で使うテーブル。コミットするまで Store には反映しない。
+// テーブルはトランザクションの中で最初に書き込むときに1度だけ複製する。
+// 書き込みが失敗した後はテーブルが変更途中の可能性があるため、以降の読み書きとコミットは失敗する
+type transaction struct {
+	tables *tables
+	cloned map[table]bool
+	err    error
+}
+
+func newTransaction(base *tables) *transaction {
+	return &transaction{tables: base.clone(), cloned: map[table]bool{}}
+}
+
+func (t *transaction) read(fn func(t *tables) error) error {
+	if t.err != nil {
+		return t.err
+	}
+	return fn(t.tables)
+}
+
+func (t *transaction) write(fn func(t *tables) error, written ...table) error {
+	if t.err != nil {
+		return t.err
+	}
+	for _, w := range written {
+		if !t.cloned[w] {
+			t.tables.cloneTable(w)
+			t.cloned[w] = true
+		}
+	}
+	if err := fn(t.tables); err != nil {
+		t.err = err
+		return err
+	}
+	return nil
+}
+
+// maxAttempts UnitOfWork を他の書き込みと競合してやり直す回数の上限
+const maxAttempts = 100
+
+type UnitOfWorkImpl struct {
+	store *Store
+}
+
+func NewUnitOfWorkImpl(store *Store) *UnitOfWorkImpl {
+	return &UnitOfWorkImpl{store: store}
+}
+
+// Do 開始時点のテーブルに対して fn を実行する。
+// その間に他の書き込みがあった場合は、最新のテーブルで fn をやり直す。
+// やり直しが maxAttempts 回に達した場合は domain.ErrConflict を返す
+func (u *UnitOfWorkImpl) Do(fn func(repositories *domain.Repositories) error) error {
+	for i := 0; i < maxAttempts; i++ {
+		base, version := u.store.snapshot()
+		tx := newTransaction(base)
+		repositories := &domain.Repositories{
+			Live:             &LiveRepositoryImpl{db: tx},
+			Band:             &BandRepositoryImpl{db: tx},
+			BandMember:       &BandMemberRepositoryImpl{db: tx},
+			Player:           &PlayerRepositoryImpl{db: tx},
+			Payment:          &PaymentRepositoryImpl{db: tx},
+			LineupRule:       &LineupRuleRepositoryImpl{db: tx},
+			BandProfile:      &BandProfileRepositoryImpl{db: tx},
+			EntryApplication: &EntryApplicationRepositoryImpl{db: tx},
+		}
+		if err := fn(repositories); err != nil {
+			return err
+		}
+		if tx.err != nil {
+			return tx.err
+		}
+		if u.store.commit(tx.tables, version) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: unit of work retried %d times", domain.ErrConflict, maxAttempts)
+}
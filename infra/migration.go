@@ -0,0 +1,220 @@
+package infra
+
+import (
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// ErrNoMigration 取り消すマイグレーションが適用されていない場合のエラー
+var ErrNoMigration = errors.New("no migration to roll back")
+
+// migrationFileName 0001_initial_schema.up.sql のようにバージョン、名前、方向を並べたファイル名
+var migrationFileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration 1つのバージョンのスキーマ変更
+type Migration struct {
+	// バージョン
+	Version int
+	// 名前
+	Name string
+	// 適用する SQL
+	Up string
+	// 取り消す SQL
+	Down string
+}
+
+// MigrationStatus マイグレーションの適用状況
+type MigrationStatus struct {
+	*Migration
+	// 適用した日時(未適用の場合は nil)
+	AppliedAt *time.Time
+}
+
+// LoadMigrations 埋め込んだマイグレーションをバージョン順に返す
+func LoadMigrations() ([]*Migration, error) {
+	return loadMigrations(migrationFiles, "migrations")
+}
+
+func loadMigrations(fsys fs.FS, dir string) ([]*Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	migrations := map[int]*Migration{}
+	for _, entry := range entries {
+		match := migrationFileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name: %s", entry.Name())
+		}
+		version, err := strconv.Atoi(match[1])
+		if err != nil {
+			return nil, err
+		}
+		content, err := fs.ReadFile(fsys, dir+"/"+entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		migration, ok := migrations[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			migrations[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has different names: %s, %s", version, migration.Name, match[2])
+		}
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	var sorted []*Migration
+	for _, migration := range migrations {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s needs both up and down files", migration.Version, migration.Name)
+		}
+		sorted = append(sorted, migration)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+	return sorted, nil
+}
+
+// Migrator マイグレーションを適用し、適用したバージョンを SchemaMigration テーブルに記録する
+type Migrator struct {
+	db         executor
+	migrations []*Migration
+}
+
+func NewMigrator(db *sql.DB, migrations []*Migration) *Migrator {
+	return &Migrator{db: db, migrations: migrations}
+}
+
+// Up 未適用のマイグレーションをバージョン順にすべて適用し、適用したマイグレーションを返す。
+// MySQL の DDL はトランザクションで取り消せないため、1つのマイグレーションが成功するごとに記録する
+func (m *Migrator) Up() ([]*Migration, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+	var done []*Migration
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+		if err := m.execute(migration.Up); err != nil {
+			return done, fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		_, err := m.db.Exec(`INSERT INTO SchemaMigration(version, name, applied_at) VALUES ( ?, ?, ? )`,
+			migration.Version, migration.Name, time.Now().Format("2006-01-02 15:04:05"))
+		if err != nil {
+			return done, err
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// Down 最後に適用したマイグレーションを1つ取り消し、取り消したマイグレーションを返す
+func (m *Migrator) Down() (*Migration, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+		if err := m.execute(migration.Down); err != nil {
+			return nil, fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		if _, err := m.db.Exec(`DELETE FROM SchemaMigration WHERE version = ?`, migration.Version); err != nil {
+			return nil, err
+		}
+		return migration, nil
+	}
+	return nil, ErrNoMigration
+}
+
+// Status すべてのマイグレーションの適用状況をバージョン順に返す
+func (m *Migrator) Status() ([]*MigrationStatus, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+	var statuses []*MigrationStatus
+	for _, migration := range m.migrations {
+		status := &MigrationStatus{Migration: migration}
+		if appliedAt, ok := applied[migration.Version]; ok {
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// applied 適用済みのバージョンと適用日時を返す。SchemaMigration テーブルがなければ作成する
+func (m *Migrator) applied() (map[int]time.Time, error) {
+	_, err := m.db.Exec(`CREATE TABLE IF NOT EXISTS SchemaMigration ( version INT PRIMARY KEY, name VARCHAR(255) NOT NULL, applied_at DATETIME NOT NULL )`)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := m.db.Query(`SELECT version, applied_at FROM SchemaMigration`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	applied := map[int]time.Time{}
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version] = appliedAt
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return applied, nil
+}
+
+// execute SQL を文ごとに実行する。ドライバの multiStatements を有効にしなくて済むように ; で分割する
+func (m *Migrator) execute(script string) error {
+	for _, statement := range splitStatements(script) {
+		if _, err := m.db.Exec(statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitStatements -- で始まるコメント行を除き、; で終わる文に分割する。文字列リテラル中の ; は考慮しない
+func splitStatements(script string) []string {
+	var lines []string
+	for _, line := range strings.Split(script, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "--") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	var statements []string
+	for _, statement := range strings.Split(strings.Join(lines, "\n"), ";") {
+		if statement = strings.TrimSpace(statement); statement != "" {
+			statements = append(statements, statement)
+		}
+	}
+	return statements
+}
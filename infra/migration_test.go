@@ -0,0 +1,174 @@
+package infra
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+var testMigrations = []*Migration{
+	{Version: 1, Name: "first", Up: "CREATE TABLE A ( id INT );\n-- comment\nCREATE TABLE B ( id INT );", Down: "DROP TABLE B;\nDROP TABLE A;"},
+	{Version: 2, Name: "second", Up: "ALTER TABLE A ADD name VARCHAR(50);", Down: "ALTER TABLE A DROP name;"},
+}
+
+const createSchemaMigration = "CREATE TABLE IF NOT EXISTS SchemaMigration"
+
+func TestLoadMigrations(t *testing.T) {
+	// when
+	migrations, err := LoadMigrations()
+
+	// then
+	assert.Nil(t, err)
+	assert.NotEmpty(t, migrations)
+	createTable := regexp.MustCompile(`CREATE TABLE (?:IF NOT EXISTS )?(\w+)`)
+	renameTable := regexp.MustCompile(`RENAME TABLE (\w+) TO (\w+)`)
+	for i, migration := range migrations {
+		assert.Equal(t, i+1, migration.Version, "バージョンは 1 から連番にする")
+		renamed := map[string]string{}
+		for _, match := range renameTable.FindAllStringSubmatch(migration.Up, -1) {
+			renamed[match[1]] = match[2]
+		}
+		// 作成したテーブルは取り消し時に削除する。作成して名前を変えたテーブルは変えた後の名前で削除する
+		for _, match := range createTable.FindAllStringSubmatch(migration.Up, -1) {
+			table := match[1]
+			if name, ok := renamed[table]; ok {
+				table = name
+			}
+			assert.Contains(t, migration.Down, "DROP TABLE "+table+";", migration.Name)
+		}
+	}
+}
+
+func TestLoadMigrationsInvalid(t *testing.T) {
+	// given
+	tests := []struct {
+		// テスト名
+		testName string
+		// マイグレーションのファイル
+		files fstest.MapFS
+	}{
+		{
+			testName: "異常系_downがない",
+			files:    fstest.MapFS{"migrations/0001_first.up.sql": {Data: []byte("CREATE TABLE A ( id INT );")}},
+		},
+		{
+			testName: "異常系_ファイル名の形式が違う",
+			files:    fstest.MapFS{"migrations/first.sql": {Data: []byte("CREATE TABLE A ( id INT );")}},
+		},
+	}
+
+	for _, tc := range tests {
+		// when
+		_, err := loadMigrations(tc.files, "migrations")
+
+		// then
+		assert.NotNil(t, err, tc.testName)
+	}
+}
+
+func TestMigratorUp(t *testing.T) {
+	// given
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Error(err.Error())
+	}
+	defer db.Close()
+	mock.ExpectExec(regexp.QuoteMeta(createSchemaMigration)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT version, applied_at FROM SchemaMigration")).
+		WillReturnRows(sqlmock.NewRows([]string{"version", "applied_at"}).AddRow(1, time.Now()))
+	mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE A ADD name VARCHAR(50)")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO SchemaMigration(version, name, applied_at) VALUES ( ?, ?, ? )")).
+		WithArgs(2, "second", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	migrator := NewMigrator(db, testMigrations)
+
+	// when
+	applied, err := migrator.Up()
+
+	// then
+	assert.Equal(t, []*Migration{testMigrations[1]}, applied)
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestMigratorDown(t *testing.T) {
+	// given
+	tests := []struct {
+		// テスト名
+		testName string
+		// 適用済みのバージョン
+		applied []int
+		// 戻り値の期待値
+		expected *Migration
+		// 戻り値の期待値(error)
+		expectedError error
+	}{
+		{
+			testName: "正常系",
+			applied:  []int{1},
+			expected: testMigrations[0],
+		},
+		{
+			testName:      "異常系_適用済みのマイグレーションがない",
+			applied:       []int{},
+			expectedError: ErrNoMigration,
+		},
+	}
+
+	for _, tc := range tests {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Error(err.Error())
+		}
+		rows := sqlmock.NewRows([]string{"version", "applied_at"})
+		for _, version := range tc.applied {
+			rows.AddRow(version, time.Now())
+		}
+		mock.ExpectExec(regexp.QuoteMeta(createSchemaMigration)).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT version, applied_at FROM SchemaMigration")).WillReturnRows(rows)
+		if tc.expected != nil {
+			mock.ExpectExec(regexp.QuoteMeta("DROP TABLE B")).WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(regexp.QuoteMeta("DROP TABLE A")).WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(regexp.QuoteMeta("DELETE FROM SchemaMigration WHERE version = ?")).
+				WithArgs(1).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+		}
+		migrator := NewMigrator(db, testMigrations)
+
+		// when
+		actual, err := migrator.Down()
+
+		// then
+		assert.Equal(t, tc.expected, actual, tc.testName)
+		assert.Equal(t, tc.expectedError, err, tc.testName)
+		assert.Nil(t, mock.ExpectationsWereMet(), tc.testName)
+		db.Close()
+	}
+}
+
+func TestMigratorStatus(t *testing.T) {
+	// given
+	appliedAt := time.Date(2022, 1, 3, 12, 0, 0, 0, time.UTC)
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Error(err.Error())
+	}
+	defer db.Close()
+	mock.ExpectExec(regexp.QuoteMeta(createSchemaMigration)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT version, applied_at FROM SchemaMigration")).
+		WillReturnRows(sqlmock.NewRows([]string{"version", "applied_at"}).AddRow(1, appliedAt))
+	migrator := NewMigrator(db, testMigrations)
+
+	// when
+	actual, err := migrator.Status()
+
+	// then
+	assert.Equal(t, []*MigrationStatus{
+		{Migration: testMigrations[0], AppliedAt: &appliedAt},
+		{Migration: testMigrations[1]},
+	}, actual)
+	assert.Nil(t, err)
+}
@@ -0,0 +1,4 @@
+DROP TABLE BandMember;
+DROP TABLE Player;
+DROP TABLE Band;
+DROP TABLE Live;
@@ -0,0 +1,5 @@
+-- マイグレーション導入前に README の手順で作成したデータベースでは、既存のテーブルをそのまま使う
+CREATE TABLE IF NOT EXISTS Live ( id SERIAL PRIMARY KEY, name VARCHAR(50), location VARCHAR(50), date DATE, performance_fee INT, equipment_cost INT );
+CREATE TABLE IF NOT EXISTS Band ( name VARCHAR(50), live_id BIGINT UNSIGNED NOT NULL, turn INT, PRIMARY KEY (live_id, turn), FOREIGN KEY (live_id) REFERENCES Live(id) );
+CREATE TABLE IF NOT EXISTS Player ( name VARCHAR(50), part ENUM('Vo.', 'Gt.', 'Gt.Vo.', 'Key.', 'Ba.', 'Dr.'), PRIMARY KEY (name, part) );
+CREATE TABLE IF NOT EXISTS BandMember ( live_id BIGINT UNSIGNED NOT NULL, turn INT, member_name VARCHAR(50), member_part ENUM('Vo.', 'Gt.', 'Gt.Vo.', 'Key.', 'Ba.', 'Dr.'), PRIMARY KEY(live_id, turn, member_name, member_part), FOREIGN KEY (live_id, turn) REFERENCES Band(live_id, turn), FOREIGN KEY (member_name, member_part) REFERENCES Player(name, part) ON UPDATE CASCADE );
@@ -0,0 +1,2 @@
+ALTER TABLE Band DROP COLUMN set_length;
+ALTER TABLE Live DROP COLUMN changeover, DROP COLUMN close_time, DROP COLUMN start_time, DROP COLUMN open_time;
@@ -0,0 +1,2 @@
+ALTER TABLE Live ADD open_time TIME NULL, ADD start_time TIME NULL, ADD close_time TIME NULL, ADD changeover INT NOT NULL DEFAULT 0;
+ALTER TABLE Band ADD set_length INT NOT NULL DEFAULT 0;
@@ -0,0 +1,4 @@
+CREATE TABLE PartCatalog ( code VARCHAR(20) PRIMARY KEY, display_name VARCHAR(50) NOT NULL, category ENUM('rhythm', 'melody', 'vocal') NOT NULL, sort_order INT NOT NULL DEFAULT 0 );
+
+-- 初期状態のパート。Player テーブルの ENUM と同じコードにする
+INSERT INTO PartCatalog VALUES ('Vo.', 'Vocal', 'vocal', 1), ('Gt.', 'Guitar', 'melody', 2), ('Gt.Vo.', 'Guitar & Vocal', 'vocal', 3), ('Key.', 'Keyboard', 'melody', 4), ('Ba.', 'Bass', 'rhythm', 5), ('Dr.', 'Drums', 'rhythm', 6);
@@ -0,0 +1,14 @@
+-- カタログに追加したパートは Player テーブルの ENUM にないため、そのパートを使っている場合は戻せない
+CREATE TABLE Player ( name VARCHAR(50), part ENUM('Vo.', 'Gt.', 'Gt.Vo.', 'Key.', 'Ba.', 'Dr.'), PRIMARY KEY (name, part) );
+-- 出演履歴のパートも BandMember から参照できるよう Player に含める
+INSERT INTO Player(name, part)
+  SELECT Member.name, MemberPart.part FROM Member JOIN MemberPart ON MemberPart.member_id = Member.id
+  UNION SELECT Member.name, BandMember.member_part FROM BandMember JOIN Member ON Member.id = BandMember.member_id;
+
+CREATE TABLE BandMemberByName ( live_id BIGINT UNSIGNED NOT NULL, turn INT, member_name VARCHAR(50), member_part ENUM('Vo.', 'Gt.', 'Gt.Vo.', 'Key.', 'Ba.', 'Dr.'), PRIMARY KEY(live_id, turn, member_name, member_part), FOREIGN KEY (live_id, turn) REFERENCES Band(live_id, turn), FOREIGN KEY (member_name, member_part) REFERENCES Player(name, part) ON UPDATE CASCADE );
+INSERT INTO BandMemberByName(live_id, turn, member_name, member_part)
+  SELECT BandMember.live_id, BandMember.turn, Member.name, BandMember.member_part FROM BandMember JOIN Member ON Member.id = BandMember.member_id;
+DROP TABLE BandMember;
+RENAME TABLE BandMemberByName TO BandMember;
+DROP TABLE MemberPart;
+DROP TABLE Member;
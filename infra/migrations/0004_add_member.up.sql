@@ -0,0 +1,14 @@
+CREATE TABLE Member ( id SERIAL PRIMARY KEY, name VARCHAR(50) NOT NULL UNIQUE );
+CREATE TABLE MemberPart ( member_id BIGINT UNSIGNED NOT NULL, part VARCHAR(20) NOT NULL, PRIMARY KEY (member_id, part), FOREIGN KEY (member_id) REFERENCES Member(id) ON DELETE CASCADE, FOREIGN KEY (part) REFERENCES PartCatalog(code) );
+
+-- Player の名前ごとにメンバーを1人登録し、担当できるパートを移す
+INSERT INTO Member(name) SELECT DISTINCT name FROM Player ORDER BY name;
+INSERT INTO MemberPart(member_id, part) SELECT Member.id, Player.part FROM Player JOIN Member ON Member.name = Player.name;
+
+-- BandMember は名前の代わりにメンバーの ID を参照する。出演したときのパートはそのまま出演履歴として残す
+CREATE TABLE BandMemberById ( live_id BIGINT UNSIGNED NOT NULL, turn INT, member_id BIGINT UNSIGNED NOT NULL, member_part VARCHAR(20) NOT NULL, PRIMARY KEY(live_id, turn, member_id, member_part), FOREIGN KEY (live_id, turn) REFERENCES Band(live_id, turn), FOREIGN KEY (member_id) REFERENCES Member(id), FOREIGN KEY (member_part) REFERENCES PartCatalog(code) );
+INSERT INTO BandMemberById(live_id, turn, member_id, member_part)
+  SELECT BandMember.live_id, BandMember.turn, Member.id, BandMember.member_part FROM BandMember JOIN Member ON Member.name = BandMember.member_name;
+DROP TABLE BandMember;
+RENAME TABLE BandMemberById TO BandMember;
+DROP TABLE Player;
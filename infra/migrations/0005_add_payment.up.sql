@@ -0,0 +1 @@
+CREATE TABLE Payment ( id SERIAL PRIMARY KEY, live_id BIGINT UNSIGNED NOT NULL, member_id BIGINT UNSIGNED NOT NULL, kind ENUM('payment', 'refund') NOT NULL, method ENUM('cash', 'transfer', 'other') NOT NULL, amount INT NOT NULL, paid_at DATETIME NOT NULL, note VARCHAR(255) NOT NULL DEFAULT '', FOREIGN KEY (live_id) REFERENCES Live(id) ON DELETE CASCADE, FOREIGN KEY (member_id) REFERENCES Member(id) );
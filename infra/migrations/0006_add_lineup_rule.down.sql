@@ -0,0 +1,2 @@
+DROP TABLE LineupPartRule;
+DROP TABLE LineupRule;
@@ -0,0 +1,2 @@
+CREATE TABLE LineupRule ( live_id BIGINT UNSIGNED NOT NULL PRIMARY KEY, min_members INT NOT NULL DEFAULT 0, max_members INT NOT NULL DEFAULT 0, gtvo_counts_as_vo BOOLEAN NOT NULL DEFAULT TRUE, gtvo_counts_as_gt BOOLEAN NOT NULL DEFAULT TRUE, FOREIGN KEY (live_id) REFERENCES Live(id) ON DELETE CASCADE );
+CREATE TABLE LineupPartRule ( live_id BIGINT UNSIGNED NOT NULL, part VARCHAR(20) NOT NULL, min_count INT NOT NULL DEFAULT 0, max_count INT NOT NULL DEFAULT 0, PRIMARY KEY (live_id, part), FOREIGN KEY (live_id) REFERENCES Live(id) ON DELETE CASCADE, FOREIGN KEY (part) REFERENCES PartCatalog(code) );
@@ -0,0 +1,4 @@
+DROP TABLE BandProfileMember;
+ALTER TABLE Band DROP FOREIGN KEY Band_band_id_fk;
+ALTER TABLE Band DROP COLUMN band_id;
+DROP TABLE BandProfile;
@@ -0,0 +1,3 @@
+CREATE TABLE BandProfile ( id SERIAL PRIMARY KEY, name VARCHAR(50) NOT NULL );
+ALTER TABLE Band ADD band_id BIGINT UNSIGNED NULL, ADD CONSTRAINT Band_band_id_fk FOREIGN KEY (band_id) REFERENCES BandProfile(id);
+CREATE TABLE BandProfileMember ( band_id BIGINT UNSIGNED NOT NULL, member_id BIGINT UNSIGNED NOT NULL, member_part VARCHAR(20) NOT NULL, PRIMARY KEY (band_id, member_id, member_part), FOREIGN KEY (band_id) REFERENCES BandProfile(id) ON DELETE CASCADE, FOREIGN KEY (member_id) REFERENCES Member(id), FOREIGN KEY (member_part) REFERENCES PartCatalog(code) );
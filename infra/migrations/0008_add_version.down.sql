@@ -0,0 +1,2 @@
+ALTER TABLE Band DROP COLUMN version;
+ALTER TABLE Live DROP COLUMN version;
@@ -0,0 +1,2 @@
+ALTER TABLE Live ADD version INT NOT NULL DEFAULT 1;
+ALTER TABLE Band ADD version INT NOT NULL DEFAULT 1;
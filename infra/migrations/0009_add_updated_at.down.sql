@@ -0,0 +1 @@
+ALTER TABLE Live DROP COLUMN updated_at;
@@ -0,0 +1 @@
+ALTER TABLE Live ADD updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP;
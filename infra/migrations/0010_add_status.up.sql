@@ -0,0 +1 @@
+ALTER TABLE Live ADD status VARCHAR(20) NOT NULL DEFAULT 'draft';
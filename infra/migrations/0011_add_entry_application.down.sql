@@ -0,0 +1,2 @@
+DROP TABLE EntryApplicationMember;
+DROP TABLE EntryApplication;
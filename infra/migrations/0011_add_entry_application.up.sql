@@ -0,0 +1,2 @@
+CREATE TABLE EntryApplication ( id SERIAL PRIMARY KEY, live_id BIGINT UNSIGNED NOT NULL, name VARCHAR(50) NOT NULL, band_id BIGINT UNSIGNED NULL, preferred_turn INT NOT NULL DEFAULT 0, set_length INT NOT NULL DEFAULT 0, note VARCHAR(255) NOT NULL DEFAULT '', status ENUM('pending', 'waitlisted', 'approved', 'rejected') NOT NULL DEFAULT 'pending', turn INT NULL, applied_at DATETIME NOT NULL, FOREIGN KEY (live_id) REFERENCES Live(id) ON DELETE CASCADE, FOREIGN KEY (band_id) REFERENCES BandProfile(id) );
+CREATE TABLE EntryApplicationMember ( application_id BIGINT UNSIGNED NOT NULL, member_id BIGINT UNSIGNED NOT NULL, member_part VARCHAR(20) NOT NULL, PRIMARY KEY (application_id, member_id, member_part), FOREIGN KEY (application_id) REFERENCES EntryApplication(id) ON DELETE CASCADE, FOREIGN KEY (member_id) REFERENCES Member(id), FOREIGN KEY (member_part) REFERENCES PartCatalog(code) );
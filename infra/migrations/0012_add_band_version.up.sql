@@ -0,0 +1,2 @@
+ALTER TABLE Live ADD band_version INT NOT NULL DEFAULT 0;
+UPDATE Live SET band_version = ( SELECT COALESCE(MAX(Band.version), 0) FROM Band WHERE Band.live_id = Live.id );
@@ -2,204 +2,1107 @@ package infra
 
 import (
 	"database/sql"
+	"fmt"
 	"live-scheduler/domain"
+	"strings"
 	"time"
 )
 
 const LAYOUT = "2006-01-02"
 
+// executor *sql.DB と *sql.Tx の共通部分
+type executor interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
 type LiveRepositoryImpl struct {
-	db *sql.DB
+	db executor
 }
 
 func NewLiveRepositoryImpl(db *sql.DB) *LiveRepositoryImpl {
 	return &LiveRepositoryImpl{db: db}
 }
 
-func (i *LiveRepositoryImpl) FindById(id int) (*domain.Live, error) {
+const liveColumns = `id, name, location, date, performance_fee, equipment_cost, open_time, start_time, close_time, changeover, version, updated_at, status`
+
+// scanner *sql.Row と *sql.Rows の共通部分
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanLive(row scanner) (*domain.Live, error) {
 	var live domain.Live
-	err := i.db.QueryRow(`SELECT * FROM Live WHERE id = ?`, id).
-		Scan(&live.Id, &live.Name, &live.Location, &live.Date, &live.PerformanceFee, &live.EquipmentCost)
+	var openTime, startTime, closeTime sql.NullString
+	err := row.Scan(&live.Id, &live.Name, &live.Location, &live.Date, &live.PerformanceFee, &live.EquipmentCost,
+		&openTime, &startTime, &closeTime, &live.Changeover, &live.Version, &live.UpdatedAt, &live.Status)
 	if err != nil {
 		return nil, err
 	}
+	if live.OpenTime, live.StartTime, live.CloseTime, err = parseClocks(openTime, startTime, closeTime); err != nil {
+		return nil, err
+	}
 	return &live, nil
 }
 
+// parseClocks TIME 型のカラムから読み込んだ開場・開演・終演時刻を Clock に変換する
+func parseClocks(openTime sql.NullString, startTime sql.NullString, closeTime sql.NullString) (domain.Clock, domain.Clock, domain.Clock, error) {
+	open, err := domain.ParseClock(openTime.String)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	start, err := domain.ParseClock(startTime.String)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	closing, err := domain.ParseClock(closeTime.String)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return open, start, closing, nil
+}
+
+// clockValue Clock を TIME 型のカラムに書き込む値に変換する。未設定の場合は NULL とする
+func clockValue(clock domain.Clock) interface{} {
+	if clock == 0 {
+		return nil
+	}
+	return clock.String() + ":00"
+}
+
+func (i *LiveRepositoryImpl) FindById(id int) (*domain.Live, error) {
+	live, err := scanLive(i.db.QueryRow(`SELECT `+liveColumns+` FROM Live WHERE id = ?`, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: live %d", domain.ErrNotFound, id)
+	}
+	return live, err
+}
+
+func (i *LiveRepositoryImpl) FindByIdForUpdate(id int) (*domain.Live, error) {
+	live, err := scanLive(i.db.QueryRow(`SELECT `+liveColumns+` FROM Live WHERE id = ? FOR UPDATE`, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: live %d", domain.ErrNotFound, id)
+	}
+	return live, err
+}
+
 func (i *LiveRepositoryImpl) FindByPeriod(start *time.Time, end *time.Time) ([]*domain.Live, error) {
 	rows, err := i.db.Query(
-		`SELECT * FROM Live WHERE date >= ? AND date <= ?`,
+		`SELECT `+liveColumns+` FROM Live WHERE date >= ? AND date <= ? ORDER BY date, id`,
 		start.Format(LAYOUT), end.Format(LAYOUT))
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 	var lives []*domain.Live
 	for rows.Next() {
-		var id, performanceFee, equipmentCost int
-		var name, location string
-		var date time.Time
-
-		err = rows.Scan(&id, &name, &location, &date, &performanceFee, &equipmentCost)
-		lives = append(lives, &domain.Live{
-			Id:             id,
-			Name:           name,
-			Location:       location,
-			Date:           date,
-			PerformanceFee: performanceFee,
-			EquipmentCost:  equipmentCost,
-		})
+		live, err := scanLive(rows)
+		if err != nil {
+			return nil, err
+		}
+		lives = append(lives, live)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
 	return lives, nil
 }
 
 func (i *LiveRepositoryImpl) Create(live *domain.Live) error {
 	_, err := i.db.Exec(
-		`INSERT INTO Live(name, location, date, performance_fee, equipment_cost) VALUES ( ?, ?, ?, ?, ? )`,
-		live.Name, live.Location, live.Date.Format(LAYOUT), live.PerformanceFee, live.EquipmentCost)
-	return err
+		`INSERT INTO Live(name, location, date, performance_fee, equipment_cost, open_time, start_time, close_time, changeover, status) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ? )`,
+		live.Name, live.Location, live.Date.Format(LAYOUT), live.PerformanceFee, live.EquipmentCost,
+		clockValue(live.OpenTime), clockValue(live.StartTime), clockValue(live.CloseTime), live.Changeover, live.Status)
+	return translateError(err)
 }
 
-func (i *LiveRepositoryImpl) Update(live *domain.Live) error {
-	_, err := i.db.Exec(
-		`UPDATE Live SET name = ?, location = ?, date = ?, performance_fee = ?, equipment_cost = ? WHERE id = ?`,
-		live.Name, live.Location, live.Date.Format(LAYOUT), live.PerformanceFee, live.EquipmentCost, live.Id)
-	return err
+// assignments UPDATE 文の SET 句。指定されたカラムだけを更新するために使う
+type assignments struct {
+	columns []string
+	args    []interface{}
+}
+
+func (a *assignments) set(column string, value interface{}) {
+	a.columns = append(a.columns, column+" = ?")
+	a.args = append(a.args, value)
+}
+
+// increment column を 1 増やす
+func (a *assignments) increment(column string) {
+	a.columns = append(a.columns, column+" = "+column+" + 1")
+}
+
+// now column を現在時刻にする
+func (a *assignments) now(column string) {
+	a.columns = append(a.columns, column+" = CURRENT_TIMESTAMP")
+}
+
+func (a *assignments) String() string {
+	return strings.Join(a.columns, ", ")
+}
+
+// checkVersion バージョンを条件にした UPDATE・DELETE の結果を確認する。対象の行が変更されなかった場合は
+// exists で行の有無を数え、存在しなければ ErrNotFound、存在すればバージョンが異なるとして ErrPreconditionFailed を返す
+func checkVersion(db executor, result sql.Result, target string, exists string, args ...interface{}) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected > 0 {
+		return nil
+	}
+	var count int
+	if err := db.QueryRow(exists, args...).Scan(&count); err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("%w: %s", domain.ErrNotFound, target)
+	}
+	return fmt.Errorf("%w: %s", domain.ErrPreconditionFailed, target)
+}
+
+func (i *LiveRepositoryImpl) Patch(id int, version int, patch *domain.LivePatch) error {
+	var a assignments
+	if patch.Name != nil {
+		a.set("name", *patch.Name)
+	}
+	if patch.Location != nil {
+		a.set("location", *patch.Location)
+	}
+	if patch.Date != nil {
+		a.set("date", patch.Date.Format(LAYOUT))
+	}
+	if patch.PerformanceFee != nil {
+		a.set("performance_fee", *patch.PerformanceFee)
+	}
+	if patch.EquipmentCost != nil {
+		a.set("equipment_cost", *patch.EquipmentCost)
+	}
+	if patch.OpenTime != nil {
+		a.set("open_time", clockValue(*patch.OpenTime))
+	}
+	if patch.StartTime != nil {
+		a.set("start_time", clockValue(*patch.StartTime))
+	}
+	if patch.CloseTime != nil {
+		a.set("close_time", clockValue(*patch.CloseTime))
+	}
+	if patch.Changeover != nil {
+		a.set("changeover", *patch.Changeover)
+	}
+	if patch.Status != nil {
+		a.set("status", *patch.Status)
+	}
+	a.increment("version")
+	a.now("updated_at")
+	result, err := i.db.Exec(`UPDATE Live SET `+a.String()+` WHERE id = ? AND version = ?`, append(a.args, id, version)...)
+	if err != nil {
+		return translateError(err)
+	}
+	return checkVersion(i.db, result, fmt.Sprintf("live %d", id), `SELECT COUNT(*) FROM Live WHERE id = ?`, id)
+}
+
+func (i *LiveRepositoryImpl) Touch(id int) error {
+	_, err := i.db.Exec(`UPDATE Live SET version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE id = ?`, id)
+	return translateError(err)
+}
+
+func (i *LiveRepositoryImpl) Delete(id int, version int) error {
+	result, err := i.db.Exec(`DELETE FROM Live WHERE id = ? AND version = ?`, id, version)
+	if err != nil {
+		return translateError(err)
+	}
+	return checkVersion(i.db, result, fmt.Sprintf("live %d", id), `SELECT COUNT(*) FROM Live WHERE id = ?`, id)
+}
+
+type LiveDescRepositoryImpl struct {
+	db executor
+}
+
+func NewLiveDescRepositoryImpl(db *sql.DB) *LiveDescRepositoryImpl {
+	return &LiveDescRepositoryImpl{db: db}
+}
+
+// liveDescColumns ライブ・出演バンド・メンバーを1行ずつ結合する。バンドやメンバーがいない場合は該当のカラムが NULL になる
+const liveDescColumns = `Live.id, Live.name, Live.location, Live.date, Live.performance_fee, Live.equipment_cost, ` +
+	`Live.open_time, Live.start_time, Live.close_time, Live.changeover, Live.version, Live.updated_at, Live.status, ` +
+	`COALESCE(BandProfile.name, Band.name), Band.turn, Band.set_length, Band.band_id, Band.version, ` +
+	`BandMember.member_id, Member.name, BandMember.member_part ` +
+	`FROM Live ` +
+	`LEFT JOIN Band ON Band.live_id = Live.id ` +
+	`LEFT JOIN BandProfile ON BandProfile.id = Band.band_id ` +
+	`LEFT JOIN BandMember ON BandMember.live_id = Band.live_id AND BandMember.turn = Band.turn ` +
+	`LEFT JOIN Member ON Member.id = BandMember.member_id`
+
+const liveDescOrder = ` ORDER BY Live.date, Live.id, Band.turn, BandMember.member_id, BandMember.member_part`
+
+func (l *LiveDescRepositoryImpl) FindById(id int) (*domain.LiveModel, error) {
+	liveModels, err := l.findLiveModels(`SELECT `+liveDescColumns+` WHERE Live.id = ?`+liveDescOrder, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(liveModels) == 0 {
+		return nil, fmt.Errorf("%w: live %d", domain.ErrNotFound, id)
+	}
+	return liveModels[0], nil
+}
+
+func (l *LiveDescRepositoryImpl) FindByPeriod(start *time.Time, end *time.Time) ([]*domain.LiveModel, error) {
+	return l.findLiveModels(`SELECT `+liveDescColumns+` WHERE Live.date >= ? AND Live.date <= ?`+liveDescOrder,
+		start.Format(LAYOUT), end.Format(LAYOUT))
 }
 
-func (i *LiveRepositoryImpl) Delete(id int) error {
-	_, err := i.db.Exec(`DELETE FROM Live WHERE id = ?`, id)
-	return err
+// findLiveModels ライブ・出演順の順に並んだ結合結果を LiveModel にまとめる
+func (l *LiveDescRepositoryImpl) findLiveModels(query string, args ...interface{}) ([]*domain.LiveModel, error) {
+	rows, err := l.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var liveModels []*domain.LiveModel
+	var liveModel *domain.LiveModel
+	var bandModel *domain.BandModel
+	for rows.Next() {
+		var live domain.LiveModel
+		var openTime, startTime, closeTime sql.NullString
+		var bandName, memberName, memberPart sql.NullString
+		var turn, setLength, bandId, bandVersion, memberId sql.NullInt64
+		err := rows.Scan(&live.Id, &live.Name, &live.Location, &live.Date, &live.PerformanceFee, &live.EquipmentCost,
+			&openTime, &startTime, &closeTime, &live.Changeover, &live.Version, &live.UpdatedAt, &live.Status,
+			&bandName, &turn, &setLength, &bandId, &bandVersion, &memberId, &memberName, &memberPart)
+		if err != nil {
+			return nil, err
+		}
+
+		if liveModel == nil || liveModel.Id != live.Id {
+			if live.OpenTime, live.StartTime, live.CloseTime, err = parseClocks(openTime, startTime, closeTime); err != nil {
+				return nil, err
+			}
+			liveModel = &live
+			bandModel = nil
+			liveModels = append(liveModels, liveModel)
+		}
+		if !turn.Valid {
+			continue
+		}
+		if bandModel == nil || bandModel.Turn != int(turn.Int64) {
+			bandModel = &domain.BandModel{
+				Name:      bandName.String,
+				LiveId:    liveModel.Id,
+				Turn:      int(turn.Int64),
+				SetLength: int(setLength.Int64),
+				BandId:    int(bandId.Int64),
+				Version:   int(bandVersion.Int64),
+			}
+			liveModel.Band = append(liveModel.Band, bandModel)
+		}
+		if !memberId.Valid {
+			continue
+		}
+		bandModel.Player = append(bandModel.Player, &domain.Player{MemberId: int(memberId.Int64), Name: memberName.String, Part: domain.Part(memberPart.String)})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return liveModels, nil
 }
 
 type BandRepositoryImpl struct {
-	db *sql.DB
+	db executor
 }
 
 func NewBandRepositoryImpl(db *sql.DB) *BandRepositoryImpl {
 	return &BandRepositoryImpl{db: db}
 }
 
+// bandColumns バンドプロフィールに紐づく出演バンドはプロフィールのバンド名で返す
+const bandColumns = `COALESCE(BandProfile.name, Band.name), Band.live_id, Band.turn, Band.set_length, Band.band_id, Band.version ` +
+	`FROM Band LEFT JOIN BandProfile ON BandProfile.id = Band.band_id`
+
 func (b *BandRepositoryImpl) FindByLiveId(id int) ([]*domain.Band, error) {
-	rows, err := b.db.Query(`SELECT * FROM Band WHERE live_id = ?`, id)
+	return b.findBands(`SELECT `+bandColumns+` WHERE Band.live_id = ? ORDER BY Band.turn`, id)
+}
+
+func (b *BandRepositoryImpl) FindByBandId(id int) ([]*domain.Band, error) {
+	return b.findBands(`SELECT `+bandColumns+` WHERE Band.band_id = ? ORDER BY Band.live_id, Band.turn`, id)
+}
+
+func (b *BandRepositoryImpl) findBands(query string, args ...interface{}) ([]*domain.Band, error) {
+	rows, err := b.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 	var bands []*domain.Band
 	for rows.Next() {
 		var name string
-		var liveId, turn int
+		var liveId, turn, setLength, version int
+		var bandId sql.NullInt64
 
-		err = rows.Scan(&name, &liveId, &turn)
+		err = rows.Scan(&name, &liveId, &turn, &setLength, &bandId, &version)
 		if err != nil {
 			return nil, err
 		}
-		band := domain.Band{Name: name, LiveId: liveId, Turn: turn}
+		band := domain.Band{Name: name, LiveId: liveId, Turn: turn, SetLength: setLength, BandId: int(bandId.Int64), Version: version}
 		bands = append(bands, &band)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 	return bands, nil
 }
 
+// idValue 外部キーのカラムに書き込む値に変換する。0 の場合は NULL とする
+func idValue(id int) interface{} {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
+
+// nextVersion ライブの Live.band_version を 1 増やして返す。LAST_INSERT_ID(expr) により、増やした値を同じ文の結果として受け取る。
+// ライブが存在しない場合は 0 を返し、続く書き込みが外部キーまたは行の有無で失敗する
+func (b *BandRepositoryImpl) nextVersion(liveId int) (int, error) {
+	result, err := b.db.Exec(`UPDATE Live SET band_version = LAST_INSERT_ID(band_version + 1) WHERE id = ?`, liveId)
+	if err != nil {
+		return 0, translateError(err)
+	}
+	version, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(version), nil
+}
+
 func (b *BandRepositoryImpl) Create(band *domain.Band) error {
-	_, err := b.db.Exec(
-		`INSERT INTO Band(name, live_id, turn) VALUES ( ?, ?, ? )`,
-		band.Name, band.LiveId, band.Turn)
-	return err
+	version, err := b.nextVersion(band.LiveId)
+	if err != nil {
+		return err
+	}
+	_, err = b.db.Exec(
+		`INSERT INTO Band(name, live_id, turn, set_length, band_id, version) VALUES ( ?, ?, ?, ?, ?, ? )`,
+		band.Name, band.LiveId, band.Turn, band.SetLength, idValue(band.BandId), version)
+	if err != nil {
+		return translateError(err)
+	}
+	band.Version = version
+	return nil
 }
 
-func (b *BandRepositoryImpl) Update(id int, turn int, band *domain.Band) error {
-	_, err := b.db.Exec(
-		`UPDATE Band SET name = ?, live_id = ?, turn = ? WHERE live_id = ? AND turn = ?`,
-		band.Name, band.LiveId, band.Turn, id, turn)
-	return err
+func (b *BandRepositoryImpl) Patch(id int, turn int, version int, patch *domain.BandPatch) error {
+	var a assignments
+	if patch.Name != nil {
+		a.set("name", *patch.Name)
+	}
+	if patch.SetLength != nil {
+		a.set("set_length", *patch.SetLength)
+	}
+	if patch.BandId != nil {
+		a.set("band_id", idValue(*patch.BandId))
+	}
+	next, err := b.nextVersion(id)
+	if err != nil {
+		return err
+	}
+	a.set("version", next)
+	result, err := b.db.Exec(`UPDATE Band SET `+a.String()+` WHERE live_id = ? AND turn = ? AND version = ?`, append(a.args, id, turn, version)...)
+	if err != nil {
+		return translateError(err)
+	}
+	return checkVersion(b.db, result, fmt.Sprintf("band %d-%d", id, turn), `SELECT COUNT(*) FROM Band WHERE live_id = ? AND turn = ?`, id, turn)
+}
+
+func (b *BandRepositoryImpl) Delete(id int, turn int, version int) error {
+	result, err := b.db.Exec(`DELETE FROM Band WHERE live_id = ? AND turn = ? AND version = ?`, id, turn, version)
+	if err != nil {
+		return translateError(err)
+	}
+	return checkVersion(b.db, result, fmt.Sprintf("band %d-%d", id, turn), `SELECT COUNT(*) FROM Band WHERE live_id = ? AND turn = ?`, id, turn)
 }
 
-func (b *BandRepositoryImpl) Delete(id int, turn int) error {
-	_, err := b.db.Exec(`DELETE FROM Band WHERE live_id = ? AND turn = ?`, id, turn)
-	return err
+func (b *BandRepositoryImpl) DeleteByLiveId(id int) error {
+	_, err := b.db.Exec(`DELETE FROM Band WHERE live_id = ?`, id)
+	return translateError(err)
 }
 
 type BandMemberRepositoryImpl struct {
-	db *sql.DB
+	db executor
 }
 
 func NewBandMemberRepositoryImpl(db *sql.DB) *BandMemberRepositoryImpl {
 	return &BandMemberRepositoryImpl{db: db}
 }
 
+// bandMemberColumns メンバーの名前は Member テーブルの現在の表示名を返す
+const bandMemberColumns = `BandMember.live_id, BandMember.turn, BandMember.member_id, Member.name, BandMember.member_part ` +
+	`FROM BandMember JOIN Member ON Member.id = BandMember.member_id`
+
 func (b *BandMemberRepositoryImpl) FindByLiveIdAndTurn(id int, turn int) ([]*domain.Player, error) {
-	rows, err := b.db.Query(`SELECT * FROM BandMember WHERE live_id = ? AND turn = ?`, id, turn)
+	bandMembers, err := b.findBandMembers(
+		`SELECT `+bandMemberColumns+` WHERE BandMember.live_id = ? AND BandMember.turn = ? ORDER BY BandMember.member_id, BandMember.member_part`, id, turn)
 	if err != nil {
 		return nil, err
 	}
 	var players []*domain.Player
+	for _, bandMember := range bandMembers {
+		players = append(players, &domain.Player{MemberId: bandMember.MemberId, Name: bandMember.MemberName, Part: bandMember.MemberPart})
+	}
+	return players, nil
+}
+
+func (b *BandMemberRepositoryImpl) FindByLiveId(id int) ([]*domain.BandMember, error) {
+	return b.findBandMembers(
+		`SELECT `+bandMemberColumns+` WHERE BandMember.live_id = ? ORDER BY BandMember.turn, BandMember.member_id, BandMember.member_part`, id)
+}
+
+func (b *BandMemberRepositoryImpl) findBandMembers(query string, args ...interface{}) ([]*domain.BandMember, error) {
+	rows, err := b.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var bandMembers []*domain.BandMember
 	for rows.Next() {
-		var liveId, turn int
+		var liveId, turn, memberId int
 		var name, part string
 
-		err = rows.Scan(&liveId, &turn, &name, &part)
+		err = rows.Scan(&liveId, &turn, &memberId, &name, &part)
 		if err != nil {
 			return nil, err
 		}
-		player := domain.Player{Name: name, Part: domain.Part(part)}
-		players = append(players, &player)
+		bandMember := domain.BandMember{LiveId: liveId, Turn: turn, MemberId: memberId, MemberName: name, MemberPart: domain.Part(part)}
+		bandMembers = append(bandMembers, &bandMember)
 	}
-	return players, nil
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return bandMembers, nil
+}
+
+// FindAppearances BandMember, Band, Live を結合し、1回のクエリで出演記録を取得する。start, end が nil の場合は期間を制限しない
+func (b *BandMemberRepositoryImpl) FindAppearances(memberId int, start *time.Time, end *time.Time) ([]*domain.Appearance, error) {
+	query := `SELECT Live.id, Live.name, Live.location, Live.date, COALESCE(BandProfile.name, Band.name), Band.turn, BandMember.member_part ` +
+		`FROM BandMember ` +
+		`JOIN Band ON Band.live_id = BandMember.live_id AND Band.turn = BandMember.turn ` +
+		`LEFT JOIN BandProfile ON BandProfile.id = Band.band_id ` +
+		`JOIN Live ON Live.id = Band.live_id ` +
+		`WHERE BandMember.member_id = ?`
+	args := []interface{}{memberId}
+	if start != nil {
+		query += ` AND Live.date >= ?`
+		args = append(args, start.Format(LAYOUT))
+	}
+	if end != nil {
+		query += ` AND Live.date <= ?`
+		args = append(args, end.Format(LAYOUT))
+	}
+	query += ` ORDER BY Live.date, Live.id, Band.turn, BandMember.member_part`
+
+	rows, err := b.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var appearances []*domain.Appearance
+	for rows.Next() {
+		var appearance domain.Appearance
+		var part string
+		err := rows.Scan(&appearance.LiveId, &appearance.LiveName, &appearance.Location, &appearance.Date,
+			&appearance.BandName, &appearance.Turn, &part)
+		if err != nil {
+			return nil, err
+		}
+		appearance.Part = domain.Part(part)
+		appearances = append(appearances, &appearance)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return appearances, nil
 }
 
 func (b *BandMemberRepositoryImpl) Create(bandMember *domain.BandMember) error {
 	_, err := b.db.Exec(
-		`INSERT INTO BandMember(live_id, turn, member_name, member_part) VALUES ( ?, ?, ?, ? )`,
-		bandMember.LiveId, bandMember.Turn, bandMember.MemberName, string(bandMember.MemberPart))
-	return err
+		`INSERT INTO BandMember(live_id, turn, member_id, member_part) VALUES ( ?, ?, ?, ? )`,
+		bandMember.LiveId, bandMember.Turn, bandMember.MemberId, string(bandMember.MemberPart))
+	return translateError(err)
 }
 
 func (b *BandMemberRepositoryImpl) Delete(bandMember *domain.BandMember) error {
-	_, err := b.db.Exec(
-		`DELETE FROM BandMember WHERE live_id = ? AND turn = ? AND member_name = ? AND member_part = ?`,
-		bandMember.LiveId, bandMember.Turn, bandMember.MemberName, string(bandMember.MemberPart))
-	return err
+	result, err := b.db.Exec(
+		`DELETE FROM BandMember WHERE live_id = ? AND turn = ? AND member_id = ? AND member_part = ?`,
+		bandMember.LiveId, bandMember.Turn, bandMember.MemberId, string(bandMember.MemberPart))
+	if err != nil {
+		return translateError(err)
+	}
+	return checkBandMember(b.db, result, bandMember)
 }
 
-func (b *BandMemberRepositoryImpl) Update(bandMember *domain.BandMember, id int, turn int) error {
-	_, err := b.db.Exec(
-		`UPDATE BandMember SET id = ?, turn = ?, member_name = ?, member_part = ? WHERE live_id = ? AND turn = ?`,
-		bandMember.LiveId, bandMember.Turn, bandMember.MemberName, string(bandMember.MemberPart), id, turn)
-	return err
+func (b *BandMemberRepositoryImpl) Update(current *domain.BandMember, replacement *domain.BandMember) error {
+	result, err := b.db.Exec(
+		`UPDATE BandMember SET live_id = ?, turn = ?, member_id = ?, member_part = ? WHERE live_id = ? AND turn = ? AND member_id = ? AND member_part = ?`,
+		replacement.LiveId, replacement.Turn, replacement.MemberId, string(replacement.MemberPart),
+		current.LiveId, current.Turn, current.MemberId, string(current.MemberPart))
+	if err != nil {
+		return translateError(err)
+	}
+	return checkBandMember(b.db, result, current)
+}
+
+// checkBandMember UPDATE・DELETE で行が変更されなかった場合に、bandMember が存在しなければ ErrNotFound を返す。
+// 同じ値で置き換えた場合は変更された行が 0 件になるため、行の有無を数えて確認する
+func checkBandMember(db executor, result sql.Result, bandMember *domain.BandMember) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected > 0 {
+		return nil
+	}
+	var count int
+	err = db.QueryRow(`SELECT COUNT(*) FROM BandMember WHERE live_id = ? AND turn = ? AND member_id = ? AND member_part = ?`,
+		bandMember.LiveId, bandMember.Turn, bandMember.MemberId, string(bandMember.MemberPart)).Scan(&count)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("%w: band member %d-%d %d %s", domain.ErrNotFound, bandMember.LiveId, bandMember.Turn, bandMember.MemberId, bandMember.MemberPart)
+	}
+	return nil
+}
+
+func (b *BandMemberRepositoryImpl) DeleteByLiveId(id int) error {
+	_, err := b.db.Exec(`DELETE FROM BandMember WHERE live_id = ?`, id)
+	return translateError(err)
 }
 
 type PlayerRepositoryImpl struct {
-	db *sql.DB
+	db executor
 }
 
 func NewPlayerRepositoryImpl(db *sql.DB) *PlayerRepositoryImpl {
 	return &PlayerRepositoryImpl{db: db}
 }
 
-func (p *PlayerRepositoryImpl) Create(player *domain.Player) error {
-	_, err := p.db.Exec(`INSERT INTO Player(name, part) VALUES ( ?, ? )`, player.Name, string(player.Part))
-	return err
+func (p *PlayerRepositoryImpl) FindByPart(part *domain.Part) ([]*domain.Player, error) {
+	rows, err := p.db.Query(
+		`SELECT Member.id, Member.name, MemberPart.part FROM Member JOIN MemberPart ON MemberPart.member_id = Member.id `+
+			`WHERE MemberPart.part = ? ORDER BY Member.id`, string(*part))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var players []*domain.Player
+	for rows.Next() {
+		var id int
+		var name, part string
+
+		err = rows.Scan(&id, &name, &part)
+		if err != nil {
+			return nil, err
+		}
+		player := domain.Player{MemberId: id, Name: name, Part: domain.Part(part)}
+		players = append(players, &player)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return players, nil
+}
+
+func (p *PlayerRepositoryImpl) FindById(id int) (*domain.Member, error) {
+	return p.findMember(`SELECT id, name FROM Member WHERE id = ?`, id)
 }
 
-func (p *PlayerRepositoryImpl) Delete(player *domain.Player) error {
-	_, err := p.db.Exec(`DELETE FROM Player WHERE name = ? AND part = ?`, player.Name, string(player.Part))
-	return err
+func (p *PlayerRepositoryImpl) FindByName(name string) (*domain.Member, error) {
+	return p.findMember(`SELECT id, name FROM Member WHERE name = ?`, name)
 }
 
-func (p *PlayerRepositoryImpl) FindByPart(part *domain.Part) ([]*domain.Player, error) {
-	rows, err := p.db.Query(`SELECT * FROM Player WHERE Part = ?`, string(*part))
+func (p *PlayerRepositoryImpl) findMember(query string, args ...interface{}) (*domain.Member, error) {
+	var member domain.Member
+	err := p.db.QueryRow(query, args...).Scan(&member.Id, &member.Name)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, err
 	}
+	rows, err := p.db.Query(`SELECT part FROM MemberPart WHERE member_id = ? ORDER BY part`, member.Id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var part string
+		if err := rows.Scan(&part); err != nil {
+			return nil, err
+		}
+		member.Part = append(member.Part, domain.Part(part))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (p *PlayerRepositoryImpl) Create(member *domain.Member) error {
+	result, err := p.db.Exec(`INSERT INTO Member(name) VALUES ( ? )`, member.Name)
+	if err != nil {
+		return translateError(err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	member.Id = int(id)
+	for _, part := range member.Part {
+		if err := p.AddPart(member.Id, part); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *PlayerRepositoryImpl) Rename(id int, name string) error {
+	_, err := p.db.Exec(`UPDATE Member SET name = ? WHERE id = ?`, name, id)
+	return translateError(err)
+}
+
+func (p *PlayerRepositoryImpl) AddPart(id int, part domain.Part) error {
+	_, err := p.db.Exec(`INSERT INTO MemberPart(member_id, part) VALUES ( ?, ? )`, id, string(part))
+	return translateError(err)
+}
+
+func (p *PlayerRepositoryImpl) RemovePart(id int, part domain.Part) error {
+	_, err := p.db.Exec(`DELETE FROM MemberPart WHERE member_id = ? AND part = ?`, id, string(part))
+	return translateError(err)
+}
+
+type PaymentRepositoryImpl struct {
+	db executor
+}
+
+func NewPaymentRepositoryImpl(db *sql.DB) *PaymentRepositoryImpl {
+	return &PaymentRepositoryImpl{db: db}
+}
+
+func (p *PaymentRepositoryImpl) FindByLiveId(id int) ([]*domain.Payment, error) {
+	rows, err := p.db.Query(
+		`SELECT Payment.id, Payment.live_id, Payment.member_id, Member.name, Payment.kind, Payment.method, Payment.amount, Payment.paid_at, Payment.note `+
+			`FROM Payment JOIN Member ON Member.id = Payment.member_id WHERE Payment.live_id = ? ORDER BY Payment.paid_at, Payment.id`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var payments []*domain.Payment
+	for rows.Next() {
+		var payment domain.Payment
+		var kind, method string
+
+		err = rows.Scan(&payment.Id, &payment.LiveId, &payment.MemberId, &payment.PlayerName, &kind, &method, &payment.Amount, &payment.PaidAt, &payment.Note)
+		if err != nil {
+			return nil, err
+		}
+		payment.Kind = domain.PaymentKind(kind)
+		payment.Method = domain.PaymentMethod(method)
+		payments = append(payments, &payment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return payments, nil
+}
+
+func (p *PaymentRepositoryImpl) Create(payment *domain.Payment) error {
+	result, err := p.db.Exec(
+		`INSERT INTO Payment(live_id, member_id, kind, method, amount, paid_at, note) VALUES ( ?, ?, ?, ?, ?, ?, ? )`,
+		payment.LiveId, payment.MemberId, string(payment.Kind), string(payment.Method), payment.Amount, payment.PaidAt, payment.Note)
+	if err != nil {
+		return translateError(err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	payment.Id = int(id)
+	return nil
+}
+
+type LineupRuleRepositoryImpl struct {
+	db executor
+}
+
+func NewLineupRuleRepositoryImpl(db *sql.DB) *LineupRuleRepositoryImpl {
+	return &LineupRuleRepositoryImpl{db: db}
+}
+
+func (l *LineupRuleRepositoryImpl) FindByLiveId(id int) (*domain.LineupRule, error) {
+	var rule domain.LineupRule
+	err := l.db.QueryRow(
+		`SELECT live_id, min_members, max_members, gtvo_counts_as_vo, gtvo_counts_as_gt FROM LineupRule WHERE live_id = ?`, id).
+		Scan(&rule.LiveId, &rule.MinMembers, &rule.MaxMembers, &rule.GtVoCountsAsVo, &rule.GtVoCountsAsGt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := l.db.Query(`SELECT part, min_count, max_count FROM LineupPartRule WHERE live_id = ? ORDER BY part`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var part string
+		var min, max int
+
+		err = rows.Scan(&part, &min, &max)
+		if err != nil {
+			return nil, err
+		}
+		rule.Part = append(rule.Part, &domain.PartRule{Part: domain.Part(part), Min: min, Max: max})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (l *LineupRuleRepositoryImpl) Save(rule *domain.LineupRule) error {
+	_, err := l.db.Exec(
+		`INSERT INTO LineupRule(live_id, min_members, max_members, gtvo_counts_as_vo, gtvo_counts_as_gt) VALUES ( ?, ?, ?, ?, ? ) `+
+			`ON DUPLICATE KEY UPDATE min_members = VALUES(min_members), max_members = VALUES(max_members), `+
+			`gtvo_counts_as_vo = VALUES(gtvo_counts_as_vo), gtvo_counts_as_gt = VALUES(gtvo_counts_as_gt)`,
+		rule.LiveId, rule.MinMembers, rule.MaxMembers, rule.GtVoCountsAsVo, rule.GtVoCountsAsGt)
+	if err != nil {
+		return translateError(err)
+	}
+	_, err = l.db.Exec(`DELETE FROM LineupPartRule WHERE live_id = ?`, rule.LiveId)
+	if err != nil {
+		return translateError(err)
+	}
+	for _, partRule := range rule.Part {
+		_, err = l.db.Exec(
+			`INSERT INTO LineupPartRule(live_id, part, min_count, max_count) VALUES ( ?, ?, ?, ? )`,
+			rule.LiveId, string(partRule.Part), partRule.Min, partRule.Max)
+		if err != nil {
+			return translateError(err)
+		}
+	}
+	return nil
+}
+
+type BandProfileRepositoryImpl struct {
+	db executor
+}
+
+func NewBandProfileRepositoryImpl(db *sql.DB) *BandProfileRepositoryImpl {
+	return &BandProfileRepositoryImpl{db: db}
+}
+
+func (b *BandProfileRepositoryImpl) FindAll() ([]*domain.BandProfile, error) {
+	rows, err := b.db.Query(`SELECT id, name FROM BandProfile ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var profiles []*domain.BandProfile
+	for rows.Next() {
+		var profile domain.BandProfile
+		if err := rows.Scan(&profile.Id, &profile.Name); err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, &profile)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	for _, profile := range profiles {
+		if profile.Player, err = b.findRoster(profile.Id); err != nil {
+			return nil, err
+		}
+	}
+	return profiles, nil
+}
+
+func (b *BandProfileRepositoryImpl) FindById(id int) (*domain.BandProfile, error) {
+	var profile domain.BandProfile
+	err := b.db.QueryRow(`SELECT id, name FROM BandProfile WHERE id = ?`, id).Scan(&profile.Id, &profile.Name)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if profile.Player, err = b.findRoster(id); err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+func (b *BandProfileRepositoryImpl) findRoster(id int) ([]*domain.Player, error) {
+	rows, err := b.db.Query(
+		`SELECT Member.id, Member.name, BandProfileMember.member_part FROM BandProfileMember JOIN Member ON Member.id = BandProfileMember.member_id `+
+			`WHERE BandProfileMember.band_id = ? ORDER BY Member.id, BandProfileMember.member_part`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 	var players []*domain.Player
 	for rows.Next() {
+		var memberId int
 		var name, part string
+		if err := rows.Scan(&memberId, &name, &part); err != nil {
+			return nil, err
+		}
+		players = append(players, &domain.Player{MemberId: memberId, Name: name, Part: domain.Part(part)})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return players, nil
+}
 
-		err = rows.Scan(&name, &part)
+func (b *BandProfileRepositoryImpl) Create(profile *domain.BandProfile) error {
+	result, err := b.db.Exec(`INSERT INTO BandProfile(name) VALUES ( ? )`, profile.Name)
+	if err != nil {
+		return translateError(err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	profile.Id = int(id)
+	return nil
+}
+
+func (b *BandProfileRepositoryImpl) Rename(id int, name string) error {
+	_, err := b.db.Exec(`UPDATE BandProfile SET name = ? WHERE id = ?`, name, id)
+	return translateError(err)
+}
+
+func (b *BandProfileRepositoryImpl) SaveRoster(id int, players []*domain.Player) error {
+	_, err := b.db.Exec(`DELETE FROM BandProfileMember WHERE band_id = ?`, id)
+	if err != nil {
+		return translateError(err)
+	}
+	for _, player := range players {
+		_, err = b.db.Exec(
+			`INSERT INTO BandProfileMember(band_id, member_id, member_part) VALUES ( ?, ?, ? )`,
+			id, player.MemberId, string(player.Part))
+		if err != nil {
+			return translateError(err)
+		}
+	}
+	return nil
+}
+
+type PartRepositoryImpl struct {
+	db executor
+}
+
+func NewPartRepositoryImpl(db *sql.DB) *PartRepositoryImpl {
+	return &PartRepositoryImpl{db: db}
+}
+
+const partColumns = `code, display_name, category, sort_order`
+
+func scanPart(scanner scanner) (*domain.PartDefinition, error) {
+	var part domain.PartDefinition
+	var code, category string
+	if err := scanner.Scan(&code, &part.DisplayName, &category, &part.SortOrder); err != nil {
+		return nil, err
+	}
+	part.Code = domain.Part(code)
+	part.Category = domain.PartCategory(category)
+	return &part, nil
+}
+
+func (p *PartRepositoryImpl) FindAll() ([]*domain.PartDefinition, error) {
+	rows, err := p.db.Query(`SELECT ` + partColumns + ` FROM PartCatalog ORDER BY sort_order, code`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var parts []*domain.PartDefinition
+	for rows.Next() {
+		part, err := scanPart(rows)
 		if err != nil {
 			return nil, err
 		}
-		player := domain.Player{Name: name, Part: domain.Part(part)}
-		players = append(players, &player)
+		parts = append(parts, part)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return parts, nil
+}
+
+func (p *PartRepositoryImpl) Save(part *domain.PartDefinition) error {
+	_, err := p.db.Exec(
+		`INSERT INTO PartCatalog(code, display_name, category, sort_order) VALUES ( ?, ?, ?, ? ) `+
+			`ON DUPLICATE KEY UPDATE display_name = VALUES(display_name), category = VALUES(category), sort_order = VALUES(sort_order)`,
+		string(part.Code), part.DisplayName, string(part.Category), part.SortOrder)
+	return translateError(err)
+}
+
+func (p *PartRepositoryImpl) Delete(code domain.Part) error {
+	_, err := p.db.Exec(`DELETE FROM PartCatalog WHERE code = ?`, string(code))
+	return translateError(err)
+}
+
+func (p *PartRepositoryImpl) IsUsed(code domain.Part) (bool, error) {
+	var used bool
+	err := p.db.QueryRow(
+		`SELECT EXISTS (SELECT 1 FROM MemberPart WHERE part = ?) OR EXISTS (SELECT 1 FROM BandMember WHERE member_part = ?) `+
+			`OR EXISTS (SELECT 1 FROM BandProfileMember WHERE member_part = ?) OR EXISTS (SELECT 1 FROM LineupPartRule WHERE part = ?) `+
+			`OR EXISTS (SELECT 1 FROM EntryApplicationMember WHERE member_part = ?)`,
+		string(code), string(code), string(code), string(code), string(code)).Scan(&used)
+	return used, err
+}
+
+type EntryApplicationRepositoryImpl struct {
+	db executor
+}
+
+func NewEntryApplicationRepositoryImpl(db *sql.DB) *EntryApplicationRepositoryImpl {
+	return &EntryApplicationRepositoryImpl{db: db}
+}
+
+const entryApplicationColumns = `id, live_id, name, band_id, preferred_turn, set_length, note, status, turn, applied_at`
+
+func scanEntryApplication(row scanner) (*domain.EntryApplication, error) {
+	var application domain.EntryApplication
+	var bandId, turn sql.NullInt64
+	err := row.Scan(&application.Id, &application.LiveId, &application.Name, &bandId, &application.PreferredTurn, &application.SetLength,
+		&application.Note, &application.Status, &turn, &application.AppliedAt)
+	if err != nil {
+		return nil, err
+	}
+	application.BandId, application.Turn = int(bandId.Int64), int(turn.Int64)
+	return &application, nil
+}
+
+func (e *EntryApplicationRepositoryImpl) FindByLiveId(id int, status *domain.ApplicationStatus) ([]*domain.EntryApplication, error) {
+	condition, args := `EntryApplication.live_id = ?`, []interface{}{id}
+	if status != nil {
+		condition, args = condition+` AND EntryApplication.status = ?`, append(args, string(*status))
+	}
+	rows, err := e.db.Query(`SELECT `+entryApplicationColumns+` FROM EntryApplication WHERE `+condition+` ORDER BY id`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var applications []*domain.EntryApplication
+	for rows.Next() {
+		application, err := scanEntryApplication(rows)
+		if err != nil {
+			return nil, err
+		}
+		applications = append(applications, application)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(applications) == 0 {
+		return applications, nil
+	}
+	players, err := e.findMembers(condition, args...)
+	if err != nil {
+		return nil, err
+	}
+	for _, application := range applications {
+		application.Player = players[application.Id]
+	}
+	return applications, nil
+}
+
+func (e *EntryApplicationRepositoryImpl) FindById(id int) (*domain.EntryApplication, error) {
+	application, err := scanEntryApplication(e.db.QueryRow(`SELECT `+entryApplicationColumns+` FROM EntryApplication WHERE id = ?`, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	players, err := e.findMembers(`EntryApplication.id = ?`, id)
+	if err != nil {
+		return nil, err
+	}
+	application.Player = players[id]
+	return application, nil
+}
+
+// findMembers condition に一致する申込のメンバーを1回のクエリで読み込み、申込 ID ごとにまとめて返す
+func (e *EntryApplicationRepositoryImpl) findMembers(condition string, args ...interface{}) (map[int][]*domain.Player, error) {
+	rows, err := e.db.Query(
+		`SELECT EntryApplicationMember.application_id, Member.id, Member.name, EntryApplicationMember.member_part FROM EntryApplicationMember `+
+			`JOIN EntryApplication ON EntryApplication.id = EntryApplicationMember.application_id JOIN Member ON Member.id = EntryApplicationMember.member_id `+
+			`WHERE `+condition+` ORDER BY EntryApplicationMember.application_id, Member.id, EntryApplicationMember.member_part`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	players := map[int][]*domain.Player{}
+	for rows.Next() {
+		var applicationId, memberId int
+		var name, part string
+		if err := rows.Scan(&applicationId, &memberId, &name, &part); err != nil {
+			return nil, err
+		}
+		players[applicationId] = append(players[applicationId], &domain.Player{MemberId: memberId, Name: name, Part: domain.Part(part)})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return players, nil
+}
+
+func (e *EntryApplicationRepositoryImpl) Create(application *domain.EntryApplication) error {
+	result, err := e.db.Exec(
+		`INSERT INTO EntryApplication(live_id, name, band_id, preferred_turn, set_length, note, status, turn, applied_at) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ? )`,
+		application.LiveId, application.Name, idValue(application.BandId), application.PreferredTurn, application.SetLength,
+		application.Note, string(application.Status), idValue(application.Turn), application.AppliedAt)
+	if err != nil {
+		return translateError(err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	for _, player := range application.Player {
+		_, err = e.db.Exec(
+			`INSERT INTO EntryApplicationMember(application_id, member_id, member_part) VALUES ( ?, ?, ? )`,
+			id, player.MemberId, string(player.Part))
+		if err != nil {
+			return translateError(err)
+		}
+	}
+	application.Id = int(id)
+	return nil
+}
+
+func (e *EntryApplicationRepositoryImpl) UpdateStatus(id int, current domain.ApplicationStatus, status domain.ApplicationStatus, turn int) error {
+	result, err := e.db.Exec(`UPDATE EntryApplication SET status = ?, turn = ? WHERE id = ? AND status = ?`,
+		string(status), idValue(turn), id, string(current))
+	if err != nil {
+		return translateError(err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected > 0 {
+		return nil
+	}
+	var count int
+	if err := e.db.QueryRow(`SELECT COUNT(*) FROM EntryApplication WHERE id = ?`, id).Scan(&count); err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("%w: entry application %d", domain.ErrNotFound, id)
 	}
-	return players, err
+	return fmt.Errorf("%w: entry application %d is not %s", domain.ErrConflict, id, current)
 }
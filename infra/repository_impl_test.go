@@ -1,6 +1,8 @@
 package infra
 
 import (
+	"database/sql/driver"
+	"errors"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
 	"live-scheduler/domain"
@@ -11,6 +13,8 @@ import (
 
 var now = time.Now()
 
+var liveColumnNames = []string{"id", "name", "location", "date", "performance_fee", "equipment_cost", "open_time", "start_time", "close_time", "changeover", "version", "updated_at", "status"}
+
 func TestFindByPeriod(t *testing.T) {
 	// given
 	expected := domain.Live{
@@ -20,16 +24,23 @@ func TestFindByPeriod(t *testing.T) {
 		Date:           now,
 		PerformanceFee: 5500,
 		EquipmentCost:  2000,
+		OpenTime:       17*60 + 30,
+		StartTime:      18 * 60,
+		Changeover:     10,
+		Version:        1,
+		UpdatedAt:      now,
+		Status:         domain.LiveEntryOpen,
 	}
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		t.Error(err.Error())
 	}
 	defer db.Close()
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM Live WHERE date >= ? AND date <= ?")).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT "+liveColumns+" FROM Live WHERE date >= ? AND date <= ? ORDER BY date, id")).
 		WithArgs(now.Format("2006-01-02"), now.Format("2006-01-02")).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "location", "date", "performance_fee", "equipment_cost"}).
-			AddRow(expected.Id, expected.Name, expected.Location, expected.Date, expected.PerformanceFee, expected.EquipmentCost))
+		WillReturnRows(sqlmock.NewRows(liveColumnNames).
+			AddRow(expected.Id, expected.Name, expected.Location, expected.Date, expected.PerformanceFee, expected.EquipmentCost,
+				"17:30:00", "18:00:00", nil, 10, 1, now, "entry_open"))
 	repository := NewLiveRepositoryImpl(db)
 
 	// when
@@ -40,6 +51,114 @@ func TestFindByPeriod(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+var liveDescColumnNames = append(append([]string{}, liveColumnNames...),
+	"band_name", "turn", "set_length", "band_id", "band_version", "member_id", "member_name", "member_part")
+
+func TestLiveDescFindById(t *testing.T) {
+	// given
+	expected := &domain.LiveModel{
+		Id:             1,
+		Name:           "name",
+		Location:       "location",
+		Date:           now,
+		PerformanceFee: 5500,
+		EquipmentCost:  2000,
+		OpenTime:       17*60 + 30,
+		Changeover:     10,
+		Version:        4,
+		UpdatedAt:      now,
+		Status:         domain.LiveConfirmed,
+		Band: []*domain.BandModel{
+			{Name: "band1", LiveId: 1, Turn: 1, SetLength: 20, Version: 1, Player: []*domain.Player{
+				{MemberId: 1, Name: "player1", Part: domain.Gt},
+				{MemberId: 2, Name: "player2", Part: domain.Dr},
+			}},
+			{Name: "profile", LiveId: 1, Turn: 2, SetLength: 30, BandId: 5, Version: 2, Player: []*domain.Player{
+				{MemberId: 1, Name: "player1", Part: domain.Ba},
+			}},
+			{Name: "band3", LiveId: 1, Turn: 3, SetLength: 20, Version: 1},
+		},
+	}
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Error(err.Error())
+	}
+	defer db.Close()
+	// バンドの数によらず1回のクエリで取得する
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT " + liveDescColumns + " WHERE Live.id = ?" + liveDescOrder)).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(liveDescColumnNames).
+			AddRow(1, "name", "location", now, 5500, 2000, "17:30:00", nil, nil, 10, 4, now, "confirmed", "band1", 1, 20, nil, 1, 1, "player1", "Gt.").
+			AddRow(1, "name", "location", now, 5500, 2000, "17:30:00", nil, nil, 10, 4, now, "confirmed", "band1", 1, 20, nil, 1, 2, "player2", "Dr.").
+			AddRow(1, "name", "location", now, 5500, 2000, "17:30:00", nil, nil, 10, 4, now, "confirmed", "profile", 2, 30, 5, 2, 1, "player1", "Ba.").
+			AddRow(1, "name", "location", now, 5500, 2000, "17:30:00", nil, nil, 10, 4, now, "confirmed", "band3", 3, 20, nil, 1, nil, nil, nil))
+	repository := NewLiveDescRepositoryImpl(db)
+
+	// when
+	actual, err := repository.FindById(1)
+
+	// then
+	assert.Equal(t, expected, actual)
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestLiveDescFindByIdNotFound(t *testing.T) {
+	// given
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Error(err.Error())
+	}
+	defer db.Close()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT " + liveDescColumns + " WHERE Live.id = ?" + liveDescOrder)).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(liveDescColumnNames))
+	repository := NewLiveDescRepositoryImpl(db)
+
+	// when
+	actual, err := repository.FindById(1)
+
+	// then
+	assert.Nil(t, actual)
+	assert.True(t, errors.Is(err, domain.ErrNotFound))
+}
+
+func TestLiveDescFindByPeriod(t *testing.T) {
+	// given
+	expected := []*domain.LiveModel{
+		{Id: 1, Name: "live1", Location: "location", Date: now, Version: 1, UpdatedAt: now, Status: domain.LiveDraft, Band: []*domain.BandModel{
+			{Name: "band1", LiveId: 1, Turn: 1, SetLength: 20, Version: 1, Player: []*domain.Player{{MemberId: 1, Name: "player1", Part: domain.Gt}}},
+			{Name: "band2", LiveId: 1, Turn: 2, SetLength: 20, Version: 1, Player: []*domain.Player{{MemberId: 2, Name: "player2", Part: domain.Vo}}},
+		}},
+		{Id: 2, Name: "live2", Location: "location", Date: now, Version: 1, UpdatedAt: now, Status: domain.LiveDraft},
+		{Id: 3, Name: "live3", Location: "location", Date: now, Version: 1, UpdatedAt: now, Status: domain.LiveDraft, Band: []*domain.BandModel{
+			{Name: "band1", LiveId: 3, Turn: 1, SetLength: 20, Version: 1, Player: []*domain.Player{{MemberId: 1, Name: "player1", Part: domain.Gt}}},
+		}},
+	}
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Error(err.Error())
+	}
+	defer db.Close()
+	// ライブの件数によらず1回のクエリで取得する
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT "+liveDescColumns+" WHERE Live.date >= ? AND Live.date <= ?"+liveDescOrder)).
+		WithArgs(now.Format("2006-01-02"), now.Format("2006-01-02")).
+		WillReturnRows(sqlmock.NewRows(liveDescColumnNames).
+			AddRow(1, "live1", "location", now, 0, 0, nil, nil, nil, 0, 1, now, "draft", "band1", 1, 20, nil, 1, 1, "player1", "Gt.").
+			AddRow(1, "live1", "location", now, 0, 0, nil, nil, nil, 0, 1, now, "draft", "band2", 2, 20, nil, 1, 2, "player2", "Vo.").
+			AddRow(2, "live2", "location", now, 0, 0, nil, nil, nil, 0, 1, now, "draft", nil, nil, nil, nil, nil, nil, nil, nil).
+			AddRow(3, "live3", "location", now, 0, 0, nil, nil, nil, 0, 1, now, "draft", "band1", 1, 20, nil, 1, 1, "player1", "Gt."))
+	repository := NewLiveDescRepositoryImpl(db)
+
+	// when
+	actual, err := repository.FindByPeriod(&now, &now)
+
+	// then
+	assert.Equal(t, expected, actual)
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
 func TestFindById(t *testing.T) {
 	// given
 	expected := domain.Live{
@@ -49,16 +168,23 @@ func TestFindById(t *testing.T) {
 		Date:           now,
 		PerformanceFee: 5500,
 		EquipmentCost:  2000,
+		OpenTime:       17*60 + 30,
+		StartTime:      18 * 60,
+		Changeover:     10,
+		Version:        1,
+		UpdatedAt:      now,
+		Status:         domain.LiveEntryOpen,
 	}
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		t.Error(err.Error())
 	}
 	defer db.Close()
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM Live WHERE id = ?")).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT " + liveColumns + " FROM Live WHERE id = ?")).
 		WithArgs(1).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "location", "date", "performance_fee", "equipment_cost"}).
-			AddRow(expected.Id, expected.Name, expected.Location, expected.Date, expected.PerformanceFee, expected.EquipmentCost))
+		WillReturnRows(sqlmock.NewRows(liveColumnNames).
+			AddRow(expected.Id, expected.Name, expected.Location, expected.Date, expected.PerformanceFee, expected.EquipmentCost,
+				"17:30:00", "18:00:00", nil, 10, 1, now, "entry_open"))
 	repository := NewLiveRepositoryImpl(db)
 
 	// when
@@ -68,3 +194,449 @@ func TestFindById(t *testing.T) {
 	assert.Equal(t, &expected, actual)
 	assert.Nil(t, err)
 }
+
+func TestLivePatch(t *testing.T) {
+	// given
+	name, closeTime := "renamed", domain.Clock(0)
+	tests := []struct {
+		// テスト名
+		testName string
+		// 更新内容
+		patch *domain.LivePatch
+		// 実行される SQL
+		expectedQuery string
+		// SQL の引数
+		expectedArgs []driver.Value
+		// 更新された行数
+		rowsAffected int64
+		// 更新されなかった場合に数えたライブの件数
+		count int
+		// 戻り値の期待値(error)
+		expectedError error
+	}{
+		{
+			testName:      "正常系_指定したカラムだけを更新する",
+			patch:         &domain.LivePatch{Name: &name, CloseTime: &closeTime},
+			expectedQuery: "UPDATE Live SET name = ?, close_time = ?, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND version = ?",
+			expectedArgs:  []driver.Value{"renamed", nil, 1, 2},
+			rowsAffected:  1,
+		},
+		{
+			testName:      "正常系_更新するカラムがなくてもバージョンを増やす",
+			patch:         &domain.LivePatch{},
+			expectedQuery: "UPDATE Live SET version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND version = ?",
+			expectedArgs:  []driver.Value{1, 2},
+			rowsAffected:  1,
+		},
+		{
+			testName:      "異常系_バージョンが異なる",
+			patch:         &domain.LivePatch{Name: &name},
+			expectedQuery: "UPDATE Live SET name = ?, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND version = ?",
+			expectedArgs:  []driver.Value{"renamed", 1, 2},
+			count:         1,
+			expectedError: domain.ErrPreconditionFailed,
+		},
+		{
+			testName:      "異常系_存在しないライブ",
+			patch:         &domain.LivePatch{Name: &name},
+			expectedQuery: "UPDATE Live SET name = ?, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND version = ?",
+			expectedArgs:  []driver.Value{"renamed", 1, 2},
+			expectedError: domain.ErrNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Error(err.Error())
+		}
+		mock.ExpectExec(regexp.QuoteMeta(tc.expectedQuery)).
+			WithArgs(tc.expectedArgs...).
+			WillReturnResult(sqlmock.NewResult(0, tc.rowsAffected))
+		if tc.rowsAffected == 0 {
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM Live WHERE id = ?")).
+				WithArgs(1).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(tc.count))
+		}
+		repository := NewLiveRepositoryImpl(db)
+
+		// when
+		err = repository.Patch(1, 2, tc.patch)
+
+		// then
+		assert.True(t, errors.Is(err, tc.expectedError), tc.testName)
+		assert.Nil(t, mock.ExpectationsWereMet(), tc.testName)
+		db.Close()
+	}
+}
+
+func TestLiveTouch(t *testing.T) {
+	// given
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Error(err.Error())
+	}
+	defer db.Close()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE Live SET version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE id = ?")).
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	repository := NewLiveRepositoryImpl(db)
+
+	// when
+	err = repository.Touch(1)
+
+	// then
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestBandPatch(t *testing.T) {
+	// given
+	setLength, bandId := 25, 0
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Error(err.Error())
+	}
+	defer db.Close()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE Live SET band_version = LAST_INSERT_ID(band_version + 1) WHERE id = ?")).
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(5, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE Band SET set_length = ?, band_id = ?, version = ? WHERE live_id = ? AND turn = ? AND version = ?")).
+		WithArgs(25, nil, 5, 1, 2, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	repository := NewBandRepositoryImpl(db)
+
+	// when
+	err = repository.Patch(1, 2, 1, &domain.BandPatch{SetLength: &setLength, BandId: &bandId})
+
+	// then
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestBandDeleteVersionMismatch(t *testing.T) {
+	// given
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Error(err.Error())
+	}
+	defer db.Close()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM Band WHERE live_id = ? AND turn = ? AND version = ?")).
+		WithArgs(1, 2, 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM Band WHERE live_id = ? AND turn = ?")).
+		WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	repository := NewBandRepositoryImpl(db)
+
+	// when
+	err = repository.Delete(1, 2, 1)
+
+	// then
+	assert.True(t, errors.Is(err, domain.ErrPreconditionFailed))
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestBandMemberUpdate(t *testing.T) {
+	// given
+	current := domain.BandMember{LiveId: 1, Turn: 2, MemberId: 3, MemberName: "drummer", MemberPart: domain.Dr}
+	replacement := domain.BandMember{LiveId: 1, Turn: 2, MemberId: 4, MemberName: "drummer2", MemberPart: domain.Dr}
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Error(err.Error())
+	}
+	defer db.Close()
+	mock.ExpectExec(regexp.QuoteMeta(
+		"UPDATE BandMember SET live_id = ?, turn = ?, member_id = ?, member_part = ? WHERE live_id = ? AND turn = ? AND member_id = ? AND member_part = ?")).
+		WithArgs(1, 2, 4, "Dr.", 1, 2, 3, "Dr.").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	repository := NewBandMemberRepositoryImpl(db)
+
+	// when
+	err = repository.Update(&current, &replacement)
+
+	// then
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestBandMemberDeleteNotFound(t *testing.T) {
+	// given
+	bandMember := domain.BandMember{LiveId: 1, Turn: 2, MemberId: 3, MemberName: "drummer", MemberPart: domain.Dr}
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Error(err.Error())
+	}
+	defer db.Close()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM BandMember WHERE live_id = ? AND turn = ? AND member_id = ? AND member_part = ?")).
+		WithArgs(1, 2, 3, "Dr.").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM BandMember WHERE live_id = ? AND turn = ? AND member_id = ? AND member_part = ?")).
+		WithArgs(1, 2, 3, "Dr.").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	repository := NewBandMemberRepositoryImpl(db)
+
+	// when
+	err = repository.Delete(&bandMember)
+
+	// then
+	assert.True(t, errors.Is(err, domain.ErrNotFound))
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestPaymentCreate(t *testing.T) {
+	// given
+	payment := domain.Payment{LiveId: 1, MemberId: 3, PlayerName: "drummer", Kind: domain.Paid, Method: domain.Cash, Amount: 3000, PaidAt: now}
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Error(err.Error())
+	}
+	defer db.Close()
+	mock.ExpectExec(regexp.QuoteMeta(
+		"INSERT INTO Payment(live_id, member_id, kind, method, amount, paid_at, note) VALUES ( ?, ?, ?, ?, ?, ?, ? )")).
+		WithArgs(1, 3, "payment", "cash", 3000, now, "").
+		WillReturnResult(sqlmock.NewResult(10, 1))
+	repository := NewPaymentRepositoryImpl(db)
+
+	// when
+	err = repository.Create(&payment)
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, 10, payment.Id)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestLineupRuleFindByLiveId(t *testing.T) {
+	// given
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Error(err.Error())
+	}
+	defer db.Close()
+	query := "SELECT live_id, min_members, max_members, gtvo_counts_as_vo, gtvo_counts_as_gt FROM LineupRule WHERE live_id = ?"
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"live_id", "min_members", "max_members", "gtvo_counts_as_vo", "gtvo_counts_as_gt"}).
+			AddRow(1, 3, 5, true, false))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT part, min_count, max_count FROM LineupPartRule WHERE live_id = ? ORDER BY part")).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"part", "min_count", "max_count"}).
+			AddRow("Dr.", 1, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"live_id", "min_members", "max_members", "gtvo_counts_as_vo", "gtvo_counts_as_gt"}))
+	repository := NewLineupRuleRepositoryImpl(db)
+
+	// when
+	actual, err := repository.FindByLiveId(1)
+	notFound, notFoundErr := repository.FindByLiveId(2)
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, &domain.LineupRule{
+		LiveId:         1,
+		Part:           []*domain.PartRule{{Part: domain.Dr, Min: 1, Max: 1}},
+		MinMembers:     3,
+		MaxMembers:     5,
+		GtVoCountsAsVo: true,
+	}, actual)
+	assert.Nil(t, notFoundErr)
+	assert.Nil(t, notFound)
+}
+
+func TestBandFindByBandId(t *testing.T) {
+	// given
+	expected := []*domain.Band{
+		{Name: "renamed", LiveId: 1, Turn: 2, SetLength: 30, BandId: 5, Version: 1},
+		{Name: "renamed", LiveId: 3, Turn: 1, SetLength: 20, BandId: 5, Version: 2},
+	}
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Error(err.Error())
+	}
+	defer db.Close()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT " + bandColumns + " WHERE Band.band_id = ? ORDER BY Band.live_id, Band.turn")).
+		WithArgs(5).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "live_id", "turn", "set_length", "band_id", "version"}).
+			AddRow("renamed", 1, 2, 30, 5, 1).
+			AddRow("renamed", 3, 1, 20, 5, 2))
+	repository := NewBandRepositoryImpl(db)
+
+	// when
+	actual, err := repository.FindByBandId(5)
+
+	// then
+	assert.Equal(t, expected, actual)
+	assert.Nil(t, err)
+}
+
+func TestBandMemberFindAppearances(t *testing.T) {
+	// given
+	date := time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2021, 12, 31, 0, 0, 0, 0, time.UTC)
+	expected := []*domain.Appearance{
+		{LiveId: 1, LiveName: "live", Location: "hall", Date: date, BandName: "band", Turn: 2, Part: domain.Dr},
+	}
+
+	tests := []struct {
+		// テスト名
+		testName string
+		// 期間の開始日
+		start *time.Time
+		// 期間の終了日
+		end *time.Time
+		// 期間の条件
+		condition string
+		// 期間の引数
+		args []driver.Value
+	}{
+		{
+			testName: "正常系_期間指定なし",
+		},
+		{
+			testName:  "正常系_期間指定あり",
+			start:     &date,
+			end:       &end,
+			condition: " AND Live.date >= ? AND Live.date <= ?",
+			args:      []driver.Value{"2021-04-01", "2021-12-31"},
+		},
+	}
+
+	for _, tc := range tests {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Error(err.Error())
+		}
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT Live.id, Live.name, Live.location, Live.date, COALESCE(BandProfile.name, Band.name), Band.turn, BandMember.member_part " +
+			"FROM BandMember JOIN Band ON Band.live_id = BandMember.live_id AND Band.turn = BandMember.turn " +
+			"LEFT JOIN BandProfile ON BandProfile.id = Band.band_id JOIN Live ON Live.id = Band.live_id " +
+			"WHERE BandMember.member_id = ?" + tc.condition + " ORDER BY Live.date, Live.id, Band.turn, BandMember.member_part")).
+			WithArgs(append([]driver.Value{3}, tc.args...)...).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "location", "date", "band_name", "turn", "member_part"}).
+				AddRow(1, "live", "hall", date, "band", 2, "Dr."))
+		repository := NewBandMemberRepositoryImpl(db)
+
+		// when
+		actual, err := repository.FindAppearances(3, tc.start, tc.end)
+
+		// then
+		assert.Equal(t, expected, actual, tc.testName)
+		assert.Nil(t, err, tc.testName)
+		assert.Nil(t, mock.ExpectationsWereMet(), tc.testName)
+		db.Close()
+	}
+}
+
+func TestPlayerFindByName(t *testing.T) {
+	// given
+	tests := []struct {
+		// テスト名
+		testName string
+		// Member テーブルに登録されているか
+		exists bool
+		// 戻り値の期待値
+		expected *domain.Member
+	}{
+		{
+			testName: "正常系",
+			exists:   true,
+			expected: &domain.Member{Id: 3, Name: "drummer", Part: []domain.Part{domain.Dr, domain.Vo}},
+		},
+		{
+			testName: "正常系_登録されていない",
+			exists:   false,
+			expected: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Error(err.Error())
+		}
+		rows := sqlmock.NewRows([]string{"id", "name"})
+		if tc.exists {
+			rows.AddRow(3, "drummer")
+		}
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT id, name FROM Member WHERE name = ?")).
+			WithArgs("drummer").
+			WillReturnRows(rows)
+		if tc.exists {
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT part FROM MemberPart WHERE member_id = ? ORDER BY part")).
+				WithArgs(3).
+				WillReturnRows(sqlmock.NewRows([]string{"part"}).AddRow("Dr.").AddRow("Vo."))
+		}
+		repository := NewPlayerRepositoryImpl(db)
+
+		// when
+		actual, err := repository.FindByName("drummer")
+
+		// then
+		assert.Nil(t, err, tc.testName)
+		assert.Equal(t, tc.expected, actual, tc.testName)
+		assert.Nil(t, mock.ExpectationsWereMet(), tc.testName)
+		db.Close()
+	}
+}
+
+func TestEntryApplicationUpdateStatusConflict(t *testing.T) {
+	// given
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Error(err.Error())
+	}
+	defer db.Close()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE EntryApplication SET status = ?, turn = ? WHERE id = ? AND status = ?")).
+		WithArgs("approved", 2, 5, "pending").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM EntryApplication WHERE id = ?")).
+		WithArgs(5).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	repository := NewEntryApplicationRepositoryImpl(db)
+
+	// when
+	err = repository.UpdateStatus(5, domain.ApplicationPending, domain.ApplicationApproved, 2)
+
+	// then
+	assert.True(t, errors.Is(err, domain.ErrConflict))
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestEntryApplicationFindByLiveId(t *testing.T) {
+	// given
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Error(err.Error())
+	}
+	defer db.Close()
+	appliedAt := time.Date(2022, 1, 10, 21, 0, 0, 0, time.UTC)
+	columns := []string{"id", "live_id", "name", "band_id", "preferred_turn", "set_length", "note", "status", "turn", "applied_at"}
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT "+entryApplicationColumns+" FROM EntryApplication WHERE EntryApplication.live_id = ? AND EntryApplication.status = ? ORDER BY id")).
+		WithArgs(1, "pending").
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(5, 1, "first", nil, 0, 20, "", "pending", nil, appliedAt).
+			AddRow(6, 1, "second", nil, 2, 30, "", "pending", nil, appliedAt))
+	// 申込の件数によらず、メンバーは1回のクエリで読み込む
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE EntryApplication.live_id = ? AND EntryApplication.status = ? ORDER BY EntryApplicationMember.application_id")).
+		WithArgs(1, "pending").
+		WillReturnRows(sqlmock.NewRows([]string{"application_id", "id", "name", "member_part"}).
+			AddRow(5, 3, "drummer", "Dr.").
+			AddRow(6, 3, "drummer", "Ba.").
+			AddRow(6, 4, "guitarist", "Gt."))
+	repository := NewEntryApplicationRepositoryImpl(db)
+	pending := domain.ApplicationPending
+
+	// when
+	applications, err := repository.FindByLiveId(1, &pending)
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, []*domain.EntryApplication{
+		{Id: 5, LiveId: 1, Name: "first", SetLength: 20, Status: domain.ApplicationPending, AppliedAt: appliedAt,
+			Player: []*domain.Player{{MemberId: 3, Name: "drummer", Part: domain.Dr}}},
+		{Id: 6, LiveId: 1, Name: "second", PreferredTurn: 2, SetLength: 30, Status: domain.ApplicationPending, AppliedAt: appliedAt,
+			Player: []*domain.Player{{MemberId: 3, Name: "drummer", Part: domain.Ba}, {MemberId: 4, Name: "guitarist", Part: domain.Gt}}},
+	}, applications)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
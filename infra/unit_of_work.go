@@ -0,0 +1,48 @@
+package infra
+
+import (
+	"database/sql"
+	"fmt"
+	"live-scheduler/domain"
+)
+
+type UnitOfWorkImpl struct {
+	db *sql.DB
+}
+
+func NewUnitOfWorkImpl(db *sql.DB) *UnitOfWorkImpl {
+	return &UnitOfWorkImpl{db: db}
+}
+
+// Do fn がエラーを返すか panic した場合はロールバックする
+func (u *UnitOfWorkImpl) Do(fn func(repositories *domain.Repositories) error) error {
+	tx, err := u.db.Begin()
+	if err != nil {
+		return err
+	}
+	repositories := &domain.Repositories{
+		Live:             &LiveRepositoryImpl{db: tx},
+		Band:             &BandRepositoryImpl{db: tx},
+		BandMember:       &BandMemberRepositoryImpl{db: tx},
+		Player:           &PlayerRepositoryImpl{db: tx},
+		Payment:          &PaymentRepositoryImpl{db: tx},
+		LineupRule:       &LineupRuleRepositoryImpl{db: tx},
+		BandProfile:      &BandProfileRepositoryImpl{db: tx},
+		EntryApplication: &EntryApplicationRepositoryImpl{db: tx},
+	}
+	// fn が panic した場合もロールバックしてから panic を伝える
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+	if err := fn(repositories); err != nil {
+		// 呼び出し元が判定できるよう fn のエラーを返し、ロールバックのエラーは付け加えるだけにする
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return fmt.Errorf("%w (rollback: %v)", err, rollbackErr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,110 @@
+package infra
+
+import (
+	"errors"
+	"fmt"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"live-scheduler/domain"
+	"regexp"
+	"testing"
+)
+
+func TestUnitOfWorkDo(t *testing.T) {
+	// given
+	band := domain.Band{Name: "band", LiveId: 1, Turn: 1}
+	bandMember := domain.BandMember{LiveId: 1, Turn: 1, MemberId: 3, MemberName: "drummer", MemberPart: domain.Dr}
+	expectedError := fmt.Errorf("dummy message")
+
+	tests := []struct {
+		// テスト名
+		testName string
+		// BandMember 登録時のエラー
+		memberError error
+	}{
+		{testName: "正常系_コミットされる", memberError: nil},
+		{testName: "異常系_ロールバックされる", memberError: expectedError},
+	}
+
+	for _, tc := range tests {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Error(err.Error())
+		}
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE Live SET band_version = LAST_INSERT_ID(band_version + 1) WHERE id = ?")).
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO Band(name, live_id, turn, set_length, band_id, version) VALUES ( ?, ?, ?, ?, ?, ? )")).
+			WithArgs("band", 1, 1, 0, nil, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		memberExec := mock.ExpectExec(regexp.QuoteMeta("INSERT INTO BandMember(live_id, turn, member_id, member_part) VALUES ( ?, ?, ?, ? )")).
+			WithArgs(1, 1, 3, "Dr.")
+		if tc.memberError == nil {
+			memberExec.WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+		} else {
+			memberExec.WillReturnError(tc.memberError)
+			mock.ExpectRollback()
+		}
+		unitOfWork := NewUnitOfWorkImpl(db)
+
+		// when
+		err = unitOfWork.Do(func(repositories *domain.Repositories) error {
+			if err := repositories.Band.Create(&band); err != nil {
+				return err
+			}
+			return repositories.BandMember.Create(&bandMember)
+		})
+
+		// then
+		assert.Equal(t, tc.memberError, err, fmt.Sprintf("テスト名: %s", tc.testName))
+		assert.Nil(t, mock.ExpectationsWereMet(), fmt.Sprintf("テスト名: %s", tc.testName))
+		db.Close()
+	}
+}
+
+func TestUnitOfWorkDoRollbackError(t *testing.T) {
+	// given
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Error(err.Error())
+	}
+	defer db.Close()
+	mock.ExpectBegin()
+	mock.ExpectRollback().WillReturnError(fmt.Errorf("connection lost"))
+	unitOfWork := NewUnitOfWorkImpl(db)
+
+	// when
+	err = unitOfWork.Do(func(repositories *domain.Repositories) error {
+		return fmt.Errorf("%w: live 1", domain.ErrPreconditionFailed)
+	})
+
+	// then
+	assert.True(t, errors.Is(err, domain.ErrPreconditionFailed))
+	assert.Contains(t, err.Error(), "connection lost")
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestUnitOfWorkDoPanic(t *testing.T) {
+	// given
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Error(err.Error())
+	}
+	defer db.Close()
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	unitOfWork := NewUnitOfWorkImpl(db)
+
+	// when
+	do := func() {
+		_ = unitOfWork.Do(func(repositories *domain.Repositories) error {
+			panic("dummy panic")
+		})
+	}
+
+	// then
+	assert.PanicsWithValue(t, "dummy panic", do)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,132 @@
+package presentation
+
+import (
+	"github.com/labstack/echo/v4"
+	"live-scheduler/domain"
+	"net/http"
+	"strconv"
+)
+
+type BandProfileHandler struct {
+	bandProfileService domain.BandProfileService
+	partService        domain.PartService
+}
+
+func NewBandProfileHandler(bandProfileService domain.BandProfileService, partService domain.PartService) *BandProfileHandler {
+	return &BandProfileHandler{bandProfileService: bandProfileService, partService: partService}
+}
+
+func (h *BandProfileHandler) GetBandProfiles(context echo.Context) error {
+	profiles, err := h.bandProfileService.GetAll()
+	if err != nil {
+		return err
+	}
+	responses := []*BandProfileResponse{}
+	for _, profile := range profiles {
+		responses = append(responses, NewBandProfileResponse(profile))
+	}
+	return context.JSON(http.StatusOK, responses)
+}
+
+func (h *BandProfileHandler) GetBandProfile(context echo.Context) error {
+	bandId, err := strconv.ParseInt(context.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	profile, err := h.bandProfileService.GetById(int(bandId))
+	if err != nil {
+		return err
+	}
+	return context.JSON(http.StatusOK, NewBandProfileResponse(profile))
+}
+
+func (h *BandProfileHandler) GetBandHistory(context echo.Context) error {
+	bandId, err := strconv.ParseInt(context.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	bands, err := h.bandProfileService.GetHistory(int(bandId))
+	if err != nil {
+		return err
+	}
+	return context.JSON(http.StatusOK, NewBandHistoryResponses(bands))
+}
+
+func (h *BandProfileHandler) PostBandProfile(context echo.Context) error {
+	request := new(BandProfileRequest)
+	if err := context.Bind(request); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := context.Validate(request); err != nil {
+		return err
+	}
+	catalog, err := h.partService.Parser()
+	if err != nil {
+		return err
+	}
+	profile, err := request.ToModel(catalog)
+	if err != nil {
+		return err
+	}
+	if err := h.bandProfileService.Register(profile); err != nil {
+		return err
+	}
+	return context.JSON(http.StatusOK, NewBandProfileResponse(profile))
+}
+
+func (h *BandProfileHandler) PatchBandProfile(context echo.Context) error {
+	bandId, err := strconv.ParseInt(context.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	request := new(BandProfileRenameRequest)
+	if err := context.Bind(request); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := context.Validate(request); err != nil {
+		return err
+	}
+	if err := h.bandProfileService.Rename(int(bandId), request.Name); err != nil {
+		return err
+	}
+	return h.GetBandProfile(context)
+}
+
+func (h *BandProfileHandler) PutBandRoster(context echo.Context) error {
+	bandId, err := strconv.ParseInt(context.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	request := new(BandRosterRequest)
+	if err := context.Bind(request); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := context.Validate(request); err != nil {
+		return err
+	}
+	catalog, err := h.partService.Parser()
+	if err != nil {
+		return err
+	}
+	players, err := toPlayers(catalog, request.Member)
+	if err != nil {
+		return err
+	}
+	if err := h.bandProfileService.SaveRoster(int(bandId), players); err != nil {
+		return err
+	}
+	return h.GetBandProfile(context)
+}
+
+// PostBandRosterCopy 出演バンドに紐づくプロフィールの既定のメンバーをバンドメンバーとして登録する
+func (h *BandProfileHandler) PostBandRosterCopy(context echo.Context) error {
+	liveId, turn, err := bandKey(context)
+	if err != nil {
+		return err
+	}
+	conflicts, err := h.bandProfileService.CopyRoster(liveId, turn)
+	if err != nil {
+		return err
+	}
+	return context.JSON(http.StatusOK, NewConflictResponses(conflicts))
+}
@@ -0,0 +1,28 @@
+package presentation
+
+import (
+	"github.com/labstack/echo/v4"
+	"live-scheduler/domain"
+	"net/http"
+	"strconv"
+)
+
+type ConflictHandler struct {
+	conflictService domain.ConflictService
+}
+
+func NewConflictHandler(conflictService domain.ConflictService) *ConflictHandler {
+	return &ConflictHandler{conflictService: conflictService}
+}
+
+func (h *ConflictHandler) GetConflict(context echo.Context) error {
+	liveId, err := strconv.ParseInt(context.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	conflicts, err := h.conflictService.CheckLive(int(liveId))
+	if err != nil {
+		return err
+	}
+	return context.JSON(http.StatusOK, NewConflictResponses(conflicts))
+}
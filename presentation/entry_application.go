@@ -0,0 +1,141 @@
+package presentation
+
+import (
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"live-scheduler/domain"
+	"net/http"
+	"strconv"
+)
+
+type EntryApplicationHandler struct {
+	entryApplicationService domain.EntryApplicationService
+	partService             domain.PartService
+}
+
+func NewEntryApplicationHandler(entryApplicationService domain.EntryApplicationService, partService domain.PartService) *EntryApplicationHandler {
+	return &EntryApplicationHandler{entryApplicationService: entryApplicationService, partService: partService}
+}
+
+// GetEntryApplications ライブの申込を申込順に返す。status を指定した場合はその状態の申込だけを返す
+func (h *EntryApplicationHandler) GetEntryApplications(context echo.Context) error {
+	liveId, err := strconv.ParseInt(context.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	var status *domain.ApplicationStatus
+	if value := context.QueryParam("status"); value != "" {
+		s := domain.ApplicationStatus(value)
+		switch s {
+		case domain.ApplicationPending, domain.ApplicationWaitlisted, domain.ApplicationApproved, domain.ApplicationRejected:
+			status = &s
+		default:
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("invalid status: %s", value))
+		}
+	}
+	applications, err := h.entryApplicationService.GetByLiveId(int(liveId), status)
+	if err != nil {
+		return err
+	}
+	responses := []*EntryApplicationResponse{}
+	for _, application := range applications {
+		responses = append(responses, NewEntryApplicationResponse(application))
+	}
+	return context.JSON(http.StatusOK, responses)
+}
+
+func (h *EntryApplicationHandler) GetEntryApplication(context echo.Context) error {
+	liveId, applicationId, err := applicationKey(context)
+	if err != nil {
+		return err
+	}
+	application, err := h.entryApplicationService.GetById(liveId, applicationId)
+	if err != nil {
+		return err
+	}
+	return context.JSON(http.StatusOK, NewEntryApplicationResponse(application))
+}
+
+// PostEntryApplication 募集中のライブに出演を申し込む
+func (h *EntryApplicationHandler) PostEntryApplication(context echo.Context) error {
+	liveId, err := strconv.ParseInt(context.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	request := new(EntryApplicationRequest)
+	if err := context.Bind(request); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := context.Validate(request); err != nil {
+		return err
+	}
+	catalog, err := h.partService.Parser()
+	if err != nil {
+		return err
+	}
+	application, err := request.ToModel(int(liveId), catalog)
+	if err != nil {
+		return err
+	}
+	if err := h.entryApplicationService.Apply(application); err != nil {
+		return err
+	}
+	return context.JSON(http.StatusOK, NewEntryApplicationResponse(application))
+}
+
+// PostEntryApplicationApprove 申込を承認し、出演バンドとメンバーを登録する。承認した申込と登録後のライブの出演の重複を返す
+func (h *EntryApplicationHandler) PostEntryApplicationApprove(context echo.Context) error {
+	liveId, applicationId, err := applicationKey(context)
+	if err != nil {
+		return err
+	}
+	request := new(EntryApplicationApproveRequest)
+	if err := context.Bind(request); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := context.Validate(request); err != nil {
+		return err
+	}
+	application, conflicts, err := h.entryApplicationService.Approve(liveId, applicationId, request.Turn)
+	if err != nil {
+		return err
+	}
+	return context.JSON(http.StatusOK, &EntryApplicationApproveResponse{Application: NewEntryApplicationResponse(application), Conflict: NewConflictResponses(conflicts)})
+}
+
+func (h *EntryApplicationHandler) PostEntryApplicationReject(context echo.Context) error {
+	liveId, applicationId, err := applicationKey(context)
+	if err != nil {
+		return err
+	}
+	application, err := h.entryApplicationService.Reject(liveId, applicationId)
+	if err != nil {
+		return err
+	}
+	return context.JSON(http.StatusOK, NewEntryApplicationResponse(application))
+}
+
+func (h *EntryApplicationHandler) PostEntryApplicationWaitlist(context echo.Context) error {
+	liveId, applicationId, err := applicationKey(context)
+	if err != nil {
+		return err
+	}
+	application, err := h.entryApplicationService.Waitlist(liveId, applicationId)
+	if err != nil {
+		return err
+	}
+	return context.JSON(http.StatusOK, NewEntryApplicationResponse(application))
+}
+
+// applicationKey パスのライブ ID と申込 ID を返す
+func applicationKey(context echo.Context) (int, int, error) {
+	liveId, err := strconv.ParseInt(context.Param("live_id"), 10, 64)
+	if err != nil {
+		return 0, 0, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	applicationId, err := strconv.ParseInt(context.Param("application_id"), 10, 64)
+	if err != nil {
+		return 0, 0, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	return int(liveId), int(applicationId), nil
+}
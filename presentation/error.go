@@ -0,0 +1,69 @@
+package presentation
+
+import (
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"live-scheduler/domain"
+	"net/http"
+	"strings"
+)
+
+// ErrorResponse エラー時のレスポンス。code は分類ごとに固定の文字列、message は原因を表す
+type ErrorResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// errorKinds domain のエラーの分類とステータスコードの対応
+var errorKinds = []struct {
+	kind   error
+	status int
+	code   string
+}{
+	{kind: domain.ErrNotFound, status: http.StatusNotFound, code: "not_found"},
+	{kind: domain.ErrConflict, status: http.StatusConflict, code: "conflict"},
+	{kind: domain.ErrForeignKeyViolation, status: http.StatusConflict, code: "foreign_key_violation"},
+	{kind: domain.ErrValidation, status: http.StatusUnprocessableEntity, code: "validation_failed"},
+	{kind: domain.ErrPreconditionFailed, status: http.StatusPreconditionFailed, code: "precondition_failed"},
+	{kind: domain.ErrBadRequest, status: http.StatusBadRequest, code: "bad_request"},
+}
+
+// NewErrorResponse エラーをステータスコードとレスポンスに変換する。
+// 分類できないエラーは 500 とし、内部の情報を返さないようにメッセージは固定にする
+func NewErrorResponse(err error) (int, *ErrorResponse) {
+	var httpError *echo.HTTPError
+	if errors.As(err, &httpError) {
+		return httpError.Code, &ErrorResponse{Code: statusCode(httpError.Code), Message: fmt.Sprint(httpError.Message)}
+	}
+	for _, e := range errorKinds {
+		if errors.Is(err, e.kind) {
+			return e.status, &ErrorResponse{Code: e.code, Message: err.Error()}
+		}
+	}
+	return http.StatusInternalServerError, &ErrorResponse{Code: statusCode(http.StatusInternalServerError), Message: http.StatusText(http.StatusInternalServerError)}
+}
+
+// statusCode ステータスコードの名前を snake_case にする(例: 400 -> bad_request)
+func statusCode(status int) string {
+	return strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_")
+}
+
+// ErrorHandler ハンドラが返したエラーを ErrorResponse の形式で返す。Echo の HTTPErrorHandler に設定する
+func ErrorHandler(err error, context echo.Context) {
+	if context.Response().Committed {
+		return
+	}
+	status, response := NewErrorResponse(err)
+	if status == http.StatusInternalServerError {
+		context.Logger().Error(err)
+	}
+	if context.Request().Method == http.MethodHead {
+		err = context.NoContent(status)
+	} else {
+		err = context.JSON(status, response)
+	}
+	if err != nil {
+		context.Logger().Error(err)
+	}
+}
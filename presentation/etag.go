@@ -0,0 +1,63 @@
+package presentation
+
+import (
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// etag バージョンを ETag ヘッダの値(例: "3")にする
+func etag(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+// setETag レスポンスにバージョンを ETag として設定する
+func setETag(context echo.Context, version int) {
+	context.Response().Header().Set("ETag", etag(version))
+}
+
+// ifMatch If-Match ヘッダに指定されたバージョンを返す。
+// 指定がない場合は 428、GET で受け取った ETag の形式でない場合は 400 を返す
+func ifMatch(context echo.Context) (int, error) {
+	value := strings.TrimSpace(context.Request().Header.Get("If-Match"))
+	if value == "" {
+		return 0, echo.NewHTTPError(http.StatusPreconditionRequired, "If-Match header is required")
+	}
+	unquoted, err := strconv.Unquote(value)
+	if err != nil {
+		return 0, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("invalid If-Match header: %s", value))
+	}
+	version, err := strconv.Atoi(unquoted)
+	if err != nil {
+		return 0, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("invalid If-Match header: %s", value))
+	}
+	return version, nil
+}
+
+// setLastModified レスポンスに最終更新日時を設定する
+func setLastModified(context echo.Context, modifiedAt time.Time) {
+	context.Response().Header().Set("Last-Modified", modifiedAt.UTC().Format(http.TimeFormat))
+}
+
+// notModified 条件付き GET の条件から 304 を返してよいかを判定する。
+// If-None-Match がある場合はそれだけで判定し(弱い比較)、ない場合は If-Modified-Since 以降に更新されていないかで判定する
+func notModified(context echo.Context, version int, modifiedAt time.Time) bool {
+	header := context.Request().Header
+	if noneMatch := header.Get("If-None-Match"); noneMatch != "" {
+		for _, tag := range strings.Split(noneMatch, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == "*" || tag == etag(version) {
+				return true
+			}
+		}
+		return false
+	}
+	since, err := http.ParseTime(header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	return !modifiedAt.Truncate(time.Second).After(since)
+}
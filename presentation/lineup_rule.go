@@ -0,0 +1,77 @@
+package presentation
+
+import (
+	"github.com/labstack/echo/v4"
+	"live-scheduler/domain"
+	"net/http"
+	"strconv"
+)
+
+type LineupRuleHandler struct {
+	lineupRuleService domain.LineupRuleService
+	liveDescService   domain.LiveDescService
+	partService       domain.PartService
+}
+
+func NewLineupRuleHandler(lineupRuleService domain.LineupRuleService, liveDescService domain.LiveDescService, partService domain.PartService) *LineupRuleHandler {
+	return &LineupRuleHandler{lineupRuleService: lineupRuleService, liveDescService: liveDescService, partService: partService}
+}
+
+func (h *LineupRuleHandler) GetLineupRule(context echo.Context) error {
+	liveId, err := strconv.ParseInt(context.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	rule, err := h.lineupRuleService.GetByLiveId(int(liveId))
+	if err != nil {
+		return err
+	}
+	return context.JSON(http.StatusOK, NewLineupRuleResponse(rule))
+}
+
+func (h *LineupRuleHandler) PutLineupRule(context echo.Context) error {
+	liveId, err := strconv.ParseInt(context.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	request := new(LineupRuleRequest)
+	if err := context.Bind(request); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := context.Validate(request); err != nil {
+		return err
+	}
+	catalog, err := h.partService.Parser()
+	if err != nil {
+		return err
+	}
+	rule, err := request.ToModel(catalog, int(liveId))
+	if err != nil {
+		return err
+	}
+	err = h.lineupRuleService.Save(rule)
+	if err != nil {
+		return err
+	}
+	return context.JSON(http.StatusOK, NewLineupRuleResponse(rule))
+}
+
+func (h *LineupRuleHandler) GetValidation(context echo.Context) error {
+	liveId, err := strconv.ParseInt(context.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	liveModel, err := h.liveDescService.GetById(int(liveId))
+	if err != nil {
+		return err
+	}
+	validations, err := h.lineupRuleService.Validate(liveModel)
+	if err != nil {
+		return err
+	}
+	response := []*BandValidationResponsePart{}
+	for _, validation := range validations {
+		response = append(response, NewBandValidationResponsePart(validation))
+	}
+	return context.JSON(http.StatusOK, response)
+}
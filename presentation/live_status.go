@@ -0,0 +1,41 @@
+package presentation
+
+import (
+	"github.com/labstack/echo/v4"
+	"live-scheduler/domain"
+	"net/http"
+	"strconv"
+)
+
+type LiveStatusHandler struct {
+	liveStatusService domain.LiveStatusService
+}
+
+func NewLiveStatusHandler(liveStatusService domain.LiveStatusService) *LiveStatusHandler {
+	return &LiveStatusHandler{liveStatusService: liveStatusService}
+}
+
+// PostLiveTransition ライブの状態を遷移させ、更新後のライブを返す
+func (h *LiveStatusHandler) PostLiveTransition(context echo.Context) error {
+	liveId, err := strconv.ParseInt(context.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	request := new(LiveTransitionRequest)
+	if err := context.Bind(request); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := context.Validate(request); err != nil {
+		return err
+	}
+	version, err := ifMatch(context)
+	if err != nil {
+		return err
+	}
+	live, err := h.liveStatusService.Transition(int(liveId), version, domain.LiveStatus(request.To))
+	if err != nil {
+		return err
+	}
+	setETag(context, live.Version)
+	return context.JSON(http.StatusOK, NewLiveResponse(live))
+}
@@ -0,0 +1,125 @@
+package presentation
+
+import (
+	"github.com/labstack/echo/v4"
+	"live-scheduler/domain"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type MemberHandler struct {
+	playerService domain.PlayerService
+	partService   domain.PartService
+}
+
+func NewMemberHandler(playerService domain.PlayerService, partService domain.PartService) *MemberHandler {
+	return &MemberHandler{playerService: playerService, partService: partService}
+}
+
+func (h *MemberHandler) GetMember(context echo.Context) error {
+	memberId, err := strconv.ParseInt(context.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	member, err := h.playerService.GetById(int(memberId))
+	if err != nil {
+		return err
+	}
+	return context.JSON(http.StatusOK, NewMemberResponse(member))
+}
+
+func (h *MemberHandler) PatchMember(context echo.Context) error {
+	memberId, err := strconv.ParseInt(context.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	request := new(MemberRenameRequest)
+	if err := context.Bind(request); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := context.Validate(request); err != nil {
+		return err
+	}
+	if err := h.playerService.Rename(int(memberId), request.Name); err != nil {
+		return err
+	}
+	return h.GetMember(context)
+}
+
+func (h *MemberHandler) PostMemberPart(context echo.Context) error {
+	memberId, err := strconv.ParseInt(context.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	request := new(MemberPartRequest)
+	if err := context.Bind(request); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := context.Validate(request); err != nil {
+		return err
+	}
+	catalog, err := h.partService.Parser()
+	if err != nil {
+		return err
+	}
+	part, err := request.ToModel(catalog)
+	if err != nil {
+		return err
+	}
+	if err := h.playerService.AddPart(int(memberId), part); err != nil {
+		return err
+	}
+	return h.GetMember(context)
+}
+
+func (h *MemberHandler) DeleteMemberPart(context echo.Context) error {
+	memberId, err := strconv.ParseInt(context.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	request := new(MemberPartRequest)
+	if err := context.Bind(request); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := context.Validate(request); err != nil {
+		return err
+	}
+	catalog, err := h.partService.Parser()
+	if err != nil {
+		return err
+	}
+	part, err := request.ToModel(catalog)
+	if err != nil {
+		return err
+	}
+	if err := h.playerService.RemovePart(int(memberId), part); err != nil {
+		return err
+	}
+	return h.GetMember(context)
+}
+
+// GetMemberHistory 名前で指定したメンバーの出演履歴を返す。start, end を省略した場合は期間を制限しない
+func (h *MemberHandler) GetMemberHistory(context echo.Context) error {
+	var start, end time.Time
+	err := echo.QueryParamsBinder(context).
+		Time("start", &start, LAYOUT).
+		Time("end", &end, LAYOUT).
+		BindError()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	history, err := h.playerService.GetHistory(context.Param("name"), optionalDate(start), optionalDate(end))
+	if err != nil {
+		return err
+	}
+	return context.JSON(http.StatusOK, NewMemberHistoryResponse(history))
+}
+
+// optionalDate クエリパラメータで指定されなかった日付を nil にする
+func optionalDate(date time.Time) *time.Time {
+	if date.IsZero() {
+		return nil
+	}
+	return &date
+}
@@ -0,0 +1,50 @@
+package presentation
+
+import (
+	"github.com/labstack/echo/v4"
+	"live-scheduler/domain"
+	"net/http"
+)
+
+type PartHandler struct {
+	partService domain.PartService
+}
+
+func NewPartHandler(partService domain.PartService) *PartHandler {
+	return &PartHandler{partService: partService}
+}
+
+func (h *PartHandler) GetParts(context echo.Context) error {
+	parts, err := h.partService.GetAll()
+	if err != nil {
+		return err
+	}
+	responses := []*PartDefinitionResponse{}
+	for _, part := range parts {
+		responses = append(responses, NewPartDefinitionResponse(part))
+	}
+	return context.JSON(http.StatusOK, responses)
+}
+
+// PutPart パートをカタログに登録する。登録済みの場合は表示名、分類、表示順を置き換える
+func (h *PartHandler) PutPart(context echo.Context) error {
+	request := new(PartDefinitionRequest)
+	if err := context.Bind(request); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := context.Validate(request); err != nil {
+		return err
+	}
+	part := request.ToModel(context.Param("code"))
+	if err := h.partService.Save(part); err != nil {
+		return err
+	}
+	return context.JSON(http.StatusOK, NewPartDefinitionResponse(part))
+}
+
+func (h *PartHandler) DeletePart(context echo.Context) error {
+	if err := h.partService.Delete(domain.Part(context.Param("code"))); err != nil {
+		return err
+	}
+	return context.NoContent(http.StatusOK)
+}
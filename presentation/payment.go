@@ -0,0 +1,73 @@
+package presentation
+
+import (
+	"github.com/labstack/echo/v4"
+	"live-scheduler/domain"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type PaymentHandler struct {
+	paymentService domain.PaymentService
+}
+
+func NewPaymentHandler(paymentService domain.PaymentService) *PaymentHandler {
+	return &PaymentHandler{paymentService: paymentService}
+}
+
+func (h *PaymentHandler) GetPayment(context echo.Context) error {
+	liveId, err := strconv.ParseInt(context.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	player := context.QueryParam("player")
+	payments, err := h.paymentService.GetByLiveId(int(liveId))
+	if err != nil {
+		return err
+	}
+	var response []*PaymentResponse
+	for _, payment := range payments {
+		if player != "" && payment.PlayerName != player {
+			continue
+		}
+		response = append(response, NewPaymentResponse(payment))
+	}
+	return context.JSON(http.StatusOK, response)
+}
+
+func (h *PaymentHandler) PostPayment(context echo.Context) error {
+	liveId, err := strconv.ParseInt(context.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	request := new(PaymentRequest)
+	if err := context.Bind(request); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := context.Validate(request); err != nil {
+		return err
+	}
+	payment := request.ToModel(int(liveId))
+	err = h.paymentService.Record(payment)
+	if err != nil {
+		return err
+	}
+	return context.JSON(http.StatusOK, NewPaymentResponse(payment))
+}
+
+func (h *PaymentHandler) GetReconciliation(context echo.Context) error {
+	var start, end time.Time
+	err := echo.QueryParamsBinder(context).
+		Time("start", &start, LAYOUT).
+		Time("end", &end, LAYOUT).
+		BindError()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	reconciliation, err := h.paymentService.Reconcile(&start, &end)
+	if err != nil {
+		return err
+	}
+	return context.JSON(http.StatusOK, NewReconciliationResponse(reconciliation))
+}
@@ -19,6 +19,14 @@ type LiveCreateRequest struct {
 	PerformanceFee int `json:"performance_fee" validate:"required"`
 	// 1バンドあたりの機材費
 	EquipmentCost int `json:"equipment_cost" validate:"required"`
+	// 開場時刻(例: "17:30")
+	OpenTime domain.Clock `json:"open_time"`
+	// 開演時刻(例: "18:00")
+	StartTime domain.Clock `json:"start_time"`
+	// 終演時刻(例: "21:30")
+	CloseTime domain.Clock `json:"close_time"`
+	// 転換時間(分)
+	Changeover int `json:"changeover" validate:"min=0"`
 }
 
 func (r LiveCreateRequest) ToModel() *domain.Live {
@@ -28,73 +36,256 @@ func (r LiveCreateRequest) ToModel() *domain.Live {
 		Date:           r.Date,
 		PerformanceFee: r.PerformanceFee,
 		EquipmentCost:  r.EquipmentCost,
+		OpenTime:       r.OpenTime,
+		StartTime:      r.StartTime,
+		CloseTime:      r.CloseTime,
+		Changeover:     r.Changeover,
 	}
 }
 
+// LivePatchRequest 指定したフィールドだけを更新する。省略したフィールドは変更しない
 type LivePatchRequest struct {
 	// ライブID
 	Id int `json:"id" validate:"required"`
 	// ライブ名
-	Name string `json:"name"`
+	Name *string `json:"name" validate:"omitempty,min=1"`
 	// 場所
-	Location string `json:"location"`
+	Location *string `json:"location" validate:"omitempty,min=1"`
 	// 日付
-	Date time.Time `json:"date"`
+	Date *time.Time `json:"date"`
 	// 1人あたりの出演料
-	PerformanceFee int `json:"performance_fee"`
+	PerformanceFee *int `json:"performance_fee" validate:"omitempty,min=0"`
 	// 1バンドあたりの機材費
-	EquipmentCost int `json:"equipment_cost"`
+	EquipmentCost *int `json:"equipment_cost" validate:"omitempty,min=0"`
+	// 開場時刻(空文字で未設定に戻す)
+	OpenTime *domain.Clock `json:"open_time"`
+	// 開演時刻(空文字で未設定に戻す)
+	StartTime *domain.Clock `json:"start_time"`
+	// 終演時刻(空文字で未設定に戻す)
+	CloseTime *domain.Clock `json:"close_time"`
+	// 転換時間(分)
+	Changeover *int `json:"changeover" validate:"omitempty,min=0"`
 }
 
-func (r LivePatchRequest) ToModel() *domain.Live {
-	return &domain.Live{
-		Id:             r.Id,
+func (r LivePatchRequest) ToModel() *domain.LivePatch {
+	return &domain.LivePatch{
 		Name:           r.Name,
 		Location:       r.Location,
 		Date:           r.Date,
 		PerformanceFee: r.PerformanceFee,
 		EquipmentCost:  r.EquipmentCost,
+		OpenTime:       r.OpenTime,
+		StartTime:      r.StartTime,
+		CloseTime:      r.CloseTime,
+		Changeover:     r.Changeover,
 	}
 }
 
+type LiveTransitionRequest struct {
+	// 遷移先の状態
+	To string `json:"to" validate:"required,oneof=draft entry_open entry_closed confirmed done cancelled"`
+}
+
 type BandCreateRequest struct {
-	LiveId int    `json:"live_id" validate:"required"`
-	Name   string `json:"name" validate:"required"`
-	Turn   int    `json:"turn" validate:"required"`
+	LiveId    int    `json:"live_id" validate:"required"`
+	Name      string `json:"name" validate:"required_without=BandId"`
+	Turn      int    `json:"turn" validate:"required"`
+	SetLength int    `json:"set_length" validate:"min=0"`
+	// バンドプロフィールの ID(省略時はプロフィールに紐づけない)
+	BandId int `json:"band_id" validate:"min=0"`
 }
 
 func (r BandCreateRequest) ToModel() *domain.Band {
 	return &domain.Band{
-		Name:   r.Name,
-		LiveId: r.LiveId,
-		Turn:   r.Turn,
+		Name:      r.Name,
+		LiveId:    r.LiveId,
+		Turn:      r.Turn,
+		SetLength: r.SetLength,
+		BandId:    r.BandId,
 	}
 }
 
+// BandPatchRequest 指定したフィールドだけを更新する。省略したフィールドは変更しない。
+// turn を指定した場合は移動(/live/:id/band/move)と同じくバンドメンバーと合わせて移動する
 type BandPatchRequest struct {
-	LiveId int    `json:"live_id"`
-	Name   string `json:"name"`
-	Turn   int    `json:"turn"`
+	Name      *string `json:"name" validate:"omitempty,min=1"`
+	Turn      *int    `json:"turn" validate:"omitempty,min=1"`
+	SetLength *int    `json:"set_length" validate:"omitempty,min=0"`
+	// バンドプロフィールの ID(0 でプロフィールとの紐づけを外す)
+	BandId *int `json:"band_id" validate:"omitempty,min=0"`
 }
 
-func (r BandPatchRequest) ToModel() *domain.Band {
-	return &domain.Band{
-		Name:   r.Name,
-		LiveId: r.LiveId,
-		Turn:   r.Turn,
+func (r BandPatchRequest) ToModel() *domain.BandPatch {
+	return &domain.BandPatch{
+		Name:      r.Name,
+		SetLength: r.SetLength,
+		BandId:    r.BandId,
 	}
 }
 
+type BandMoveRequest struct {
+	// 移動するバンドの出演順
+	From int `json:"from" validate:"required"`
+	// 移動先の出演順
+	To int `json:"to" validate:"required,min=1"`
+}
+
+type BandSwapRequest struct {
+	// 入れ替えるバンドの出演順
+	Turn1 int `json:"turn1" validate:"required"`
+	// 入れ替えるもう一方のバンドの出演順
+	Turn2 int `json:"turn2" validate:"required"`
+}
+
+type BandOrderRequest struct {
+	// 現在の出演順を新しい並びで指定する
+	Turns []int `json:"turns" validate:"required"`
+}
+
 type PlayerRequest struct {
 	Name string `json:"name" validate:"required"`
 	Part string `json:"part" validate:"required"`
 }
 
-func (p *PlayerRequest) ToModel() *domain.Player {
+// ToModel パートはカタログのパートに変換する。変換できない場合は domain.ErrUnknownPart を返す
+func (p *PlayerRequest) ToModel(catalog domain.PartCatalog) (*domain.Player, error) {
+	part, err := catalog.Parse(p.Part)
+	if err != nil {
+		return nil, err
+	}
 	return &domain.Player{
 		Name: p.Name,
-		Part: domain.Part(p.Part),
+		Part: part,
+	}, nil
+}
+
+func (p *PlayerRequest) ToBandMember(catalog domain.PartCatalog, liveId int, turn int) (*domain.BandMember, error) {
+	player, err := p.ToModel(catalog)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.BandMember{
+		LiveId:     liveId,
+		Turn:       turn,
+		MemberName: player.Name,
+		MemberPart: player.Part,
+	}, nil
+}
+
+type BandMemberReplaceRequest struct {
+	// 入れ替え対象のメンバー
+	Current PlayerRequest `json:"current"`
+	// 新しく加入するメンバー
+	Replacement PlayerRequest `json:"replacement"`
+}
+
+type LineupRequest struct {
+	// 出演するバンド
+	Band []*LineupBandRequest `json:"band" validate:"required,dive"`
+}
+
+func (r LineupRequest) ToModel(catalog domain.PartCatalog, liveId int) ([]*domain.BandModel, error) {
+	var bands []*domain.BandModel
+	for _, band := range r.Band {
+		players, err := toPlayers(catalog, band.Member)
+		if err != nil {
+			return nil, err
+		}
+		bands = append(bands, &domain.BandModel{
+			Name:      band.Name,
+			LiveId:    liveId,
+			Turn:      band.Turn,
+			SetLength: band.SetLength,
+			BandId:    band.BandId,
+			Player:    players,
+		})
 	}
+	return bands, nil
+}
+
+type LineupBandRequest struct {
+	// バンド名
+	Name string `json:"name" validate:"required_without=BandId"`
+	// 出演順
+	Turn int `json:"turn" validate:"required"`
+	// 持ち時間(分)
+	SetLength int `json:"set_length" validate:"min=0"`
+	// バンドプロフィールの ID(省略時はプロフィールに紐づけない)
+	BandId int `json:"band_id" validate:"min=0"`
+	// メンバー
+	Member []*PlayerRequest `json:"member" validate:"dive"`
+}
+
+type PaymentRequest struct {
+	// 支払ったメンバーの名前
+	PlayerName string `json:"player_name" validate:"required"`
+	// 入金か返金か
+	Kind string `json:"kind" validate:"required,oneof=payment refund"`
+	// 支払方法
+	Method string `json:"method" validate:"required,oneof=cash transfer other"`
+	// 金額
+	Amount int `json:"amount" validate:"required,min=1"`
+	// 支払日時(省略時は現在時刻)
+	PaidAt time.Time `json:"paid_at"`
+	// 備考
+	Note string `json:"note"`
+}
+
+func (r PaymentRequest) ToModel(liveId int) *domain.Payment {
+	paidAt := r.PaidAt
+	if paidAt.IsZero() {
+		paidAt = time.Now()
+	}
+	return &domain.Payment{
+		LiveId:     liveId,
+		PlayerName: r.PlayerName,
+		Kind:       domain.PaymentKind(r.Kind),
+		Method:     domain.PaymentMethod(r.Method),
+		Amount:     r.Amount,
+		PaidAt:     paidAt,
+		Note:       r.Note,
+	}
+}
+
+type LineupRuleRequest struct {
+	// パートごとの人数のルール
+	Part []*PartRuleRequest `json:"part" validate:"dive"`
+	// 最少人数(0 の場合は制限なし)
+	MinMembers int `json:"min_members" validate:"min=0"`
+	// 最大人数(0 の場合は制限なし)
+	MaxMembers int `json:"max_members" validate:"min=0"`
+	// Gt.Vo. を Vo. の人数に含めるか
+	GtVoCountsAsVo bool `json:"gtvo_counts_as_vo"`
+	// Gt.Vo. を Gt. の人数に含めるか
+	GtVoCountsAsGt bool `json:"gtvo_counts_as_gt"`
+}
+
+func (r LineupRuleRequest) ToModel(catalog domain.PartCatalog, liveId int) (*domain.LineupRule, error) {
+	var parts []*domain.PartRule
+	for _, partRule := range r.Part {
+		part, err := catalog.Parse(partRule.Part)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, &domain.PartRule{Part: part, Min: partRule.Min, Max: partRule.Max})
+	}
+	return &domain.LineupRule{
+		LiveId:         liveId,
+		Part:           parts,
+		MinMembers:     r.MinMembers,
+		MaxMembers:     r.MaxMembers,
+		GtVoCountsAsVo: r.GtVoCountsAsVo,
+		GtVoCountsAsGt: r.GtVoCountsAsGt,
+	}, nil
+}
+
+type PartRuleRequest struct {
+	// パート
+	Part string `json:"part" validate:"required"`
+	// 必要な人数
+	Min int `json:"min" validate:"min=0"`
+	// 最大人数(0 の場合は制限なし)
+	Max int `json:"max" validate:"min=0"`
 }
 
 type CustomValidator struct {
@@ -111,3 +302,135 @@ func (cv *CustomValidator) Validate(i interface{}) error {
 func NewCustomValidator() *CustomValidator {
 	return &CustomValidator{validator: validator.New()}
 }
+
+type RunningOrderRequest struct {
+	// トップバッターに固定するバンドの現在の出演順
+	Opener int `json:"opener" validate:"min=0"`
+	// トリに固定するバンドの現在の出演順
+	Headliner int `json:"headliner" validate:"min=0"`
+	// 連続出演のペナルティ(省略時は既定値)
+	ConsecutivePenalty *int `json:"consecutive_penalty" validate:"omitempty,min=0"`
+	// メンバーの入れ替わりのペナルティ(省略時は既定値)
+	ChangePenalty *int `json:"change_penalty" validate:"omitempty,min=0"`
+}
+
+func (r RunningOrderRequest) ToModel() *domain.RunningOrderOption {
+	option := &domain.RunningOrderOption{
+		Opener:             r.Opener,
+		Headliner:          r.Headliner,
+		ConsecutivePenalty: domain.DefaultConsecutivePenalty,
+		ChangePenalty:      domain.DefaultChangePenalty,
+	}
+	if r.ConsecutivePenalty != nil {
+		option.ConsecutivePenalty = *r.ConsecutivePenalty
+	}
+	if r.ChangePenalty != nil {
+		option.ChangePenalty = *r.ChangePenalty
+	}
+	return option
+}
+
+type BandProfileRequest struct {
+	// バンド名
+	Name string `json:"name" validate:"required"`
+	// 既定のメンバー
+	Member []*PlayerRequest `json:"member" validate:"dive"`
+}
+
+func (r BandProfileRequest) ToModel(catalog domain.PartCatalog) (*domain.BandProfile, error) {
+	players, err := toPlayers(catalog, r.Member)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.BandProfile{Name: r.Name, Player: players}, nil
+}
+
+type BandProfileRenameRequest struct {
+	// 新しいバンド名
+	Name string `json:"name" validate:"required"`
+}
+
+type BandRosterRequest struct {
+	// 既定のメンバー
+	Member []*PlayerRequest `json:"member" validate:"dive"`
+}
+
+func toPlayers(catalog domain.PartCatalog, members []*PlayerRequest) ([]*domain.Player, error) {
+	var players []*domain.Player
+	for _, member := range members {
+		player, err := member.ToModel(catalog)
+		if err != nil {
+			return nil, err
+		}
+		players = append(players, player)
+	}
+	return players, nil
+}
+
+type MemberRenameRequest struct {
+	// 新しい表示名
+	Name string `json:"name" validate:"required"`
+}
+
+type MemberPartRequest struct {
+	// パート
+	Part string `json:"part" validate:"required"`
+}
+
+func (r MemberPartRequest) ToModel(catalog domain.PartCatalog) (domain.Part, error) {
+	return catalog.Parse(r.Part)
+}
+
+type PartDefinitionRequest struct {
+	// 表示名(省略時はコード)
+	DisplayName string `json:"display_name"`
+	// 分類
+	Category string `json:"category" validate:"required,oneof=rhythm melody vocal"`
+	// 表示順
+	SortOrder int `json:"sort_order"`
+}
+
+func (r PartDefinitionRequest) ToModel(code string) *domain.PartDefinition {
+	return &domain.PartDefinition{
+		Code:        domain.Part(code),
+		DisplayName: r.DisplayName,
+		Category:    domain.PartCategory(r.Category),
+		SortOrder:   r.SortOrder,
+	}
+}
+
+type EntryApplicationRequest struct {
+	// 希望するバンド名
+	Name string `json:"name" validate:"required"`
+	// バンドプロフィールの ID(省略時はプロフィールに紐づけない)
+	BandId int `json:"band_id" validate:"min=0"`
+	// 出演するメンバー
+	Member []*PlayerRequest `json:"member" validate:"required,min=1,dive"`
+	// 希望する出演順(省略時は希望なし)
+	PreferredTurn int `json:"preferred_turn" validate:"min=0"`
+	// 持ち時間(分)
+	SetLength int `json:"set_length" validate:"min=0"`
+	// 備考
+	Note string `json:"note" validate:"max=255"`
+}
+
+func (r EntryApplicationRequest) ToModel(liveId int, catalog domain.PartCatalog) (*domain.EntryApplication, error) {
+	players, err := toPlayers(catalog, r.Member)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.EntryApplication{
+		LiveId:        liveId,
+		Name:          r.Name,
+		BandId:        r.BandId,
+		Player:        players,
+		PreferredTurn: r.PreferredTurn,
+		SetLength:     r.SetLength,
+		Note:          r.Note,
+	}, nil
+}
+
+type EntryApplicationApproveRequest struct {
+	// 登録する出演順(省略時は希望する出演順、空いていなければ最後)
+	Turn int `json:"turn" validate:"min=0"`
+}
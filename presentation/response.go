@@ -18,6 +18,20 @@ type LiveResponse struct {
 	PerformanceFee int `json:"performance_fee"`
 	// 1バンドあたりの機材費
 	EquipmentCost int `json:"equipment_cost"`
+	// 開場時刻
+	OpenTime domain.Clock `json:"open_time,omitempty"`
+	// 開演時刻
+	StartTime domain.Clock `json:"start_time,omitempty"`
+	// 終演時刻
+	CloseTime domain.Clock `json:"close_time,omitempty"`
+	// 転換時間(分)
+	Changeover int `json:"changeover"`
+	// バージョン(If-Match に指定する ETag と同じ値)
+	Version int `json:"version"`
+	// 状態
+	Status domain.LiveStatus `json:"status"`
+	// 出演するバンド
+	Band []*BandResponsePart `json:"band,omitempty"`
 }
 
 func (r LiveResponse) ToModel() *domain.Live {
@@ -28,6 +42,10 @@ func (r LiveResponse) ToModel() *domain.Live {
 		Date:           r.Date,
 		PerformanceFee: r.PerformanceFee,
 		EquipmentCost:  r.EquipmentCost,
+		OpenTime:       r.OpenTime,
+		StartTime:      r.StartTime,
+		CloseTime:      r.CloseTime,
+		Changeover:     r.Changeover,
 	}
 }
 
@@ -39,6 +57,12 @@ func NewLiveResponse(live *domain.Live) *LiveResponse {
 		Date:           live.Date,
 		PerformanceFee: live.PerformanceFee,
 		EquipmentCost:  live.EquipmentCost,
+		OpenTime:       live.OpenTime,
+		StartTime:      live.StartTime,
+		CloseTime:      live.CloseTime,
+		Changeover:     live.Changeover,
+		Version:        live.Version,
+		Status:         live.Status,
 	}
 }
 
@@ -53,14 +77,62 @@ type LiveDescResponse struct {
 	PerformanceFee int `json:"performance_fee"`
 	// 1バンドあたりの機材費
 	EquipmentCost int `json:"equipment_cost"`
+	// 開場時刻
+	OpenTime domain.Clock `json:"open_time,omitempty"`
+	// 開演時刻
+	StartTime domain.Clock `json:"start_time,omitempty"`
+	// 終演時刻
+	CloseTime domain.Clock `json:"close_time,omitempty"`
+	// 転換時間(分)
+	Changeover int `json:"changeover"`
+	// バージョン(If-Match に指定する ETag と同じ値)
+	Version int `json:"version"`
+	// 状態
+	Status domain.LiveStatus `json:"status"`
 	// 出演するバンド
 	Band []*BandResponsePart `json:"band,omitempty"`
 }
 
-func NewLiveDescResponse(liveModel *domain.LiveModel) *LiveDescResponse {
-	bands := liveModel.Band
+// NewLiveListResponse 期間内のライブ一覧の1件分を出演バンドとメンバーを含めて返す
+func NewLiveListResponse(liveModel *domain.LiveModel) *LiveResponse {
+	return &LiveResponse{
+		Id:             liveModel.Id,
+		Name:           liveModel.Name,
+		Location:       liveModel.Location,
+		Date:           liveModel.Date,
+		PerformanceFee: liveModel.PerformanceFee,
+		EquipmentCost:  liveModel.EquipmentCost,
+		OpenTime:       liveModel.OpenTime,
+		StartTime:      liveModel.StartTime,
+		CloseTime:      liveModel.CloseTime,
+		Changeover:     liveModel.Changeover,
+		Version:        liveModel.Version,
+		Status:         liveModel.Status,
+		Band:           newBandResponseParts(liveModel.Band, nil),
+	}
+}
+
+func NewLiveDescResponse(liveModel *domain.LiveModel, validations []*domain.BandValidation) *LiveDescResponse {
+	return &LiveDescResponse{
+		Name:           liveModel.Name,
+		Location:       liveModel.Location,
+		Date:           liveModel.Date,
+		PerformanceFee: liveModel.PerformanceFee,
+		EquipmentCost:  liveModel.EquipmentCost,
+		OpenTime:       liveModel.OpenTime,
+		StartTime:      liveModel.StartTime,
+		CloseTime:      liveModel.CloseTime,
+		Changeover:     liveModel.Changeover,
+		Version:        liveModel.Version,
+		Status:         liveModel.Status,
+		Band:           newBandResponseParts(liveModel.Band, validations),
+	}
+}
+
+// newBandResponseParts 出演バンドとメンバーをレスポンスに変換する。validations は bands と同じ順に並んでいる
+func newBandResponseParts(bands []*domain.BandModel, validations []*domain.BandValidation) []*BandResponsePart {
 	var bandResponseParts []*BandResponsePart
-	for _, band := range bands {
+	for i, band := range bands {
 		var memberResponseParts []*MemberResponsePart
 		for _, player := range band.Player {
 			memberResponseParts = append(memberResponseParts, &MemberResponsePart{
@@ -68,20 +140,20 @@ func NewLiveDescResponse(liveModel *domain.LiveModel) *LiveDescResponse {
 				Part: player.Part,
 			})
 		}
+		var validation *BandValidationResponsePart
+		if i < len(validations) {
+			validation = NewBandValidationResponsePart(validations[i])
+		}
 		bandResponseParts = append(bandResponseParts, &BandResponsePart{
-			Name:   band.Name,
-			Turn:   band.Turn,
-			Member: memberResponseParts,
+			Name:       band.Name,
+			Turn:       band.Turn,
+			SetLength:  band.SetLength,
+			Version:    band.Version,
+			Member:     memberResponseParts,
+			Validation: validation,
 		})
 	}
-	return &LiveDescResponse{
-		Name:           liveModel.Name,
-		Location:       liveModel.Location,
-		Date:           liveModel.Date,
-		PerformanceFee: liveModel.PerformanceFee,
-		EquipmentCost:  liveModel.EquipmentCost,
-		Band:           bandResponseParts,
-	}
+	return bandResponseParts
 }
 
 type BandResponsePart struct {
@@ -89,25 +161,624 @@ type BandResponsePart struct {
 	Name string `json:"name"`
 	// 出演順
 	Turn int `json:"turn"`
+	// 持ち時間(分)
+	SetLength int `json:"set_length"`
+	// バージョン(If-Match に指定する ETag と同じ値)
+	Version int `json:"version"`
 	// メンバー
 	Member []*MemberResponsePart `json:"member,omitempty"`
+	// 編成のルールの確認結果
+	Validation *BandValidationResponsePart `json:"validation,omitempty"`
 }
 
 func NewBandResponsePart(band *domain.Band) *BandResponsePart {
 	return &BandResponsePart{
-		Name: band.Name,
-		Turn: band.Turn,
+		Name:      band.Name,
+		Turn:      band.Turn,
+		SetLength: band.SetLength,
+		Version:   band.Version,
 	}
 }
 
+func NewBandListResponse(bands []*domain.Band) []*BandResponsePart {
+	var response []*BandResponsePart
+	for _, band := range bands {
+		response = append(response, NewBandResponsePart(band))
+	}
+	return response
+}
+
 type MemberResponsePart struct {
-	Name string      `json:"name"`
-	Part domain.Part `json:"part"`
+	MemberId int         `json:"member_id,omitempty"`
+	Name     string      `json:"name"`
+	Part     domain.Part `json:"part"`
 }
 
 func NewPlayerResponse(player *domain.Player) *MemberResponsePart {
 	return &MemberResponsePart{
-		Name: player.Name,
-		Part: player.Part,
+		MemberId: player.MemberId,
+		Name:     player.Name,
+		Part:     player.Part,
+	}
+}
+
+type MemberResponse struct {
+	// メンバーID
+	Id int `json:"id"`
+	// 表示名
+	Name string `json:"name"`
+	// 担当できるパート
+	Part []domain.Part `json:"part"`
+}
+
+func NewMemberResponse(member *domain.Member) *MemberResponse {
+	parts := member.Part
+	if parts == nil {
+		parts = []domain.Part{}
+	}
+	return &MemberResponse{Id: member.Id, Name: member.Name, Part: parts}
+}
+
+type LiveDeletionResponse struct {
+	// 削除されるライブ
+	Live *LiveResponse `json:"live"`
+	// 削除されるバンド
+	Band []*BandResponsePart `json:"band,omitempty"`
+	// 削除されるバンド数
+	BandCount int `json:"band_count"`
+	// 削除されるバンドメンバー数
+	MemberCount int `json:"member_count"`
+}
+
+func NewLiveDeletionResponse(deletion *domain.LiveDeletion) *LiveDeletionResponse {
+	var bandResponseParts []*BandResponsePart
+	for _, band := range deletion.Band {
+		bandResponsePart := NewBandResponsePart(band)
+		for _, bandMember := range deletion.BandMember {
+			if bandMember.Turn == band.Turn {
+				bandResponsePart.Member = append(bandResponsePart.Member, &MemberResponsePart{
+					Name: bandMember.MemberName,
+					Part: bandMember.MemberPart,
+				})
+			}
+		}
+		bandResponseParts = append(bandResponseParts, bandResponsePart)
+	}
+	return &LiveDeletionResponse{
+		Live:        NewLiveResponse(deletion.Live),
+		Band:        bandResponseParts,
+		BandCount:   len(deletion.Band),
+		MemberCount: len(deletion.BandMember),
+	}
+}
+
+type SettlementResponse struct {
+	// ライブID
+	LiveId int `json:"live_id"`
+	// 出演料の数え方
+	Rule domain.FeeRule `json:"rule"`
+	// バンドごとの機材費の負担額
+	Band []*BandSettlementResponsePart `json:"band,omitempty"`
+	// メンバーごとの支払額
+	Player []*PlayerSettlementResponsePart `json:"player,omitempty"`
+	// 支払額の合計
+	Total int `json:"total"`
+}
+
+func NewSettlementResponse(settlement *domain.Settlement) *SettlementResponse {
+	var bands []*BandSettlementResponsePart
+	for _, band := range settlement.Band {
+		var shares []*EquipmentShareResponsePart
+		for _, share := range band.Share {
+			shares = append(shares, &EquipmentShareResponsePart{Name: share.Name, Amount: share.Amount})
+		}
+		bands = append(bands, &BandSettlementResponsePart{
+			Name:          band.Name,
+			Turn:          band.Turn,
+			EquipmentCost: band.EquipmentCost,
+			Share:         shares,
+			Unassigned:    band.Unassigned,
+		})
+	}
+	var players []*PlayerSettlementResponsePart
+	for _, player := range settlement.Player {
+		players = append(players, &PlayerSettlementResponsePart{
+			Name:           player.Name,
+			Appearances:    player.Appearances,
+			PerformanceFee: player.PerformanceFee,
+			EquipmentShare: player.EquipmentShare,
+			Total:          player.Total,
+		})
+	}
+	return &SettlementResponse{
+		LiveId: settlement.LiveId,
+		Rule:   settlement.Rule,
+		Band:   bands,
+		Player: players,
+		Total:  settlement.Total,
+	}
+}
+
+type BandSettlementResponsePart struct {
+	// バンド名
+	Name string `json:"name"`
+	// 出演順
+	Turn int `json:"turn"`
+	// 機材費
+	EquipmentCost int `json:"equipment_cost"`
+	// メンバーごとの負担額
+	Share []*EquipmentShareResponsePart `json:"share,omitempty"`
+	// 誰にも割り当てられなかった機材費
+	Unassigned int `json:"unassigned"`
+}
+
+type EquipmentShareResponsePart struct {
+	Name   string `json:"name"`
+	Amount int    `json:"amount"`
+}
+
+type PlayerSettlementResponsePart struct {
+	// メンバーの名前
+	Name string `json:"name"`
+	// 出演するバンド数
+	Appearances int `json:"appearances"`
+	// 出演料
+	PerformanceFee int `json:"performance_fee"`
+	// 機材費の負担額
+	EquipmentShare int `json:"equipment_share"`
+	// 支払額
+	Total int `json:"total"`
+}
+
+type PaymentResponse struct {
+	// 支払ID
+	Id int `json:"id"`
+	// ライブID
+	LiveId int `json:"live_id"`
+	// 支払ったメンバーのID
+	MemberId int `json:"member_id"`
+	// 支払ったメンバーの名前
+	PlayerName string `json:"player_name"`
+	// 入金か返金か
+	Kind domain.PaymentKind `json:"kind"`
+	// 支払方法
+	Method domain.PaymentMethod `json:"method"`
+	// 金額
+	Amount int `json:"amount"`
+	// 支払日時
+	PaidAt time.Time `json:"paid_at"`
+	// 備考
+	Note string `json:"note,omitempty"`
+}
+
+func NewPaymentResponse(payment *domain.Payment) *PaymentResponse {
+	return &PaymentResponse{
+		Id:         payment.Id,
+		LiveId:     payment.LiveId,
+		MemberId:   payment.MemberId,
+		PlayerName: payment.PlayerName,
+		Kind:       payment.Kind,
+		Method:     payment.Method,
+		Amount:     payment.Amount,
+		PaidAt:     payment.PaidAt,
+		Note:       payment.Note,
+	}
+}
+
+type ReconciliationResponse struct {
+	// ライブごとの残高
+	Live []*LiveReconciliationResponsePart `json:"live,omitempty"`
+	// メンバーごとの残高
+	Player []*PlayerBalanceResponsePart `json:"player,omitempty"`
+}
+
+func NewReconciliationResponse(reconciliation *domain.Reconciliation) *ReconciliationResponse {
+	var lives []*LiveReconciliationResponsePart
+	for _, live := range reconciliation.Live {
+		lives = append(lives, &LiveReconciliationResponsePart{
+			Live:        NewLiveResponse(live.Live),
+			Player:      newPlayerBalanceResponseParts(live.Player),
+			Due:         live.Due,
+			Paid:        live.Paid,
+			Outstanding: live.Outstanding,
+		})
+	}
+	return &ReconciliationResponse{
+		Live:   lives,
+		Player: newPlayerBalanceResponseParts(reconciliation.Player),
+	}
+}
+
+type LiveReconciliationResponsePart struct {
+	// ライブ
+	Live *LiveResponse `json:"live"`
+	// メンバーごとの残高
+	Player []*PlayerBalanceResponsePart `json:"player,omitempty"`
+	// 請求額の合計
+	Due int `json:"due"`
+	// 支払済み額の合計
+	Paid int `json:"paid"`
+	// 未払い額の合計
+	Outstanding int `json:"outstanding"`
+}
+
+type PlayerBalanceResponsePart struct {
+	Name        string `json:"name"`
+	Due         int    `json:"due"`
+	Paid        int    `json:"paid"`
+	Outstanding int    `json:"outstanding"`
+}
+
+func newPlayerBalanceResponseParts(balances []*domain.PlayerBalance) []*PlayerBalanceResponsePart {
+	var parts []*PlayerBalanceResponsePart
+	for _, balance := range balances {
+		parts = append(parts, &PlayerBalanceResponsePart{
+			Name:        balance.Name,
+			Due:         balance.Due,
+			Paid:        balance.Paid,
+			Outstanding: balance.Outstanding,
+		})
+	}
+	return parts
+}
+
+type TimetableResponse struct {
+	// ライブID
+	LiveId int `json:"live_id"`
+	// 開場時刻
+	OpenTime domain.Clock `json:"open_time,omitempty"`
+	// 開演時刻
+	StartTime domain.Clock `json:"start_time"`
+	// 終演時刻
+	CloseTime domain.Clock `json:"close_time,omitempty"`
+	// 転換時間(分)
+	Changeover int `json:"changeover"`
+	// バンドごとの出演時刻
+	Slot []*TimetableSlotResponsePart `json:"slot,omitempty"`
+	// 最後のバンドの終了時刻
+	EndTime domain.Clock `json:"end_time"`
+}
+
+func NewTimetableResponse(timetable *domain.Timetable) *TimetableResponse {
+	var slots []*TimetableSlotResponsePart
+	for _, slot := range timetable.Slot {
+		slots = append(slots, &TimetableSlotResponsePart{
+			Name:  slot.Name,
+			Turn:  slot.Turn,
+			Start: slot.Start,
+			End:   slot.End,
+		})
+	}
+	return &TimetableResponse{
+		LiveId:     timetable.LiveId,
+		OpenTime:   timetable.OpenTime,
+		StartTime:  timetable.StartTime,
+		CloseTime:  timetable.CloseTime,
+		Changeover: timetable.Changeover,
+		Slot:       slots,
+		EndTime:    timetable.EndTime,
+	}
+}
+
+type TimetableSlotResponsePart struct {
+	// バンド名
+	Name string `json:"name"`
+	// 出演順
+	Turn int `json:"turn"`
+	// 開始時刻
+	Start domain.Clock `json:"start"`
+	// 終了時刻
+	End domain.Clock `json:"end"`
+}
+
+type ConflictResponse struct {
+	// 重複の種類
+	Kind domain.ConflictKind `json:"kind"`
+	// メンバーの名前
+	PlayerName string `json:"player_name"`
+	// ライブID
+	LiveId int `json:"live_id"`
+	// 出演順
+	Turn int `json:"turn"`
+	// 重複相手のライブID
+	OtherLiveId int `json:"other_live_id"`
+	// 重複相手の出演順
+	OtherTurn int `json:"other_turn"`
+}
+
+func NewConflictResponses(conflicts []*domain.Conflict) []*ConflictResponse {
+	responses := []*ConflictResponse{}
+	for _, conflict := range conflicts {
+		responses = append(responses, &ConflictResponse{
+			Kind:        conflict.Kind,
+			PlayerName:  conflict.PlayerName,
+			LiveId:      conflict.LiveId,
+			Turn:        conflict.Turn,
+			OtherLiveId: conflict.OtherLiveId,
+			OtherTurn:   conflict.OtherTurn,
+		})
+	}
+	return responses
+}
+
+type BandMemberRegisterResponse struct {
+	// 登録または置き換えたメンバー
+	Member *PlayerRequest `json:"member"`
+	// 登録または置き換えたメンバーの出演の重複
+	Conflict []*ConflictResponse `json:"conflict"`
+}
+
+type LineupResponse struct {
+	// 登録した出演バンド
+	Band []*LineupBandRequest `json:"band"`
+	// 登録後のライブの出演の重複
+	Conflict []*ConflictResponse `json:"conflict"`
+}
+
+type BandValidationResponsePart struct {
+	// バンド名
+	Name string `json:"name"`
+	// 出演順
+	Turn int `json:"turn"`
+	// ルールを満たしているか
+	Valid bool `json:"valid"`
+	// 満たしていないルール
+	Violation []string `json:"violation,omitempty"`
+}
+
+func NewBandValidationResponsePart(validation *domain.BandValidation) *BandValidationResponsePart {
+	return &BandValidationResponsePart{
+		Name:      validation.Name,
+		Turn:      validation.Turn,
+		Valid:     validation.Valid,
+		Violation: validation.Violation,
+	}
+}
+
+type LineupRuleResponse struct {
+	// ライブID
+	LiveId int `json:"live_id"`
+	// パートごとの人数のルール
+	Part []*PartRuleResponsePart `json:"part"`
+	// 最少人数
+	MinMembers int `json:"min_members"`
+	// 最大人数
+	MaxMembers int `json:"max_members"`
+	// Gt.Vo. を Vo. の人数に含めるか
+	GtVoCountsAsVo bool `json:"gtvo_counts_as_vo"`
+	// Gt.Vo. を Gt. の人数に含めるか
+	GtVoCountsAsGt bool `json:"gtvo_counts_as_gt"`
+}
+
+func NewLineupRuleResponse(rule *domain.LineupRule) *LineupRuleResponse {
+	parts := []*PartRuleResponsePart{}
+	for _, part := range rule.Part {
+		parts = append(parts, &PartRuleResponsePart{Part: part.Part, Min: part.Min, Max: part.Max})
+	}
+	return &LineupRuleResponse{
+		LiveId:         rule.LiveId,
+		Part:           parts,
+		MinMembers:     rule.MinMembers,
+		MaxMembers:     rule.MaxMembers,
+		GtVoCountsAsVo: rule.GtVoCountsAsVo,
+		GtVoCountsAsGt: rule.GtVoCountsAsGt,
+	}
+}
+
+type PartRuleResponsePart struct {
+	Part domain.Part `json:"part"`
+	Min  int         `json:"min"`
+	Max  int         `json:"max"`
+}
+
+type RunningOrderResponse struct {
+	// ライブID
+	LiveId int `json:"live_id"`
+	// 現在の出演順を提案する並びで並べたもの(そのまま適用に使える)
+	Turns []int `json:"turns"`
+	// 提案する並びのバンド
+	Band []*RunningOrderBandResponsePart `json:"band"`
+	// ペナルティの合計
+	Score int `json:"score"`
+	// ペナルティの内訳
+	Explanation []*ScoreItemResponsePart `json:"explanation"`
+}
+
+func NewRunningOrderResponse(runningOrder *domain.RunningOrder) *RunningOrderResponse {
+	bands := []*RunningOrderBandResponsePart{}
+	for i, band := range runningOrder.Band {
+		bands = append(bands, &RunningOrderBandResponsePart{Turn: i + 1, CurrentTurn: band.Turn, Name: band.Name})
+	}
+	explanation := []*ScoreItemResponsePart{}
+	for _, item := range runningOrder.Explanation {
+		explanation = append(explanation, &ScoreItemResponsePart{Turn: item.Turn, NextTurn: item.NextTurn, Reason: item.Reason, Penalty: item.Penalty})
+	}
+	turns := runningOrder.Turns
+	if turns == nil {
+		turns = []int{}
+	}
+	return &RunningOrderResponse{
+		LiveId:      runningOrder.LiveId,
+		Turns:       turns,
+		Band:        bands,
+		Score:       runningOrder.Score,
+		Explanation: explanation,
+	}
+}
+
+type RunningOrderBandResponsePart struct {
+	// 提案する出演順
+	Turn int `json:"turn"`
+	// 現在の出演順
+	CurrentTurn int    `json:"current_turn"`
+	Name        string `json:"name"`
+}
+
+type ScoreItemResponsePart struct {
+	Turn     int    `json:"turn"`
+	NextTurn int    `json:"next_turn"`
+	Reason   string `json:"reason"`
+	Penalty  int    `json:"penalty"`
+}
+
+type BandProfileResponse struct {
+	// バンドID
+	Id int `json:"id"`
+	// バンド名
+	Name string `json:"name"`
+	// 既定のメンバー
+	Member []*MemberResponsePart `json:"member"`
+}
+
+func NewBandProfileResponse(profile *domain.BandProfile) *BandProfileResponse {
+	members := []*MemberResponsePart{}
+	for _, player := range profile.Player {
+		members = append(members, NewPlayerResponse(player))
+	}
+	return &BandProfileResponse{Id: profile.Id, Name: profile.Name, Member: members}
+}
+
+// BandHistoryResponse バンドプロフィールに紐づく出演バンドの1件
+type BandHistoryResponse struct {
+	// ライブ ID
+	LiveId int `json:"live_id"`
+	// 出演時のバンド名
+	Name string `json:"name"`
+	// 出演順
+	Turn int `json:"turn"`
+	// 持ち時間(分)
+	SetLength int `json:"set_length"`
+}
+
+func NewBandHistoryResponses(bands []*domain.Band) []*BandHistoryResponse {
+	response := []*BandHistoryResponse{}
+	for _, band := range bands {
+		response = append(response, &BandHistoryResponse{LiveId: band.LiveId, Name: band.Name, Turn: band.Turn, SetLength: band.SetLength})
 	}
+	return response
+}
+
+type PartDefinitionResponse struct {
+	Code        domain.Part         `json:"code"`
+	DisplayName string              `json:"display_name"`
+	Category    domain.PartCategory `json:"category"`
+	SortOrder   int                 `json:"sort_order"`
+}
+
+func NewPartDefinitionResponse(part *domain.PartDefinition) *PartDefinitionResponse {
+	return &PartDefinitionResponse{Code: part.Code, DisplayName: part.DisplayName, Category: part.Category, SortOrder: part.SortOrder}
+}
+
+type MemberHistoryResponse struct {
+	// メンバーID
+	MemberId int `json:"member_id"`
+	// 表示名
+	Name string `json:"name"`
+	// 出演したライブの数
+	LiveCount int `json:"live_count"`
+	// バンドごとの出演回数
+	Band []*MemberHistoryResponseBand `json:"band"`
+	// 出演記録
+	Appearance []*MemberHistoryResponseAppearance `json:"appearance"`
+}
+
+type MemberHistoryResponseBand struct {
+	// バンド名
+	Name string `json:"name"`
+	// 出演回数
+	Count int `json:"count"`
+}
+
+type MemberHistoryResponseAppearance struct {
+	// ライブID
+	LiveId int `json:"live_id"`
+	// ライブ名
+	LiveName string `json:"live_name"`
+	// 場所
+	Location string `json:"location"`
+	// 日付
+	Date time.Time `json:"date"`
+	// バンド名
+	BandName string `json:"band_name"`
+	// 出演順
+	Turn int `json:"turn"`
+	// 担当したパート
+	Part domain.Part `json:"part"`
+}
+
+func NewMemberHistoryResponse(history *domain.PerformanceHistory) *MemberHistoryResponse {
+	response := &MemberHistoryResponse{
+		MemberId:   history.Member.Id,
+		Name:       history.Member.Name,
+		LiveCount:  history.LiveCount,
+		Band:       []*MemberHistoryResponseBand{},
+		Appearance: []*MemberHistoryResponseAppearance{},
+	}
+	for _, band := range history.Band {
+		response.Band = append(response.Band, &MemberHistoryResponseBand{Name: band.Name, Count: band.Count})
+	}
+	for _, appearance := range history.Appearance {
+		response.Appearance = append(response.Appearance, &MemberHistoryResponseAppearance{
+			LiveId:   appearance.LiveId,
+			LiveName: appearance.LiveName,
+			Location: appearance.Location,
+			Date:     appearance.Date,
+			BandName: appearance.BandName,
+			Turn:     appearance.Turn,
+			Part:     appearance.Part,
+		})
+	}
+	return response
+}
+
+type EntryApplicationResponse struct {
+	// 申込ID
+	Id int `json:"id"`
+	// ライブ ID
+	LiveId int `json:"live_id"`
+	// 希望するバンド名
+	Name string `json:"name"`
+	// バンドプロフィールの ID
+	BandId int `json:"band_id,omitempty"`
+	// 出演するメンバー
+	Member []*MemberResponsePart `json:"member"`
+	// 希望する出演順
+	PreferredTurn int `json:"preferred_turn,omitempty"`
+	// 持ち時間(分)
+	SetLength int `json:"set_length"`
+	// 備考
+	Note string `json:"note"`
+	// 状態
+	Status domain.ApplicationStatus `json:"status"`
+	// 承認して登録した出演順
+	Turn int `json:"turn,omitempty"`
+	// 申込日時
+	AppliedAt time.Time `json:"applied_at"`
+}
+
+func NewEntryApplicationResponse(application *domain.EntryApplication) *EntryApplicationResponse {
+	members := []*MemberResponsePart{}
+	for _, player := range application.Player {
+		members = append(members, NewPlayerResponse(player))
+	}
+	return &EntryApplicationResponse{
+		Id:            application.Id,
+		LiveId:        application.LiveId,
+		Name:          application.Name,
+		BandId:        application.BandId,
+		Member:        members,
+		PreferredTurn: application.PreferredTurn,
+		SetLength:     application.SetLength,
+		Note:          application.Note,
+		Status:        application.Status,
+		Turn:          application.Turn,
+		AppliedAt:     application.AppliedAt,
+	}
+}
+
+type EntryApplicationApproveResponse struct {
+	// 承認した申込
+	Application *EntryApplicationResponse `json:"application"`
+	// 承認後のライブの出演の重複
+	Conflict []*ConflictResponse `json:"conflict"`
 }
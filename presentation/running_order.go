@@ -0,0 +1,60 @@
+package presentation
+
+import (
+	"github.com/labstack/echo/v4"
+	"live-scheduler/domain"
+	"net/http"
+	"strconv"
+)
+
+type RunningOrderHandler struct {
+	runningOrderService domain.RunningOrderService
+	bandService         domain.BandService
+}
+
+func NewRunningOrderHandler(runningOrderService domain.RunningOrderService, bandService domain.BandService) *RunningOrderHandler {
+	return &RunningOrderHandler{runningOrderService: runningOrderService, bandService: bandService}
+}
+
+// PostRunningOrder 出演順を提案する。登録内容は変更しない
+func (h *RunningOrderHandler) PostRunningOrder(context echo.Context) error {
+	liveId, err := strconv.ParseInt(context.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	request := new(RunningOrderRequest)
+	if err := context.Bind(request); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := context.Validate(request); err != nil {
+		return err
+	}
+	runningOrder, err := h.runningOrderService.Propose(int(liveId), request.ToModel())
+	if err != nil {
+		return err
+	}
+	return context.JSON(http.StatusOK, NewRunningOrderResponse(runningOrder))
+}
+
+// PostRunningOrderApply 提案された出演順を適用し、適用後の出演バンドを返す
+func (h *RunningOrderHandler) PostRunningOrderApply(context echo.Context) error {
+	liveId, err := strconv.ParseInt(context.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	request := new(BandOrderRequest)
+	if err := context.Bind(request); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := context.Validate(request); err != nil {
+		return err
+	}
+	if err := h.runningOrderService.Apply(int(liveId), request.Turns); err != nil {
+		return err
+	}
+	bands, err := h.bandService.GetByLiveId(int(liveId))
+	if err != nil {
+		return err
+	}
+	return context.JSON(http.StatusOK, NewBandListResponse(bands))
+}
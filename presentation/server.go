@@ -16,6 +16,9 @@ type LiveHandler struct {
 	bandService       domain.BandService
 	bandMemberService domain.BandMemberService
 	playerService     domain.PlayerService
+	lineupService     domain.LineupService
+	lineupRuleService domain.LineupRuleService
+	partService       domain.PartService
 }
 
 func NewLiveHandler(
@@ -23,13 +26,19 @@ func NewLiveHandler(
 	liveDescService domain.LiveDescService,
 	bandService domain.BandService,
 	bandMemberService domain.BandMemberService,
-	playerService domain.PlayerService) *LiveHandler {
+	playerService domain.PlayerService,
+	lineupService domain.LineupService,
+	lineupRuleService domain.LineupRuleService,
+	partService domain.PartService) *LiveHandler {
 	return &LiveHandler{
 		liveService:       liveService,
 		liveDescService:   liveDescService,
 		bandService:       bandService,
 		bandMemberService: bandMemberService,
 		playerService:     playerService,
+		lineupService:     lineupService,
+		lineupRuleService: lineupRuleService,
+		partService:       partService,
 	}
 }
 
@@ -46,13 +55,13 @@ func (h *LiveHandler) GetLives(context echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 
-	lives, err := h.liveService.GetByPeriod(&start, &end)
+	liveModels, err := h.liveDescService.GetByPeriod(&start, &end)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return err
 	}
 	var liveResponse []*LiveResponse
-	for _, e := range lives {
-		liveResponse = append(liveResponse, NewLiveResponse(e))
+	for _, e := range liveModels {
+		liveResponse = append(liveResponse, NewLiveListResponse(e))
 	}
 	return context.JSON(http.StatusOK, liveResponse)
 }
@@ -64,9 +73,18 @@ func (h *LiveHandler) GetLive(context echo.Context) error {
 	}
 	liveModel, err := h.liveDescService.GetById(int(liveId))
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return err
 	}
-	return context.JSON(http.StatusOK, NewLiveDescResponse(liveModel))
+	setETag(context, liveModel.Version)
+	setLastModified(context, liveModel.UpdatedAt)
+	if notModified(context, liveModel.Version, liveModel.UpdatedAt) {
+		return context.NoContent(http.StatusNotModified)
+	}
+	validations, err := h.lineupRuleService.Validate(liveModel)
+	if err != nil {
+		return err
+	}
+	return context.JSON(http.StatusOK, NewLiveDescResponse(liveModel, validations))
 }
 
 func (h *LiveHandler) PostLive(context echo.Context) error {
@@ -79,7 +97,7 @@ func (h *LiveHandler) PostLive(context echo.Context) error {
 	}
 	err := h.liveService.Register(live.ToModel())
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return err
 	}
 	return context.JSON(http.StatusOK, live)
 }
@@ -92,11 +110,16 @@ func (h *LiveHandler) PatchLive(context echo.Context) error {
 	if err := context.Validate(live); err != nil {
 		return err
 	}
-	err := h.liveService.Update(live.ToModel())
+	version, err := ifMatch(context)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return err
 	}
-	return context.JSON(http.StatusOK, live)
+	updated, err := h.liveService.Update(live.Id, version, live.ToModel())
+	if err != nil {
+		return err
+	}
+	setETag(context, updated.Version)
+	return context.JSON(http.StatusOK, NewLiveResponse(updated))
 }
 
 func (h *LiveHandler) DeleteLive(context echo.Context) error {
@@ -104,9 +127,25 @@ func (h *LiveHandler) DeleteLive(context echo.Context) error {
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
-	err = h.liveService.Delete(int(liveId))
+	var dryRun bool
+	err = echo.QueryParamsBinder(context).Bool("dry_run", &dryRun).BindError()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if dryRun {
+		deletion, err := h.liveService.PreviewDelete(int(liveId))
+		if err != nil {
+			return err
+		}
+		return context.JSON(http.StatusOK, NewLiveDeletionResponse(deletion))
+	}
+	version, err := ifMatch(context)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return err
+	}
+	err = h.liveService.Delete(int(liveId), version)
+	if err != nil {
+		return err
 	}
 	return context.NoContent(http.StatusOK)
 }
@@ -132,13 +171,27 @@ func (h *LiveHandler) GetBand(context echo.Context) error {
 	}
 	bands, err := h.bandService.GetByLiveId(int(liveId))
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return err
 	}
-	var response []*BandResponsePart
-	for _, e := range bands {
-		response = append(response, NewBandResponsePart(e))
+	return context.JSON(http.StatusOK, NewBandListResponse(bands))
+}
+
+// GetBandByTurn 出演バンドを1件返す。ETag に更新・削除の If-Match に指定するバージョンを設定する
+func (h *LiveHandler) GetBandByTurn(context echo.Context) error {
+	liveId, err := strconv.ParseInt(context.Param("live_id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
-	return context.JSON(http.StatusOK, response)
+	turn, err := strconv.ParseInt(context.Param("turn"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	band, err := h.bandService.GetByTurn(int(liveId), int(turn))
+	if err != nil {
+		return err
+	}
+	setETag(context, band.Version)
+	return context.JSON(http.StatusOK, NewBandResponsePart(band))
 }
 
 func (h *LiveHandler) PostBand(context echo.Context) error {
@@ -154,12 +207,12 @@ func (h *LiveHandler) PostBand(context echo.Context) error {
 		return err
 	}
 	if band.LiveId != int(liveId) {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, "live_id does not match the path")
 	}
 
 	err = h.bandService.Register(band.ToModel())
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return err
 	}
 
 	return context.JSON(http.StatusOK, band)
@@ -182,11 +235,16 @@ func (h *LiveHandler) PatchBand(context echo.Context) error {
 	if err := context.Validate(band); err != nil {
 		return err
 	}
-	err = h.bandService.Update(int(liveId), int(turn), band.ToModel())
+	version, err := ifMatch(context)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return err
 	}
-	return context.JSON(http.StatusOK, band)
+	updated, err := h.bandService.Update(int(liveId), int(turn), version, band.Turn, band.ToModel())
+	if err != nil {
+		return err
+	}
+	setETag(context, updated.Version)
+	return context.JSON(http.StatusOK, NewBandResponsePart(updated))
 }
 
 func (h *LiveHandler) DeleteBand(context echo.Context) error {
@@ -198,18 +256,239 @@ func (h *LiveHandler) DeleteBand(context echo.Context) error {
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
-	err = h.bandService.Delete(int(liveId), int(turn))
+	version, err := ifMatch(context)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return err
+	}
+	err = h.bandService.Delete(int(liveId), int(turn), version)
+	if err != nil {
+		return err
 	}
 	return context.NoContent(http.StatusOK)
 }
 
+func (h *LiveHandler) PostBandMove(context echo.Context) error {
+	liveId, err := strconv.ParseInt(context.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	request := new(BandMoveRequest)
+	if err := context.Bind(request); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := context.Validate(request); err != nil {
+		return err
+	}
+	err = h.bandService.Move(int(liveId), request.From, request.To)
+	if err != nil {
+		return err
+	}
+	return h.GetBand(context)
+}
+
+func (h *LiveHandler) PostBandSwap(context echo.Context) error {
+	liveId, err := strconv.ParseInt(context.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	request := new(BandSwapRequest)
+	if err := context.Bind(request); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := context.Validate(request); err != nil {
+		return err
+	}
+	err = h.bandService.Swap(int(liveId), request.Turn1, request.Turn2)
+	if err != nil {
+		return err
+	}
+	return h.GetBand(context)
+}
+
+func (h *LiveHandler) PutBandOrder(context echo.Context) error {
+	liveId, err := strconv.ParseInt(context.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	request := new(BandOrderRequest)
+	if err := context.Bind(request); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := context.Validate(request); err != nil {
+		return err
+	}
+	err = h.bandService.Reorder(int(liveId), request.Turns)
+	if err != nil {
+		return err
+	}
+	return h.GetBand(context)
+}
+
+func (h *LiveHandler) PostBandCompact(context echo.Context) error {
+	liveId, err := strconv.ParseInt(context.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	err = h.bandService.Compact(int(liveId))
+	if err != nil {
+		return err
+	}
+	return h.GetBand(context)
+}
+
+func (h *LiveHandler) PostLineup(context echo.Context) error {
+	liveId, err := strconv.ParseInt(context.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	lineup := new(LineupRequest)
+	if err := context.Bind(lineup); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := context.Validate(lineup); err != nil {
+		return err
+	}
+	catalog, err := h.partService.Parser()
+	if err != nil {
+		return err
+	}
+	bands, err := lineup.ToModel(catalog, int(liveId))
+	if err != nil {
+		return err
+	}
+	conflicts, err := h.lineupService.Register(int(liveId), bands)
+	if err != nil {
+		return err
+	}
+	return context.JSON(http.StatusOK, &LineupResponse{Band: lineup.Band, Conflict: NewConflictResponses(conflicts)})
+}
+
+func (h *LiveHandler) GetBandMember(context echo.Context) error {
+	liveId, turn, err := bandKey(context)
+	if err != nil {
+		return err
+	}
+	players, err := h.bandMemberService.GetByLiveIdAndTurn(liveId, turn)
+	if err != nil {
+		return err
+	}
+	var response []*MemberResponsePart
+	for _, p := range players {
+		response = append(response, NewPlayerResponse(p))
+	}
+	return context.JSON(http.StatusOK, response)
+}
+
+func (h *LiveHandler) PostBandMember(context echo.Context) error {
+	liveId, turn, err := bandKey(context)
+	if err != nil {
+		return err
+	}
+	player := new(PlayerRequest)
+	if err := context.Bind(player); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := context.Validate(player); err != nil {
+		return err
+	}
+	catalog, err := h.partService.Parser()
+	if err != nil {
+		return err
+	}
+	bandMember, err := player.ToBandMember(catalog, liveId, turn)
+	if err != nil {
+		return err
+	}
+	conflicts, err := h.bandMemberService.Register(bandMember)
+	if err != nil {
+		return err
+	}
+	return context.JSON(http.StatusOK, &BandMemberRegisterResponse{Member: player, Conflict: NewConflictResponses(conflicts)})
+}
+
+func (h *LiveHandler) PutBandMember(context echo.Context) error {
+	liveId, turn, err := bandKey(context)
+	if err != nil {
+		return err
+	}
+	request := new(BandMemberReplaceRequest)
+	if err := context.Bind(request); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := context.Validate(request); err != nil {
+		return err
+	}
+	catalog, err := h.partService.Parser()
+	if err != nil {
+		return err
+	}
+	current, err := request.Current.ToBandMember(catalog, liveId, turn)
+	if err != nil {
+		return err
+	}
+	replacement, err := request.Replacement.ToBandMember(catalog, liveId, turn)
+	if err != nil {
+		return err
+	}
+	conflicts, err := h.bandMemberService.Update(current, replacement)
+	if err != nil {
+		return err
+	}
+	return context.JSON(http.StatusOK, &BandMemberRegisterResponse{Member: &request.Replacement, Conflict: NewConflictResponses(conflicts)})
+}
+
+func (h *LiveHandler) DeleteBandMember(context echo.Context) error {
+	liveId, turn, err := bandKey(context)
+	if err != nil {
+		return err
+	}
+	player := new(PlayerRequest)
+	if err := context.Bind(player); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := context.Validate(player); err != nil {
+		return err
+	}
+	catalog, err := h.partService.Parser()
+	if err != nil {
+		return err
+	}
+	bandMember, err := player.ToBandMember(catalog, liveId, turn)
+	if err != nil {
+		return err
+	}
+	err = h.bandMemberService.Delete(bandMember)
+	if err != nil {
+		return err
+	}
+	return context.NoContent(http.StatusOK)
+}
+
+// bandKey パスパラメータからバンドの主キー(ライブID, 出演順)を取得する
+func bandKey(context echo.Context) (int, int, error) {
+	liveId, err := strconv.ParseInt(context.Param("live_id"), 10, 64)
+	if err != nil {
+		return 0, 0, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	turn, err := strconv.ParseInt(context.Param("turn"), 10, 64)
+	if err != nil {
+		return 0, 0, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	return int(liveId), int(turn), nil
+}
+
 func (h *LiveHandler) GetPart(context echo.Context) error {
-	part := domain.Part(context.QueryParam("part"))
+	catalog, err := h.partService.Parser()
+	if err != nil {
+		return err
+	}
+	part, err := catalog.Parse(context.QueryParam("part"))
+	if err != nil {
+		return err
+	}
 	players, err := h.playerService.GetByPart(&part)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return err
 	}
 	var response []*MemberResponsePart
 	for _, p := range players {
@@ -226,9 +505,17 @@ func (h *LiveHandler) PostPart(context echo.Context) error {
 	if err := context.Validate(player); err != nil {
 		return err
 	}
-	err := h.playerService.Register(player.ToModel())
+	catalog, err := h.partService.Parser()
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return err
+	}
+	model, err := player.ToModel(catalog)
+	if err != nil {
+		return err
+	}
+	err = h.playerService.Register(model)
+	if err != nil {
+		return err
 	}
 	return context.JSON(http.StatusOK, player)
 }
@@ -241,9 +528,17 @@ func (h *LiveHandler) DeletePart(context echo.Context) error {
 	if err := context.Validate(player); err != nil {
 		return err
 	}
-	err := h.playerService.Delete(player.ToModel())
+	catalog, err := h.partService.Parser()
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return err
+	}
+	model, err := player.ToModel(catalog)
+	if err != nil {
+		return err
+	}
+	err = h.playerService.Delete(model)
+	if err != nil {
+		return err
 	}
 	return context.JSON(http.StatusOK, player)
 }
@@ -0,0 +1,113 @@
+package presentation
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"live-scheduler/domain"
+	"live-scheduler/infra/memory"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPatchBand(t *testing.T) {
+	tests := []struct {
+		// テスト名
+		testName string
+		// リクエストボディ
+		body string
+		// If-Match で指定するバージョンを 1番目のバンドのバージョンからずらす量
+		staleBy int
+		// ステータスコードの期待値
+		expectedStatus int
+		// 出演順ごとのバンド名の期待値
+		expectedBands map[int]string
+		// 出演順ごとのメンバーの期待値
+		expectedMembers map[int][]string
+	}{
+		{
+			testName:        "正常系_空いている出演順へメンバーと合わせて移動する",
+			body:            `{"turn": 3, "set_length": 25}`,
+			expectedStatus:  http.StatusOK,
+			expectedBands:   map[int]string{2: "band2", 3: "band1"},
+			expectedMembers: map[int][]string{2: {"bassist"}, 3: {"drummer"}},
+		},
+		{
+			testName:        "正常系_埋まっている出演順へ移動すると間のバンドをずらす",
+			body:            `{"turn": 2}`,
+			expectedStatus:  http.StatusOK,
+			expectedBands:   map[int]string{1: "band2", 2: "band1"},
+			expectedMembers: map[int][]string{1: {"bassist"}, 2: {"drummer"}},
+		},
+		{
+			testName:        "異常系_古いバージョンでは移動しない",
+			body:            `{"turn": 3}`,
+			staleBy:         -1,
+			expectedStatus:  http.StatusPreconditionFailed,
+			expectedBands:   map[int]string{1: "band1", 2: "band2"},
+			expectedMembers: map[int][]string{1: {"drummer"}, 2: {"bassist"}},
+		},
+	}
+
+	for _, tc := range tests {
+		// given
+		store := memory.NewStore()
+		repositories := &domain.Repositories{
+			Live:       memory.NewLiveRepositoryImpl(store),
+			Band:       memory.NewBandRepositoryImpl(store),
+			BandMember: memory.NewBandMemberRepositoryImpl(store),
+			Player:     memory.NewPlayerRepositoryImpl(store),
+		}
+		require.Nil(t, repositories.Live.Create(&domain.Live{Name: "live", Date: time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC), Status: domain.LiveDraft}))
+		band1 := &domain.Band{Name: "band1", LiveId: 1, Turn: 1, SetLength: 20}
+		require.Nil(t, repositories.Band.Create(band1))
+		require.Nil(t, repositories.Band.Create(&domain.Band{Name: "band2", LiveId: 1, Turn: 2, SetLength: 20}))
+		for turn, player := range []*domain.Member{{Name: "drummer", Part: []domain.Part{domain.Dr}}, {Name: "bassist", Part: []domain.Part{domain.Ba}}} {
+			require.Nil(t, repositories.Player.Create(player))
+			require.Nil(t, repositories.BandMember.Create(&domain.BandMember{LiveId: 1, Turn: turn + 1, MemberId: player.Id, MemberPart: player.Part[0]}))
+		}
+		unitOfWork := domain.NewGuardedUnitOfWork(memory.NewUnitOfWorkImpl(store))
+		timetableService := domain.NewTimetableServiceImpl(repositories.Live, repositories.Band, 10)
+		bandService := domain.NewBandServiceImpl(repositories.Band, unitOfWork, timetableService)
+		handler := NewLiveHandler(nil, nil, bandService, nil, nil, nil, nil, nil)
+		e := echo.New()
+		e.Validator = NewCustomValidator()
+		e.HTTPErrorHandler = ErrorHandler
+		e.PATCH("/live/:live_id/band/:turn", handler.PatchBand)
+		request := httptest.NewRequest(http.MethodPatch, "/live/1/band/1", strings.NewReader(tc.body))
+		request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		request.Header.Set("If-Match", etag(band1.Version+tc.staleBy))
+		recorder := httptest.NewRecorder()
+
+		// when
+		e.ServeHTTP(recorder, request)
+
+		// then
+		assert.Equal(t, tc.expectedStatus, recorder.Code, fmt.Sprintf("テスト名: %s", tc.testName))
+		if tc.expectedStatus == http.StatusOK {
+			var response BandResponsePart
+			require.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+			assert.Equal(t, "band1", response.Name, fmt.Sprintf("テスト名: %s", tc.testName))
+			assert.Equal(t, recorder.Header().Get("ETag"), etag(response.Version), fmt.Sprintf("テスト名: %s", tc.testName))
+		}
+		bands, err := repositories.Band.FindByLiveId(1)
+		require.Nil(t, err)
+		actualBands := map[int]string{}
+		actualMembers := map[int][]string{}
+		for _, band := range bands {
+			actualBands[band.Turn] = band.Name
+			players, err := repositories.BandMember.FindByLiveIdAndTurn(1, band.Turn)
+			require.Nil(t, err)
+			for _, player := range players {
+				actualMembers[band.Turn] = append(actualMembers[band.Turn], player.Name)
+			}
+		}
+		assert.Equal(t, tc.expectedBands, actualBands, fmt.Sprintf("テスト名: %s", tc.testName))
+		assert.Equal(t, tc.expectedMembers, actualMembers, fmt.Sprintf("テスト名: %s", tc.testName))
+	}
+}
@@ -0,0 +1,29 @@
+package presentation
+
+import (
+	"github.com/labstack/echo/v4"
+	"live-scheduler/domain"
+	"net/http"
+	"strconv"
+)
+
+type SettlementHandler struct {
+	settlementService domain.SettlementService
+}
+
+func NewSettlementHandler(settlementService domain.SettlementService) *SettlementHandler {
+	return &SettlementHandler{settlementService: settlementService}
+}
+
+func (h *SettlementHandler) GetSettlement(context echo.Context) error {
+	liveId, err := strconv.ParseInt(context.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	rule := domain.FeeRule(context.QueryParam("rule"))
+	settlement, err := h.settlementService.Calculate(int(liveId), rule)
+	if err != nil {
+		return err
+	}
+	return context.JSON(http.StatusOK, NewSettlementResponse(settlement))
+}
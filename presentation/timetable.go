@@ -0,0 +1,28 @@
+package presentation
+
+import (
+	"github.com/labstack/echo/v4"
+	"live-scheduler/domain"
+	"net/http"
+	"strconv"
+)
+
+type TimetableHandler struct {
+	timetableService domain.TimetableService
+}
+
+func NewTimetableHandler(timetableService domain.TimetableService) *TimetableHandler {
+	return &TimetableHandler{timetableService: timetableService}
+}
+
+func (h *TimetableHandler) GetTimetable(context echo.Context) error {
+	liveId, err := strconv.ParseInt(context.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	timetable, err := h.timetableService.GetByLiveId(int(liveId))
+	if err != nil {
+		return err
+	}
+	return context.JSON(http.StatusOK, NewTimetableResponse(timetable))
+}